@@ -1,13 +1,32 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
+	"math/rand/v2"
 	"os"
+	"os/signal"
+	"syscall"
 	"theatre-management-system/src/business"
+	"theatre-management-system/src/config"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/controllers"
-	"theatre-management-system/src/models"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
+	"theatre-management-system/src/metrics"
+	"theatre-management-system/src/migrations"
+	"theatre-management-system/src/openapi"
+	"theatre-management-system/src/outbox"
 	"theatre-management-system/src/repo"
+	"theatre-management-system/src/seed"
+	"theatre-management-system/src/server"
+	"theatre-management-system/src/tracing"
+	"theatre-management-system/src/webhooks"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -17,38 +36,161 @@ import (
 )
 
 func main() {
+	// Configuration: defaults < YAML file < environment < flags
+	cfg, options, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if options.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	command := ""
+	if len(options.Args) > 0 {
+		command = options.Args[0]
+	}
+
+	// Structured logs; records logged with a request's context carry its request ID
+	logger := logging.New(cfg.Logging, os.Stderr)
+	slog.SetDefault(logger)
+
+	// Tracing; spans from the migrate and seed commands are flushed on return, the server flushes its own on shutdown
+	tracerProvider, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
 	// Database connection
-	db := connectToDB()
+	db, pools := connectToDB(cfg.Database, logging.NewGormLogger(logger, cfg.Logging))
+	if cfg.Tracing.Enabled {
+		if err := tracing.InstrumentGorm(db); err != nil {
+			fatal("Failed to trace database queries", err)
+		}
+	}
+
+	// Schema changes and seeding must read what they just wrote, so they never use the replica
+	adminCtx := repo.WithPrimary(context.Background())
+
+	// "migrate <command>" manages the schema and exits instead of serving
+	if command == "migrate" {
+		if err := migrations.RunCLI(adminCtx, db, options.Args[1:], os.Stdout); err != nil {
+			fatal("Migrate command failed", err)
+		}
+		tracerProvider.Shutdown(context.Background())
+		return
+	}
+
+	// Apply pending migrations
+	if err := migrateDB(adminCtx, db); err != nil {
+		fatal("Failed to migrate database", err)
+	}
+
+	// "seed <set>" loads fixture data and exits instead of serving; the audit log attributes its changes to "seed"
+	if command == "seed" {
+		seedService := business.NewSeedService(repo.NewUnitOfWork(db))
+		if err := seed.RunCLI(logging.WithActor(adminCtx, constants.AuditActorSeed), seedService, options.Args[1:], os.Stdout); err != nil {
+			fatal("Seed command failed", err)
+		}
+		tracerProvider.Shutdown(context.Background())
+		return
+	}
 
-	// Auto-migrate database schema
-	if err := migrateDB(db); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+	// Metrics live on their own registry; both pools report their connection stats
+	appMetrics := metrics.New(metrics.NewRegistry())
+	for i, name := range []string{"primary", "replica"}[:len(pools)] {
+		if err := appMetrics.RegisterDBPool(name, pools[i]); err != nil {
+			fatal("Failed to register pool metrics", err)
+		}
 	}
 
 	// Setup Gin router
-	r := gin.Default()
+	if cfg.Logging.Level != constants.LogLevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	r := gin.New()
+
+	// Request IDs and spans first, so the access log, panics and query logs can all be correlated
+	r.Use(controllers.RequestID())
+	r.Use(controllers.Tracing())
+	r.Use(controllers.RequestLogger(logger))
+	if cfg.Metrics.Enabled {
+		r.Use(controllers.RequestMetrics(appMetrics))
+	}
+	r.Use(controllers.Recovery(logger))
 
 	// CORS configuration
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		AllowCredentials: true,
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowMethods:     cfg.CORS.AllowMethods,
+		AllowHeaders:     cfg.CORSAllowHeaders(),
+		ExposeHeaders:    []string{constants.HeaderRequestID},
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
 	}))
 
+	// Cancel queries that outlive the request deadline or the client connection
+	r.Use(controllers.RequestTimeout(cfg.Server.RequestTimeout, cfg.Server.ExportTimeout, cfg.Server.WriteTimeout-cfg.Server.RequestTimeout))
+
+	// Reject oversized bodies before they are read into memory
+	r.Use(controllers.MaxBodySize(int64(cfg.Server.MaxBodyBytes)))
+
+	// Configured page sizes for list endpoints
+	r.Use(controllers.Pagination(cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit))
+
+	// Reads follow a client's own writes to the primary until the replica has caught up
+	if cfg.Database.ReplicaURL != "" && cfg.Database.StickyWindow > 0 {
+		r.Use(controllers.ReadYourWrites(cfg.Database.StickyWindow, repo.WithPrimary))
+	}
+
+	// Require an API key for writes when enabled
+	if cfg.Auth.Enabled {
+		keys, _ := cfg.Auth.Keys() // already checked by Validate
+		r.Use(controllers.APIKeyAuth(cfg.Auth.Header, keys))
+	}
+
+	// Requests that break the OpenAPI document get a 400 before reaching a service; the document
+	// is generated once the routes are registered
+	contract := openapi.NewContract(openapi.ContractOptions{
+		Strict:            cfg.Contract.Strict,
+		ValidateResponses: cfg.Contract.ValidateResponses,
+	})
+	if cfg.Contract.Enabled {
+		r.Use(contract.Middleware())
+	}
+
 	// Initialize repositories
-	locationRepo := repo.NewLocationRepository(db)
-	theatreTypeRepo := repo.NewTheatreTypeRepository(db)
-	showTypeRepo := repo.NewShowTypeRepository(db)
-	theatreRepo := repo.NewTheatreRepository(db)
-	showRepo := repo.NewShowRepository(db)
+	uow := repo.NewUnitOfWork(db)
+
+	// Domain events are published once each change commits; subscribers register here
+	eventBus := business.NewEventBus()
+	eventBus.Subscribe(constants.EventAll, business.LogEvent)
 
 	// Initialize services
-	locationService := business.NewLocationService(locationRepo)
-	theatreTypeService := business.NewTheatreTypeService(theatreTypeRepo)
-	showTypeService := business.NewShowTypeService(showTypeRepo)
-	theatreService := business.NewTheatreService(theatreRepo, locationRepo, theatreTypeRepo)
-	showService := business.NewShowService(showRepo, theatreRepo, showTypeRepo)
+	deletePolicies := business.DeletePolicies{
+		LocationTheatres:    cfg.Delete.LocationTheatres,
+		TheatreTypeTheatres: cfg.Delete.TheatreTypeTheatres,
+		ShowTypeShows:       cfg.Delete.ShowTypeShows,
+		TheatreShows:        cfg.Delete.TheatreShows,
+	}
+	locationService := business.NewLocationService(uow, appMetrics, eventBus, deletePolicies)
+	theatreTypeService := business.NewTheatreTypeService(uow, appMetrics, eventBus, deletePolicies)
+	showTypeService := business.NewShowTypeService(uow, appMetrics, eventBus, deletePolicies)
+	theatreService := business.NewTheatreService(uow, appMetrics, eventBus, deletePolicies)
+	showService := business.NewShowService(uow, appMetrics, eventBus)
+	calendarService := business.NewCalendarService(uow.Theatres(), uow.Shows())
+	importService := business.NewImportService(uow, appMetrics, eventBus)
+	batchService := business.NewBatchService(uow, appMetrics, eventBus, deletePolicies)
+	cacheService := business.NewCacheService(cfg.Cache.DefaultTTL, cfg.Cache.CleanupInterval, appMetrics)
+	outboxService := business.NewOutboxService(uow)
+	webhookSender := webhooks.NewSender(cfg.Webhooks.Timeout)
+	webhookService := business.NewWebhookService(uow, webhookSender)
+	auditService := business.NewAuditService(uow, cfg.Audit.Retention)
+	trashPurger := business.NewTrashPurger(uow, cfg.Trash.Retention)
 
 	// Initialize controllers
 	locationController := controllers.NewLocationController(locationService)
@@ -56,68 +198,164 @@ func main() {
 	showTypeController := controllers.NewShowTypeController(showTypeService)
 	theatreController := controllers.NewTheatreController(theatreService)
 	showController := controllers.NewShowController(showService)
+	calendarController := controllers.NewCalendarController(calendarService)
+	importController := controllers.NewImportController(importService)
+	batchController := controllers.NewBatchController(batchService)
+	healthController := controllers.NewHealthController(db, cacheService)
+	outboxController := controllers.NewOutboxController(outboxService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	auditController := controllers.NewAuditController(auditService)
+	trashController := controllers.NewTrashController(locationService, theatreTypeService, showTypeService, theatreService, showService)
 
 	// Setup routes
-	setupRoutes(r, locationController, theatreTypeController, showTypeController, theatreController, showController)
+	setupRoutes(r, healthController, locationController, theatreTypeController, showTypeController, theatreController, showController, calendarController, importController, batchController, outboxController, webhookController, auditController, trashController)
+
+	// OpenAPI document describing the routes above, with Swagger UI to browse it
+	apiOptions := openapi.Options{}
+	if cfg.Auth.Enabled {
+		apiOptions.APIKeyHeader = cfg.Auth.Header
+	}
+	apiDoc := openapi.Generate(r.Routes(), apiOptions)
+	for _, route := range openapi.Undocumented(apiDoc, r.Routes()) {
+		slog.Warn("Route missing from the OpenAPI document", "route", route)
+	}
+	contract.Enforce(apiDoc)
+	r.GET(constants.OpenAPISpecPath, openapi.SpecHandler(apiDoc))
+	r.GET(constants.OpenAPIDocsPath+"/*filepath", openapi.DocsHandler(constants.OpenAPIDocsPath, constants.OpenAPISpecPath))
 
-	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Prometheus scrape endpoint, left out of the API description
+	if cfg.Metrics.Enabled {
+		r.GET(cfg.Metrics.Path, gin.WrapH(appMetrics.Handler()))
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Every change records its events in the outbox; the relay delivers them to the sinks,
+	// one of which queues deliveries for webhook subscriptions that the dispatcher then sends
+	workers := []interfaces.Worker{importService, eventBus, auditService, trashPurger}
+	if cfg.Outbox.RelayEnabled {
+		sinks, err := outbox.NewSinks(cfg.Outbox, os.Stdout, business.NewWebhookFanOut(uow))
+		if err != nil {
+			fatal("Failed to open outbox sinks", err)
+		}
+		workers = append(workers, outbox.Start(cfg.Outbox, uow.Outbox(), sinks))
 	}
-}
+	if cfg.Webhooks.DispatcherEnabled {
+		workers = append(workers, webhooks.Start(cfg.Webhooks, uow.Webhooks(), webhookSender))
+	}
+	workers = append(workers, tracerProvider)
+
+	// Start server; SIGINT or SIGTERM drains it, stops background imports, event deliveries, the outbox relay and the webhook dispatcher, flushes traces and closes the pools
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-// connectToDB establishes database connection
-func connectToDB() *gorm.DB {
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		databaseURL = constants.DefaultDatabaseURL
+	srv := server.New(cfg.Server, r, healthController, pools, workers...)
+	if err := srv.Run(ctx); err != nil {
+		fatal("Server error", err)
 	}
+}
 
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+// connectToDB connects to the primary, and to the read replica when one is configured
+func connectToDB(dbConfig config.DatabaseConfig, queryLogger logger.Interface) (*gorm.DB, []*sql.DB) {
+	gormConfig := &gorm.Config{
+		Logger: queryLogger,
+	}
 
+	db, sqlDB, err := openWithRetry("primary", dbConfig.URL, dbConfig, gormConfig)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal("Failed to connect to database", err)
 	}
+	pools := []*sql.DB{sqlDB}
 
-	log.Println("Database connection established")
-	return db
+	// List and search reads go to the replica; writes and transactions stay on the primary
+	if dbConfig.ReplicaURL != "" {
+		_, replicaDB, err := openWithRetry("replica", dbConfig.ReplicaURL, dbConfig, gormConfig)
+		if err != nil {
+			fatal("Failed to connect to read replica", err)
+		}
+		if err := repo.UseReadReplica(db, replicaDB); err != nil {
+			fatal("Failed to route reads to replica", err)
+		}
+		pools = append(pools, replicaDB)
+	}
+
+	slog.Info("Database connection established", "replica", dbConfig.ReplicaURL != "")
+	return db, pools
+}
+
+// openWithRetry opens a pool, retrying with jittered exponential backoff while the database is still starting
+func openWithRetry(name, url string, dbConfig config.DatabaseConfig, gormConfig *gorm.Config) (*gorm.DB, *sql.DB, error) {
+	backoff := dbConfig.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := gorm.Open(postgres.Open(url), gormConfig)
+		if err == nil {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return nil, nil, err
+			}
+			sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
+			sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
+			sqlDB.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+			sqlDB.SetConnMaxIdleTime(dbConfig.ConnMaxIdleTime)
+			return db, sqlDB, nil
+		}
+		if attempt >= dbConfig.ConnectAttempts {
+			return nil, nil, err
+		}
+
+		// Up to 25% jitter keeps API instances restarting together from retrying in lockstep
+		wait := backoff + rand.N(backoff/4+1)
+		slog.Warn("Database connection failed, retrying",
+			"database", name,
+			"attempt", attempt,
+			"max_attempts", dbConfig.ConnectAttempts,
+			"retry_in", wait.Round(time.Millisecond).String(),
+			"error", err,
+		)
+		time.Sleep(wait)
+		backoff = min(backoff*2, constants.DBConnectMaxBackoff)
+	}
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-// migrateDB runs database migrations
-func migrateDB(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&models.Location{},
-		&models.TheatreType{},
-		&models.ShowType{},
-		&models.Theatre{},
-		&models.Show{},
-	)
+// migrateDB applies any pending versioned migrations
+func migrateDB(ctx context.Context, db *gorm.DB) error {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ran, err := migrator.Up(ctx)
+	for _, migration := range ran {
+		slog.InfoContext(ctx, "Applied migration", "version", migration.Version, "name", migration.Name)
+	}
+	return err
 }
 
 // setupRoutes configures all API routes
 func setupRoutes(
 	r *gin.Engine,
+	healthController *controllers.HealthController,
 	locationController *controllers.LocationController,
 	theatreTypeController *controllers.TheatreTypeController,
 	showTypeController *controllers.ShowTypeController,
 	theatreController *controllers.TheatreController,
 	showController *controllers.ShowController,
+	calendarController *controllers.CalendarController,
+	importController *controllers.ImportController,
+	batchController *controllers.BatchController,
+	outboxController *controllers.OutboxController,
+	webhookController *controllers.WebhookController,
+	auditController *controllers.AuditController,
+	trashController *controllers.TrashController,
 ) {
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"message": "Theatre Management API is running",
-		})
-	})
+	// Health check endpoints
+	r.GET("/health", healthController.HealthCheck)
+	r.GET("/ready", healthController.ReadinessCheck)
+	r.GET("/live", healthController.LivenessCheck)
 
 	// API v1 routes
 	v1 := r.Group("/api/v1")
@@ -126,6 +364,7 @@ func setupRoutes(
 	locations := v1.Group("/locations")
 	{
 		locations.POST("", locationController.CreateLocation)
+		locations.POST("/batch", batchController.ExecuteBatch(constants.BatchResourceLocations))
 		locations.GET("", locationController.GetAllLocations)
 		locations.GET("/:id", locationController.GetLocationByID)
 		locations.PATCH("/:id", locationController.UpdateLocation)
@@ -139,6 +378,7 @@ func setupRoutes(
 	theatreTypes := v1.Group("/theatre-types")
 	{
 		theatreTypes.POST("", theatreTypeController.CreateTheatreType)
+		theatreTypes.POST("/batch", batchController.ExecuteBatch(constants.BatchResourceTheatreTypes))
 		theatreTypes.GET("", theatreTypeController.GetAllTheatreTypes)
 		theatreTypes.GET("/:id", theatreTypeController.GetTheatreTypeByID)
 		theatreTypes.PATCH("/:id", theatreTypeController.UpdateTheatreType)
@@ -151,6 +391,7 @@ func setupRoutes(
 	showTypes := v1.Group("/show-types")
 	{
 		showTypes.POST("", showTypeController.CreateShowType)
+		showTypes.POST("/batch", batchController.ExecuteBatch(constants.BatchResourceShowTypes))
 		showTypes.GET("", showTypeController.GetAllShowTypes)
 		showTypes.GET("/:id", showTypeController.GetShowTypeByID)
 		showTypes.PATCH("/:id", showTypeController.UpdateShowType)
@@ -163,6 +404,7 @@ func setupRoutes(
 	theatres := v1.Group("/theatres")
 	{
 		theatres.POST("", theatreController.CreateTheatre)
+		theatres.POST("/batch", batchController.ExecuteBatch(constants.BatchResourceTheatres))
 		theatres.GET("", theatreController.GetAllTheatres)
 		theatres.GET("/:id", theatreController.GetTheatreByID)
 		theatres.PATCH("/:id", theatreController.UpdateTheatre)
@@ -173,12 +415,18 @@ func setupRoutes(
 		theatres.GET("/type/:typeId", theatreController.GetTheatresByTheatreTypeID)
 		theatres.GET("/nearby", theatreController.GetNearbyTheatres)
 		theatres.GET("/search", theatreController.SearchTheatres)
+		theatres.GET("/:id/calendar.ics", calendarController.GetTheatreCalendar)
+		theatres.GET("/:id/revisions", theatreController.GetTheatreRevisions)
+		theatres.GET("/:id/revisions/diff", theatreController.DiffTheatreRevisions)
+		theatres.GET("/:id/revisions/:revision", theatreController.GetTheatreRevision)
+		theatres.POST("/:id/revisions/:revision/restore", theatreController.RestoreTheatreRevision)
 	}
 
 	// Show routes
 	shows := v1.Group("/shows")
 	{
 		shows.POST("", showController.CreateShow)
+		shows.POST("/batch", batchController.ExecuteBatch(constants.BatchResourceShows))
 		shows.GET("", showController.GetAllShows)
 		shows.GET("/:id", showController.GetShowByID)
 		shows.PATCH("/:id", showController.UpdateShow)
@@ -190,5 +438,50 @@ func setupRoutes(
 		shows.GET("/theatre/:theatreId", showController.GetShowsByTheatreID)
 		shows.GET("/type/:typeId", showController.GetShowsByShowTypeID)
 		shows.GET("/search", showController.SearchShows)
+		shows.GET("/:id/calendar.ics", calendarController.GetShowCalendar)
+		shows.GET("/:id/revisions", showController.GetShowRevisions)
+		shows.GET("/:id/revisions/diff", showController.DiffShowRevisions)
+		shows.GET("/:id/revisions/:revision", showController.GetShowRevision)
+		shows.POST("/:id/revisions/:revision/restore", showController.RestoreShowRevision)
+	}
+
+	// Import routes
+	imports := v1.Group("/imports", controllers.MaxBodySize(constants.ImportMaxBodySize))
+	{
+		imports.POST("/:entity", importController.ImportFile)
+		imports.GET("/jobs/:id", importController.GetImportJob)
+	}
+
+	// Webhook routes
+	webhookRoutes := v1.Group("/webhooks")
+	{
+		webhookRoutes.POST("", webhookController.CreateWebhook)
+		webhookRoutes.GET("", webhookController.GetAllWebhooks)
+		webhookRoutes.GET("/:id", webhookController.GetWebhookByID)
+		webhookRoutes.PATCH("/:id", webhookController.UpdateWebhook)
+		webhookRoutes.DELETE("/:id", webhookController.DeleteWebhook)
+		webhookRoutes.POST("/:id/test", webhookController.SendTestEvent)
+		webhookRoutes.GET("/:id/deliveries", webhookController.GetDeliveries)
+		webhookRoutes.GET("/:id/deliveries/:deliveryId", webhookController.GetDelivery)
+	}
+
+	// Audit routes
+	v1.GET("/audit", auditController.ListEntries)
+
+	// Trash routes
+	trash := v1.Group("/trash")
+	{
+		trash.GET("/:resource", trashController.ListDeleted)
+		trash.POST("/:resource/:id/restore", trashController.Restore)
+		trash.DELETE("/:resource/:id", trashController.Purge)
+	}
+
+	// Admin routes
+	admin := v1.Group("/admin")
+	{
+		admin.GET("/outbox", outboxController.ListMessages)
+		admin.POST("/outbox/replay", outboxController.ReplayFailed)
+		admin.GET("/outbox/:id", outboxController.GetMessage)
+		admin.POST("/outbox/:id/replay", outboxController.ReplayMessage)
 	}
 }
@@ -0,0 +1,101 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+)
+
+// auditChange is one field's value before and after a change; Old is null on create and New on delete
+type auditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// auditIgnoredFields are bookkeeping fields that change on every write or never change
+var auditIgnoredFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// recordAudit writes an audit entry in tx's transaction, attributing it to the actor, request and client IP on ctx.
+// before is nil for a create and after is nil for a delete; an update that changed nothing is not recorded.
+func recordAudit(ctx context.Context, tx interfaces.UnitOfWork, entityType string, entityID uuid.UUID, before, after interface{}) error {
+	action := constants.AuditActionUpdate
+	switch {
+	case before == nil:
+		action = constants.AuditActionCreate
+	case after == nil:
+		action = constants.AuditActionDelete
+	}
+
+	changes := auditChanges(before, after)
+	if len(changes) == 0 && action == constants.AuditActionUpdate {
+		return nil
+	}
+	return recordAuditAction(ctx, tx, entityType, entityID, action, changes)
+}
+
+// recordAuditAction writes an audit entry for an action recordAudit cannot tell from before and after, such as a restore
+func recordAuditAction(ctx context.Context, tx interfaces.UnitOfWork, entityType string, entityID uuid.UUID, action string, changes map[string]auditChange) error {
+	payload, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Audit().Append(ctx, &models.AuditEntry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      auditActor(ctx),
+		RequestID:  logging.RequestID(ctx),
+		IPAddress:  logging.ClientIP(ctx),
+		Changes:    payload,
+	})
+}
+
+// auditActor is who ctx's request acts for, or anonymous when API key auth is off
+func auditActor(ctx context.Context) string {
+	if actor := logging.Actor(ctx); actor != "" {
+		return actor
+	}
+	return constants.AuditActorAnonymous
+}
+
+// auditChanges diffs the scalar JSON fields of two versions of an entity. Nested objects and lists are left out:
+// they are related entities, whose own changes are audited, and the link to them shows up as its *_id field.
+func auditChanges(before, after interface{}) map[string]auditChange {
+	beforeFields, afterFields := map[string]interface{}{}, map[string]interface{}{}
+	if before != nil {
+		beforeFields = jsonFields(before)
+	}
+	if after != nil {
+		afterFields = jsonFields(after)
+	}
+
+	changes := map[string]auditChange{}
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		for name := range fields {
+			if auditIgnoredFields[name] || isNested(beforeFields[name]) || isNested(afterFields[name]) {
+				continue
+			}
+			if _, seen := changes[name]; seen || reflect.DeepEqual(beforeFields[name], afterFields[name]) {
+				continue
+			}
+			changes[name] = auditChange{Old: beforeFields[name], New: afterFields[name]}
+		}
+	}
+	return changes
+}
+
+// isNested reports whether a decoded JSON value is an object or a list
+func isNested(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// auditEntityTypes lists the entities whose changes are audited
+var auditEntityTypes = []string{
+	constants.EventAggregateLocation,
+	constants.EventAggregateTheatreType,
+	constants.EventAggregateShowType,
+	constants.EventAggregateTheatre,
+	constants.EventAggregateShow,
+}
+
+// auditService implements the AuditService interface
+type auditService struct {
+	auditRepo interfaces.AuditRepository
+	mapper    *mappers.AuditMapper
+	retention time.Duration
+
+	// stop ends the retention loop, which closes done once it has returned; cancel aborts a purge in progress
+	stop   chan struct{}
+	done   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewAuditService creates a new audit service that, with a positive retention, deletes older entries in the background
+func NewAuditService(uow interfaces.UnitOfWork, retention time.Duration) interfaces.AuditService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &auditService{
+		auditRepo: uow.Audit(),
+		mapper:    mappers.NewAuditMapper(),
+		retention: retention,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	go s.enforceRetention()
+	return s
+}
+
+// ListEntries retrieves audit entries, newest first, narrowed by the query
+func (s *auditService) ListEntries(ctx context.Context, query dto.AuditQuery, limit, offset int) ([]*dto.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "AuditService.ListEntries")
+	defer span.End()
+
+	filter, err := s.parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	entries, err := s.auditRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDTOs(entries), nil
+}
+
+// parseQuery validates the query and turns it into a repository filter
+func (s *auditService) parseQuery(query dto.AuditQuery) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		EntityType: query.Entity,
+		Action:     query.Action,
+		Actor:      query.Actor,
+	}
+
+	if query.Entity != "" && !slices.Contains(auditEntityTypes, query.Entity) {
+		return filter, errors.New(constants.ErrorAuditInvalidQuery + ": entity must be location, theatre_type, show_type, theatre or show")
+	}
+	switch query.Action {
+	case "", constants.AuditActionCreate, constants.AuditActionUpdate, constants.AuditActionDelete,
+		constants.AuditActionRestore, constants.AuditActionPurge:
+	default:
+		return filter, errors.New(constants.ErrorAuditInvalidQuery + ": action must be create, update, delete, restore or purge")
+	}
+	if query.ID != "" {
+		id, err := uuid.Parse(query.ID)
+		if err != nil {
+			return filter, errors.New(constants.ErrorAuditInvalidQuery + ": id must be a UUID")
+		}
+		filter.EntityID = &id
+	}
+	if query.From != "" {
+		from, err := time.Parse(time.RFC3339, query.From)
+		if err != nil {
+			return filter, errors.New(constants.ErrorAuditInvalidQuery + ": from must be an RFC 3339 time")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(time.RFC3339, query.To)
+		if err != nil {
+			return filter, errors.New(constants.ErrorAuditInvalidQuery + ": to must be an RFC 3339 time")
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+// enforceRetention deletes entries older than the retention period now and once per purge interval until stopped
+func (s *auditService) enforceRetention() {
+	defer close(s.done)
+	if s.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(constants.AuditPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.auditRepo.DeleteBefore(s.ctx, time.Now().Add(-s.retention))
+		if err != nil {
+			if s.ctx.Err() == nil {
+				slog.Error("Failed to purge audit entries", "error", err)
+			}
+		} else if purged > 0 {
+			slog.Info("Purged audit entries past their retention", "count", purged)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops the retention loop, waiting for a purge in progress and cancelling it if ctx expires first
+func (s *auditService) Shutdown(ctx context.Context) error {
+	close(s.stop)
+	defer s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,278 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// errBatchRolledBack signals that an atomic batch with a failed operation must discard its transaction
+	errBatchRolledBack = errors.New("batch rolled back")
+
+	// errBatchOperationFailed discards a failed operation's writes without aborting the rest of the batch
+	errBatchOperationFailed = errors.New("batch operation failed")
+)
+
+// batchNotFoundErrors maps each resource to the error its services return when the target is missing
+var batchNotFoundErrors = map[string]string{
+	constants.BatchResourceLocations:    constants.ErrorLocationNotFound,
+	constants.BatchResourceTheatreTypes: constants.ErrorTheatreTypeNotFound,
+	constants.BatchResourceShowTypes:    constants.ErrorShowTypeNotFound,
+	constants.BatchResourceTheatres:     constants.ErrorTheatreNotFound,
+	constants.BatchResourceShows:        constants.ErrorShowNotFound,
+}
+
+// batchService implements the BatchService interface
+type batchService struct {
+	uow      interfaces.UnitOfWork
+	events   interfaces.EventPublisher
+	metrics  interfaces.BusinessMetrics
+	policies DeletePolicies
+}
+
+// NewBatchService creates a new batch service
+func NewBatchService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher, policies DeletePolicies) interfaces.BatchService {
+	return &batchService{
+		uow:      uow,
+		events:   events,
+		metrics:  metrics,
+		policies: policies,
+	}
+}
+
+// ExecuteBatch runs create/update/delete operations through the resource's service,
+// in one transaction when atomic or independently otherwise
+func (s *batchService) ExecuteBatch(ctx context.Context, resource string, operations []dto.BatchOperation, atomic bool) (*dto.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "BatchService.ExecuteBatch")
+	defer span.End()
+
+	if _, ok := batchNotFoundErrors[resource]; !ok {
+		return nil, fmt.Errorf("%s: unsupported resource %q", constants.ErrorBatchInvalid, resource)
+	}
+	if len(operations) == 0 {
+		return nil, errors.New(constants.ErrorBatchInvalid + ": at least one operation is required")
+	}
+	if len(operations) > constants.BatchMaxOperations {
+		return nil, fmt.Errorf("%s: at most %d operations are allowed", constants.ErrorBatchInvalid, constants.BatchMaxOperations)
+	}
+
+	result := &dto.BatchResult{
+		Resource: resource,
+		Atomic:   atomic,
+		Total:    len(operations),
+		Results:  make([]dto.BatchItemResult, 0, len(operations)),
+	}
+
+	if !atomic {
+		services := NewServices(s.uow, s.events, s.policies)
+		for i, operation := range operations {
+			s.recordResult(result, s.applyOperation(ctx, services, resource, i, operation))
+		}
+		result.Committed = result.Succeeded > 0
+		s.countCreated(result)
+		return result, nil
+	}
+
+	// Every operation nests in its own transaction so a failure doesn't abort the outer one,
+	// letting the remaining operations still report their own outcome
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		services := NewServices(tx, s.events, s.policies)
+
+		for i, operation := range operations {
+			var item dto.BatchItemResult
+			err := tx.Do(ctx, func(interfaces.UnitOfWork) error {
+				item = s.applyOperation(ctx, services, resource, i, operation)
+				if item.Error != "" {
+					return errBatchOperationFailed
+				}
+				return nil
+			})
+			if err != nil && !errors.Is(err, errBatchOperationFailed) {
+				return err
+			}
+			s.recordResult(result, item)
+		}
+
+		if result.Failed > 0 {
+			return errBatchRolledBack
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errBatchRolledBack) {
+		return nil, err
+	}
+
+	result.Committed = err == nil
+	if !result.Committed {
+		for i := range result.Results {
+			if result.Results[i].Error == "" {
+				result.Results[i].Status = http.StatusFailedDependency
+				result.Results[i].Data = nil
+				result.Results[i].Error = constants.ErrorBatchRolledBack
+			}
+		}
+	}
+	s.countCreated(result)
+
+	return result, nil
+}
+
+// countCreated records the creates that were committed
+func (s *batchService) countCreated(result *dto.BatchResult) {
+	created := 0
+	for _, item := range result.Results {
+		if item.Status == http.StatusCreated {
+			created++
+		}
+	}
+	if created > 0 {
+		s.metrics.EntitiesCreated(result.Resource, created)
+	}
+}
+
+// recordResult appends an operation result and updates the batch counts
+func (s *batchService) recordResult(result *dto.BatchResult, item dto.BatchItemResult) {
+	result.Results = append(result.Results, item)
+	if item.Error != "" {
+		result.Failed++
+	} else {
+		result.Succeeded++
+	}
+}
+
+// applyOperation validates the operation's shape and dispatches it to the resource's service
+func (s *batchService) applyOperation(ctx context.Context, services *Services, resource string, index int, operation dto.BatchOperation) dto.BatchItemResult {
+	item := dto.BatchItemResult{
+		Index: index,
+		Op:    operation.Op,
+		ID:    operation.ID,
+	}
+
+	if err := validateBatchOperation(operation); err != nil {
+		item.Status = http.StatusBadRequest
+		item.Error = err.Error()
+		return item
+	}
+
+	var (
+		data interface{}
+		err  error
+	)
+	switch resource {
+	case constants.BatchResourceLocations:
+		data, err = applyBatchOperation(ctx, operation, services.Locations.CreateLocation, services.Locations.UpdateLocation, services.Locations.DeleteLocation)
+	case constants.BatchResourceTheatreTypes:
+		data, err = applyBatchOperation(ctx, operation, services.TheatreTypes.CreateTheatreType, services.TheatreTypes.UpdateTheatreType, services.TheatreTypes.DeleteTheatreType)
+	case constants.BatchResourceShowTypes:
+		data, err = applyBatchOperation(ctx, operation, services.ShowTypes.CreateShowType, services.ShowTypes.UpdateShowType, services.ShowTypes.DeleteShowType)
+	case constants.BatchResourceTheatres:
+		data, err = applyBatchOperation(ctx, operation, services.Theatres.CreateTheatre, services.Theatres.UpdateTheatre, services.Theatres.DeleteTheatre)
+	case constants.BatchResourceShows:
+		data, err = applyBatchOperation(ctx, operation, services.Shows.CreateShow, services.Shows.UpdateShow, services.Shows.DeleteShow)
+	}
+
+	if err != nil {
+		item.Status = batchErrorStatus(resource, err)
+		item.Error = err.Error()
+
+		// A refused delete lists what still belongs to the record
+		var conflict *dto.DeleteConflict
+		if errors.As(err, &conflict) {
+			item.Data = conflict
+		}
+		return item
+	}
+
+	switch operation.Op {
+	case constants.BatchOpCreate:
+		item.Status = http.StatusCreated
+	case constants.BatchOpUpdate:
+		item.Status = http.StatusOK
+	case constants.BatchOpDelete:
+		item.Status = http.StatusNoContent
+	}
+	item.Data = data
+
+	return item
+}
+
+// validateBatchOperation checks that an operation carries what its kind needs
+func validateBatchOperation(operation dto.BatchOperation) error {
+	switch operation.Op {
+	case constants.BatchOpCreate:
+		if len(operation.Data) == 0 {
+			return errors.New(constants.ErrorInvalidInput + ": create requires data")
+		}
+	case constants.BatchOpUpdate:
+		if operation.ID == nil || len(operation.Data) == 0 {
+			return errors.New(constants.ErrorInvalidInput + ": update requires id and data")
+		}
+	case constants.BatchOpDelete:
+		if operation.ID == nil {
+			return errors.New(constants.ErrorInvalidInput + ": delete requires id")
+		}
+	default:
+		return fmt.Errorf("%s: op must be one of create, update, delete", constants.ErrorInvalidInput)
+	}
+	return nil
+}
+
+// applyBatchOperation decodes the operation's data into the resource's base DTO and calls the matching service method
+func applyBatchOperation[B any, D any](
+	ctx context.Context,
+	operation dto.BatchOperation,
+	create func(context.Context, *B) (D, error),
+	update func(context.Context, uuid.UUID, *B) (D, error),
+	remove func(context.Context, uuid.UUID, *uuid.UUID) error,
+) (interface{}, error) {
+	if operation.Op == constants.BatchOpDelete {
+		return nil, remove(ctx, *operation.ID, operation.ReassignTo)
+	}
+
+	var body B
+	if err := json.Unmarshal(operation.Data, &body); err != nil {
+		return nil, errors.New(constants.ErrorInvalidInput + ": " + err.Error())
+	}
+
+	if operation.Op == constants.BatchOpCreate {
+		return create(ctx, &body)
+	}
+	return update(ctx, *operation.ID, &body)
+}
+
+// batchErrorStatus maps a service error to the status the single-item endpoint would answer with
+func batchErrorStatus(resource string, err error) int {
+	message := err.Error()
+
+	switch {
+	case message == batchNotFoundErrors[resource]:
+		return http.StatusNotFound
+	case strings.HasPrefix(message, constants.ErrorValidationFailed):
+		return http.StatusUnprocessableEntity
+	case strings.HasPrefix(message, constants.ErrorInvalidInput),
+		strings.HasPrefix(message, constants.ErrorInvalidTimezone),
+		strings.HasPrefix(message, constants.ErrorInvalidReassignTarget):
+		return http.StatusBadRequest
+	case strings.HasPrefix(message, constants.ErrorHasDependents):
+		return http.StatusConflict
+	}
+
+	// A missing related record, such as the theatre a show points at
+	for _, notFound := range batchNotFoundErrors {
+		if message == notFound {
+			return http.StatusBadRequest
+		}
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,138 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/testdb"
+
+	"github.com/google/uuid"
+)
+
+func TestBatchErrorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		err      error
+		want     int
+	}{
+		{name: "target not found", resource: constants.BatchResourceShows, err: errors.New(constants.ErrorShowNotFound), want: http.StatusNotFound},
+		{name: "related record not found", resource: constants.BatchResourceShows, err: errors.New(constants.ErrorTheatreNotFound), want: http.StatusBadRequest},
+		{name: "validation failed", resource: constants.BatchResourceTheatres, err: errors.New(constants.ErrorValidationFailed + ": Name is required"), want: http.StatusUnprocessableEntity},
+		{name: "invalid input", resource: constants.BatchResourceLocations, err: errors.New(constants.ErrorInvalidInput + ": unexpected end of JSON input"), want: http.StatusBadRequest},
+		{name: "invalid timezone", resource: constants.BatchResourceLocations, err: errors.New(constants.ErrorInvalidTimezone + ": Mars/Olympus"), want: http.StatusBadRequest},
+		{name: "invalid reassign target", resource: constants.BatchResourceTheatres, err: errors.New(constants.ErrorInvalidReassignTarget + ": same record"), want: http.StatusBadRequest},
+		{
+			name:     "delete refused",
+			resource: constants.BatchResourceTheatres,
+			err:      &dto.DeleteConflict{Dependents: []dto.Dependent{{Type: "show", ID: uuid.New(), Name: "Hamilton"}}},
+			want:     http.StatusConflict,
+		},
+		{name: "not-found error that only starts the message", resource: constants.BatchResourceShows, err: errors.New(constants.ErrorShowNotFound + ": extra"), want: http.StatusInternalServerError},
+		{name: "unexpected", resource: constants.BatchResourceTheatreTypes, err: errors.New("connection reset"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchErrorStatus(tt.resource, tt.err); got != tt.want {
+				t.Errorf("batchErrorStatus(%q, %q) = %d, want %d", tt.resource, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBatchOperation(t *testing.T) {
+	id := uuid.New()
+	data := json.RawMessage(`{"name":"Broadway"}`)
+
+	tests := []struct {
+		name      string
+		operation dto.BatchOperation
+		wantErr   bool
+	}{
+		{name: "create", operation: dto.BatchOperation{Op: constants.BatchOpCreate, Data: data}},
+		{name: "create without data", operation: dto.BatchOperation{Op: constants.BatchOpCreate}, wantErr: true},
+		{name: "update", operation: dto.BatchOperation{Op: constants.BatchOpUpdate, ID: &id, Data: data}},
+		{name: "update without id", operation: dto.BatchOperation{Op: constants.BatchOpUpdate, Data: data}, wantErr: true},
+		{name: "delete", operation: dto.BatchOperation{Op: constants.BatchOpDelete, ID: &id}},
+		{name: "delete without id", operation: dto.BatchOperation{Op: constants.BatchOpDelete}, wantErr: true},
+		{name: "unknown op", operation: dto.BatchOperation{Op: "upsert", ID: &id, Data: data}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBatchOperation(tt.operation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateBatchOperation returned %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && batchErrorStatus(constants.BatchResourceTheatreTypes, err) != http.StatusBadRequest {
+				t.Errorf("%q doesn't map to 400", err)
+			}
+		})
+	}
+}
+
+func TestAtomicBatchWithAFailureCommitsNothing(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, "business_test")
+	service := NewBatchService(repo.NewUnitOfWork(db), nopMetrics{}, NewEventBus(), DeletePolicies{})
+
+	create := func(body string) dto.BatchOperation {
+		return dto.BatchOperation{Op: constants.BatchOpCreate, Data: json.RawMessage(body)}
+	}
+	operations := []dto.BatchOperation{
+		create(`{"name":"Broadway"}`),
+		create(`{"name":""}`),
+		create(`{"name":"Off-Broadway"}`),
+	}
+
+	result, err := service.ExecuteBatch(ctx, constants.BatchResourceTheatreTypes, operations, true)
+	if err != nil {
+		t.Fatalf("ExecuteBatch: %v", err)
+	}
+	if result.Committed || result.Succeeded != 2 || result.Failed != 1 {
+		t.Errorf("committed %v with %d succeeded and %d failed, want nothing committed with 2 and 1", result.Committed, result.Succeeded, result.Failed)
+	}
+
+	wantStatus := []int{http.StatusFailedDependency, http.StatusUnprocessableEntity, http.StatusFailedDependency}
+	for i, item := range result.Results {
+		if item.Status != wantStatus[i] {
+			t.Errorf("operation %d answered %d, want %d", i, item.Status, wantStatus[i])
+		}
+		if item.Status == http.StatusFailedDependency && (item.Error != constants.ErrorBatchRolledBack || item.Data != nil) {
+			t.Errorf("rolled back operation %d reports error %q and data %v", i, item.Error, item.Data)
+		}
+	}
+
+	for _, model := range []interface{}{&models.TheatreType{}, &models.AuditEntry{}, &models.OutboxMessage{}} {
+		var count int64
+		if err := db.Model(model).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("%T rows = %d after a rolled back batch, want 0", model, count)
+		}
+	}
+
+	// Without atomic the valid operations stand on their own
+	result, err = service.ExecuteBatch(ctx, constants.BatchResourceTheatreTypes, operations, false)
+	if err != nil {
+		t.Fatalf("ExecuteBatch: %v", err)
+	}
+	if !result.Committed || result.Results[0].Status != http.StatusCreated || result.Results[2].Status != http.StatusCreated {
+		t.Errorf("non-atomic batch committed %v with results %+v", result.Committed, result.Results)
+	}
+	var count int64
+	if err := db.Model(&models.TheatreType{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("theatre types = %d after the non-atomic batch, want 2", count)
+	}
+}
@@ -3,6 +3,7 @@ package business
 import (
 	"encoding/json"
 	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -10,16 +11,20 @@ import (
 
 // CacheService provides caching functionality for the application
 type CacheService struct {
-	cache *cache.Cache
+	cache   *cache.Cache
+	metrics interfaces.CacheMetrics
 }
 
-// NewCacheService creates a new cache service
-func NewCacheService() *CacheService {
+// NewCacheService creates a new cache service; expired and deleted entries count as evictions
+func NewCacheService(defaultExpiration, cleanupInterval time.Duration, metrics interfaces.CacheMetrics) *CacheService {
+	c := cache.New(defaultExpiration, cleanupInterval)
+	c.OnEvicted(func(string, interface{}) {
+		metrics.CacheEviction()
+	})
+
 	return &CacheService{
-		cache: cache.New(
-			time.Duration(constants.CacheDefaultExpiration)*time.Second,
-			time.Duration(constants.CacheCleanupInterval)*time.Second,
-		),
+		cache:   c,
+		metrics: metrics,
 	}
 }
 
@@ -38,8 +43,10 @@ func (cs *CacheService) Set(key string, value interface{}, duration time.Duratio
 func (cs *CacheService) Get(key string, dest interface{}) (bool, error) {
 	data, found := cs.cache.Get(key)
 	if !found {
+		cs.metrics.CacheMiss()
 		return false, nil
 	}
+	cs.metrics.CacheHit()
 
 	jsonData, ok := data.([]byte)
 	if !ok {
@@ -0,0 +1,329 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	icsDateTimeLayout = "20060102T150405"
+	icsLineLimit      = 75 // octets per content line before folding (RFC 5545 section 3.1)
+)
+
+// calendarService implements the CalendarService interface
+type calendarService struct {
+	theatreRepo interfaces.TheatreRepository
+	showRepo    interfaces.ShowRepository
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(
+	theatreRepo interfaces.TheatreRepository,
+	showRepo interfaces.ShowRepository,
+) interfaces.CalendarService {
+	return &calendarService{
+		theatreRepo: theatreRepo,
+		showRepo:    showRepo,
+	}
+}
+
+// GetTheatreCalendar builds an iCalendar feed of a theatre's shows, optionally filtered by show type
+func (s *calendarService) GetTheatreCalendar(ctx context.Context, theatreID uuid.UUID, showTypeID *uuid.UUID) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.GetTheatreCalendar")
+	defer span.End()
+
+	theatre, err := s.theatreRepo.GetByID(ctx, theatreID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorTheatreNotFound)
+		}
+		return nil, err
+	}
+
+	shows := make([]*models.Show, 0, len(theatre.Shows))
+	for i := range theatre.Shows {
+		if showTypeID != nil && theatre.Shows[i].ShowTypeID != *showTypeID {
+			continue
+		}
+		shows = append(shows, &theatre.Shows[i])
+	}
+
+	return s.buildCalendar(theatre.Name, theatre, shows), nil
+}
+
+// GetShowCalendar builds an iCalendar feed for a single show
+func (s *calendarService) GetShowCalendar(ctx context.Context, showID uuid.UUID) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.GetShowCalendar")
+	defer span.End()
+
+	show, err := s.showRepo.GetByID(ctx, showID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorShowNotFound)
+		}
+		return nil, err
+	}
+
+	return s.buildCalendar(show.Title, &show.Theatre, []*models.Show{show}), nil
+}
+
+// buildCalendar renders a VCALENDAR with one VEVENT per scheduled show in the theatre's timezone
+func (s *calendarService) buildCalendar(name string, theatre *models.Theatre, shows []*models.Show) []byte {
+	timezone := theatre.Location.Timezone
+	if timezone == "" {
+		timezone = constants.DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		timezone, loc = constants.DefaultTimezone, time.UTC
+	}
+
+	// Shows without a start date cannot be placed on a calendar
+	scheduled := make([]*models.Show, 0, len(shows))
+	for _, show := range shows {
+		if show.StartDate != nil {
+			scheduled = append(scheduled, show)
+		}
+	}
+
+	w := &icsWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", constants.CalendarProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeText(name))
+	w.line("X-WR-TIMEZONE", timezone)
+
+	from, to := calendarSpan(scheduled)
+	writeTimezone(w, timezone, loc, from, to)
+
+	for _, show := range scheduled {
+		writeEvent(w, show, theatre, timezone, loc)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// writeEvent renders a show as a VEVENT: a run spanning several days repeats daily until its last day,
+// each performance lasting the show's duration, while a show within one day ends at its end date
+func writeEvent(w *icsWriter, show *models.Show, theatre *models.Theatre, timezone string, loc *time.Location) {
+	start := show.StartDate.In(loc)
+	modified := lastModified(show, theatre).UTC().Format(icsDateTimeLayout) + "Z"
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("show-%s@%s", show.ID, constants.CalendarUIDDomain))
+	w.line("DTSTAMP", modified)
+	w.line("LAST-MODIFIED", modified)
+	w.line("CREATED", show.CreatedAt.UTC().Format(icsDateTimeLayout)+"Z")
+	w.line("SEQUENCE", fmt.Sprintf("%d", show.Sequence))
+	w.line("DTSTART;TZID="+timezone, start.Format(icsDateTimeLayout))
+
+	var end time.Time
+	if show.EndDate != nil && show.EndDate.After(*show.StartDate) {
+		end = show.EndDate.In(loc)
+	}
+	switch {
+	case !end.IsZero() && end.YearDay() == start.YearDay() && end.Year() == start.Year():
+		w.line("DTEND;TZID="+timezone, end.Format(icsDateTimeLayout))
+	case !end.IsZero():
+		if show.Duration > 0 {
+			w.line("DTEND;TZID="+timezone, start.Add(time.Duration(show.Duration)*time.Minute).Format(icsDateTimeLayout))
+		}
+		// UNTIL is the last performance's start; a date-only end date still includes that day's performance
+		last := time.Date(end.Year(), end.Month(), end.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+		w.line("RRULE", "FREQ=DAILY;UNTIL="+last.UTC().Format(icsDateTimeLayout)+"Z")
+	case show.Duration > 0:
+		w.line("DTEND;TZID="+timezone, start.Add(time.Duration(show.Duration)*time.Minute).Format(icsDateTimeLayout))
+	}
+
+	w.line("SUMMARY", escapeText(show.Title))
+	if show.Description != "" {
+		w.line("DESCRIPTION", escapeText(show.Description))
+	}
+	w.line("LOCATION", escapeText(locationText(theatre)))
+	if theatre.Location.Latitude != nil && theatre.Location.Longitude != nil {
+		w.line("GEO", fmt.Sprintf("%f;%f", *theatre.Location.Latitude, *theatre.Location.Longitude))
+	}
+	if show.ImageURL != "" {
+		w.line("ATTACH", show.ImageURL)
+	}
+
+	status := "CONFIRMED"
+	if !show.IsActive {
+		status = "CANCELLED"
+	}
+	w.line("STATUS", status)
+	w.line("TRANSP", "OPAQUE")
+	w.line("END", "VEVENT")
+}
+
+// lastModified is when the show or the venue details its event carries last changed
+func lastModified(show *models.Show, theatre *models.Theatre) time.Time {
+	modified := show.UpdatedAt
+	for _, updated := range []time.Time{theatre.UpdatedAt, theatre.Location.UpdatedAt} {
+		if updated.After(modified) {
+			modified = updated
+		}
+	}
+	return modified
+}
+
+// locationText joins the theatre name and address parts from its location
+func locationText(theatre *models.Theatre) string {
+	location := theatre.Location
+	parts := []string{theatre.Name, theatre.Address, location.Address, location.City, location.State, location.PostalCode, location.Country}
+
+	seen := make(map[string]bool, len(parts))
+	text := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" || seen[part] {
+			continue
+		}
+		seen[part] = true
+		text = append(text, part)
+	}
+
+	return strings.Join(text, ", ")
+}
+
+// calendarSpan returns the range of whole years covered by the given shows
+func calendarSpan(shows []*models.Show) (time.Time, time.Time) {
+	now := time.Now().UTC()
+	first, last := now, now
+	for _, show := range shows {
+		if show.StartDate.Before(first) {
+			first = *show.StartDate
+		}
+		if show.StartDate.After(last) {
+			last = *show.StartDate
+		}
+		if show.EndDate != nil && show.EndDate.After(last) {
+			last = *show.EndDate
+		}
+	}
+
+	from := time.Date(first.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(last.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return from, to
+}
+
+// writeTimezone renders a VTIMEZONE with every UTC offset transition between from and to
+func writeTimezone(w *icsWriter, timezone string, loc *time.Location, from, to time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", timezone)
+
+	name, offset := from.In(loc).Zone()
+	writeObservance(w, from.In(loc).IsDST(), from, offset, offset, name)
+
+	// Walk day by day, then narrow each offset change down to the second it happens
+	for day := from; day.Before(to); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, midOffset := mid.In(loc).Zone(); midOffset == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		name, _ = hi.In(loc).Zone()
+		writeObservance(w, hi.In(loc).IsDST(), hi, offset, nextOffset, name)
+		offset = nextOffset
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// writeObservance renders a STANDARD or DAYLIGHT sub-component starting at the given instant
+func writeObservance(w *icsWriter, isDST bool, onset time.Time, offsetFrom, offsetTo int, name string) {
+	component := "STANDARD"
+	if isDST {
+		component = "DAYLIGHT"
+	}
+
+	// DTSTART is the local time of the onset expressed in the offset being replaced
+	localOnset := onset.UTC().Add(time.Duration(offsetFrom) * time.Second)
+
+	w.line("BEGIN", component)
+	w.line("DTSTART", localOnset.Format(icsDateTimeLayout))
+	w.line("TZOFFSETFROM", formatOffset(offsetFrom))
+	w.line("TZOFFSETTO", formatOffset(offsetTo))
+	w.line("TZNAME", escapeText(name))
+	w.line("END", component)
+}
+
+// formatOffset formats a UTC offset in seconds as +HHMM or +HHMMSS
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	hours, minutes, secs := seconds/3600, (seconds%3600)/60, seconds%60
+	if secs != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, secs)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
+
+// escapeText escapes a TEXT property value per RFC 5545 section 3.3.11
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// icsWriter writes CRLF-terminated content lines folded at 75 octets
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+// line writes a "NAME:value" content line, folding it without splitting UTF-8 characters
+func (w *icsWriter) line(name, value string) {
+	content := name + ":" + value
+	limit := icsLineLimit
+
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+
+		// Continuation lines lose one octet to the leading space
+		limit = icsLineLimit - 1
+	}
+
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,300 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/testdb"
+	"time"
+	"unicode/utf8"
+)
+
+// unfold splits iCalendar output into its content lines, joining folded continuations back on
+func unfold(ics string) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(ics, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestICSWriterLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string // the folded output, when it matters beyond the invariants checked for every case
+	}{
+		{name: "short", value: "Hamilton", want: "SUMMARY:Hamilton\r\n"},
+		{name: "exactly 75 octets", value: strings.Repeat("a", 67), want: "SUMMARY:" + strings.Repeat("a", 67) + "\r\n"},
+		{
+			name:  "76 octets",
+			value: strings.Repeat("a", 68),
+			want:  "SUMMARY:" + strings.Repeat("a", 67) + "\r\n a\r\n",
+		},
+		{
+			name:  "two-octet character across the limit",
+			value: strings.Repeat("a", 66) + "é",
+			want:  "SUMMARY:" + strings.Repeat("a", 66) + "\r\n é\r\n",
+		},
+		{name: "three continuation lines", value: strings.Repeat("b", 220)},
+		{name: "multi-byte throughout", value: strings.Repeat("Théâtre 劇場 🎭 ", 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &icsWriter{}
+			w.line("SUMMARY", tt.value)
+			got := w.buf.String()
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("line wrote %q, want %q", got, tt.want)
+			}
+			if !strings.HasSuffix(got, "\r\n") {
+				t.Fatalf("line %q is not CRLF-terminated", got)
+			}
+			for i, physical := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(physical) > icsLineLimit {
+					t.Errorf("line %d is %d octets, over %d", i, len(physical), icsLineLimit)
+				}
+				if !utf8.ValidString(physical) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i, physical)
+				}
+				if i > 0 && !strings.HasPrefix(physical, " ") {
+					t.Errorf("continuation line %d doesn't start with a space: %q", i, physical)
+				}
+			}
+			if unfolded := unfold(got); len(unfolded) != 1 || unfolded[0] != "SUMMARY:"+tt.value {
+				t.Errorf("unfolded to %q, want %q", unfolded, "SUMMARY:"+tt.value)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "Hamilton", want: "Hamilton"},
+		{value: "Majestic, Broadway", want: `Majestic\, Broadway`},
+		{value: "Act 1; Act 2", want: `Act 1\; Act 2`},
+		{value: `C:\shows`, want: `C:\\shows`},
+		{value: "line 1\nline 2", want: `line 1\nline 2`},
+		{value: "line 1\r\nline 2", want: `line 1\nline 2`},
+		{value: "line 1\rline 2", want: `line 1\nline 2`},
+		{value: `a\;b`, want: `a\\\;b`},
+		{value: "colon: kept", want: "colon: kept"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeText(tt.value); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{seconds: 0, want: "+0000"},
+		{seconds: -5 * 3600, want: "-0500"},
+		{seconds: 5*3600 + 30*60, want: "+0530"},
+		{seconds: -(4*3600 + 56*60 + 2), want: "-045602"}, // New York's local mean time
+	}
+
+	for _, tt := range tests {
+		if got := formatOffset(tt.seconds); got != tt.want {
+			t.Errorf("formatOffset(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTimezone(t *testing.T) {
+	from := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		timezone string
+		want     []string
+	}{
+		{
+			timezone: "America/New_York",
+			want: []string{
+				"BEGIN:VTIMEZONE", "TZID:America/New_York",
+				"BEGIN:STANDARD", "DTSTART:20251231T190000", "TZOFFSETFROM:-0500", "TZOFFSETTO:-0500", "TZNAME:EST", "END:STANDARD",
+				"BEGIN:DAYLIGHT", "DTSTART:20260308T020000", "TZOFFSETFROM:-0500", "TZOFFSETTO:-0400", "TZNAME:EDT", "END:DAYLIGHT",
+				"BEGIN:STANDARD", "DTSTART:20261101T020000", "TZOFFSETFROM:-0400", "TZOFFSETTO:-0500", "TZNAME:EST", "END:STANDARD",
+				"END:VTIMEZONE",
+			},
+		},
+		{
+			timezone: "Europe/London",
+			want: []string{
+				"BEGIN:VTIMEZONE", "TZID:Europe/London",
+				"BEGIN:STANDARD", "DTSTART:20260101T000000", "TZOFFSETFROM:+0000", "TZOFFSETTO:+0000", "TZNAME:GMT", "END:STANDARD",
+				"BEGIN:DAYLIGHT", "DTSTART:20260329T010000", "TZOFFSETFROM:+0000", "TZOFFSETTO:+0100", "TZNAME:BST", "END:DAYLIGHT",
+				"BEGIN:STANDARD", "DTSTART:20261025T020000", "TZOFFSETFROM:+0100", "TZOFFSETTO:+0000", "TZNAME:GMT", "END:STANDARD",
+				"END:VTIMEZONE",
+			},
+		},
+		{
+			timezone: "Asia/Kolkata",
+			want: []string{
+				"BEGIN:VTIMEZONE", "TZID:Asia/Kolkata",
+				"BEGIN:STANDARD", "DTSTART:20260101T053000", "TZOFFSETFROM:+0530", "TZOFFSETTO:+0530", "TZNAME:IST", "END:STANDARD",
+				"END:VTIMEZONE",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.timezone)
+			if err != nil {
+				t.Skipf("timezone database unavailable: %v", err)
+			}
+
+			w := &icsWriter{}
+			writeTimezone(w, tt.timezone, loc, from, to)
+			if got := unfold(w.buf.String()); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("VTIMEZONE =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestWriteEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+	at := func(day, hour, minute int) *time.Time {
+		instant := time.Date(2026, time.July, day, hour, minute, 0, 0, loc)
+		return &instant
+	}
+	theatre := &models.Theatre{Name: "Majestic", Location: models.Location{City: "New York", Timezone: "America/New_York"}}
+
+	tests := []struct {
+		name    string
+		show    models.Show
+		want    []string
+		missing []string // property names the event must not carry
+	}{
+		{
+			name: "run over several days repeats daily",
+			show: models.Show{StartDate: at(1, 19, 30), EndDate: at(5, 0, 0), Duration: 150},
+			want: []string{
+				"DTSTART;TZID=America/New_York:20260701T193000",
+				"DTEND;TZID=America/New_York:20260701T220000",
+				"RRULE:FREQ=DAILY;UNTIL=20260705T233000Z",
+			},
+		},
+		{
+			name:    "run without a duration has no end",
+			show:    models.Show{StartDate: at(1, 19, 30), EndDate: at(3, 23, 0)},
+			want:    []string{"RRULE:FREQ=DAILY;UNTIL=20260703T233000Z"},
+			missing: []string{"DTEND"},
+		},
+		{
+			name:    "performance within one day ends at its end date",
+			show:    models.Show{StartDate: at(1, 19, 30), EndDate: at(1, 22, 15), Duration: 150},
+			want:    []string{"DTEND;TZID=America/New_York:20260701T221500"},
+			missing: []string{"RRULE"},
+		},
+		{
+			name:    "end date before the start is ignored",
+			show:    models.Show{StartDate: at(2, 19, 30), EndDate: at(1, 22, 0), Duration: 90},
+			want:    []string{"DTEND;TZID=America/New_York:20260702T210000"},
+			missing: []string{"RRULE"},
+		},
+		{
+			name:    "no end date and no duration",
+			show:    models.Show{StartDate: at(1, 19, 30)},
+			missing: []string{"DTEND", "RRULE"},
+		},
+		{
+			name: "revised and cancelled",
+			show: models.Show{StartDate: at(1, 19, 30), Sequence: 3},
+			want: []string{"SEQUENCE:3", "STATUS:CANCELLED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := tt.show
+			show.Title = "Hamilton"
+			w := &icsWriter{}
+			writeEvent(w, &show, theatre, "America/New_York", loc)
+			lines := unfold(w.buf.String())
+
+			for _, want := range tt.want {
+				if !containsLine(lines, want) {
+					t.Errorf("event lacks %q:\n%s", want, strings.Join(lines, "\n"))
+				}
+			}
+			for _, name := range tt.missing {
+				for _, line := range lines {
+					if strings.HasPrefix(line, name+":") || strings.HasPrefix(line, name+";") {
+						t.Errorf("event has %q", line)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestShowCalendarSequenceFollowsUpdates(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, "business_test")
+	location := &models.Location{Name: "Theater District", City: "New York", Country: "United States", Timezone: "America/New_York"}
+	theatreType := &models.TheatreType{Name: "Broadway"}
+	showType := &models.ShowType{Name: "Musical"}
+	for _, record := range []interface{}{location, theatreType, showType} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	theatre := &models.Theatre{Name: "Majestic", LocationID: location.ID, TheatreTypeID: theatreType.ID}
+	if err := db.Create(theatre).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	uow := repo.NewUnitOfWork(db)
+	shows := NewShowService(uow, nopMetrics{}, NewEventBus())
+	calendars := NewCalendarService(repo.NewTheatreRepository(db), repo.NewShowRepository(db))
+
+	start := time.Date(2026, time.July, 1, 23, 30, 0, 0, time.UTC)
+	input := &dto.ShowBase{Title: "Hamilton", TheatreID: theatre.ID, ShowTypeID: showType.ID, StartDate: &start}
+	created, err := shows.CreateShow(ctx, input)
+	if err != nil {
+		t.Fatalf("CreateShow: %v", err)
+	}
+
+	for want := 0; want <= 2; want++ {
+		if want > 0 {
+			input.Title = strings.Repeat("Hamilton ", want)
+			if _, err := shows.UpdateShow(ctx, created.ID, input); err != nil {
+				t.Fatalf("UpdateShow: %v", err)
+			}
+		}
+		feed, err := calendars.GetShowCalendar(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("GetShowCalendar: %v", err)
+		}
+		if line := fmt.Sprintf("SEQUENCE:%d", want); !containsLine(unfold(string(feed)), line) {
+			t.Errorf("after %d updates the feed lacks %s", want, line)
+		}
+	}
+}
+
+// containsLine reports whether lines holds want
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeletePolicies decides, for each relationship, what deleting a record does to the records that belong to it:
+// restrict, cascade or reassign; an empty policy restricts
+type DeletePolicies struct {
+	LocationTheatres    string
+	TheatreTypeTheatres string
+	ShowTypeShows       string
+	TheatreShows        string
+}
+
+// deleteRelationship describes the records that belong to one about to be deleted and how to delete or move them
+type deleteRelationship struct {
+	policy     string
+	parent     string // the kind of record being deleted, such as location
+	dependents []dto.Dependent
+	lockTarget func(ctx context.Context, id uuid.UUID) error // locks the record dependents would move to
+	remove     func() error
+	move       func(to uuid.UUID) error
+}
+
+// applyDeletePolicy deals with the dependents of the record id before it is deleted. Cascade deletes them and
+// reassign moves them to reassignTo; restrict, or reassign with nowhere to move them to, refuses while there are any
+func applyDeletePolicy(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID, relationship deleteRelationship) error {
+	if reassignTo != nil {
+		if relationship.policy != constants.DeletePolicyReassign {
+			return errors.New(constants.ErrorInvalidReassignTarget + ": reassign_to needs the reassign delete policy")
+		}
+		if *reassignTo == id {
+			return fmt.Errorf("%s: reassign_to must be another %s", constants.ErrorInvalidReassignTarget, relationship.parent)
+		}
+		if err := relationship.lockTarget(ctx, *reassignTo); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%s: %s %s not found", constants.ErrorInvalidReassignTarget, relationship.parent, *reassignTo)
+			}
+			return err
+		}
+	}
+
+	if len(relationship.dependents) == 0 {
+		return nil
+	}
+	switch {
+	case relationship.policy == constants.DeletePolicyCascade:
+		return relationship.remove()
+	case relationship.policy == constants.DeletePolicyReassign && reassignTo != nil:
+		return relationship.move(*reassignTo)
+	}
+	return &dto.DeleteConflict{Dependents: relationship.dependents}
+}
+
+// theatreDependents lists theatres as dependents
+func theatreDependents(theatres []*models.Theatre) []dto.Dependent {
+	dependents := make([]dto.Dependent, len(theatres))
+	for i, theatre := range theatres {
+		dependents[i] = dto.Dependent{Type: constants.EventAggregateTheatre, ID: theatre.ID, Name: theatre.Name}
+	}
+	return dependents
+}
+
+// showDependents lists shows as dependents
+func showDependents(shows []*models.Show) []dto.Dependent {
+	dependents := make([]dto.Dependent, len(shows))
+	for i, show := range shows {
+		dependents[i] = dto.Dependent{Type: constants.EventAggregateShow, ID: show.ID, Name: show.Title}
+	}
+	return dependents
+}
+
+// deleteTheatres deletes theatres through their service, so each applies its own policy to its shows
+func deleteTheatres(ctx context.Context, services *Services, theatres []*models.Theatre) error {
+	for _, theatre := range theatres {
+		if err := services.Theatres.DeleteTheatre(ctx, theatre.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteShows deletes shows through their service
+func deleteShows(ctx context.Context, services *Services, shows []*models.Show) error {
+	for _, show := range shows {
+		if err := services.Shows.DeleteShow(ctx, show.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveTheatres repoints theatres through the normal update, so each move is validated, audited and versioned
+func moveTheatres(ctx context.Context, services *Services, theatres []*models.Theatre, repoint func(*dto.TheatreBase)) error {
+	mapper := mappers.NewTheatreMapper()
+	for _, theatre := range theatres {
+		theatreDTO := mapper.ToBaseDTO(theatre)
+		repoint(theatreDTO)
+		if _, err := services.Theatres.UpdateTheatre(ctx, theatre.ID, theatreDTO); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveShows repoints shows through the normal update, so each move is validated, audited and versioned
+func moveShows(ctx context.Context, services *Services, shows []*models.Show, repoint func(*dto.ShowBase)) error {
+	mapper := mappers.NewShowMapper()
+	for _, show := range shows {
+		showDTO := mapper.ToBaseDTO(show)
+		repoint(showDTO)
+		if _, err := services.Shows.UpdateShow(ctx, show.ID, showDTO); err != nil {
+			return err
+		}
+	}
+	return nil
+}
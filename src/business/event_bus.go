@@ -0,0 +1,129 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+)
+
+// eventSubscriber is one registered handler
+type eventSubscriber struct {
+	handler interfaces.EventHandler
+	async   bool
+}
+
+// eventBus implements the EventBus interface in process
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]eventSubscriber
+
+	// Asynchronous deliveries are tracked so shutdown can wait for them; once it has started, none are added
+	pending  sync.WaitGroup
+	stopping bool
+}
+
+// NewEventBus creates an in-process event bus with no subscribers
+func NewEventBus() interfaces.EventBus {
+	return &eventBus{
+		subscribers: make(map[string][]eventSubscriber),
+	}
+}
+
+// Subscribe registers a handler that runs before Publish returns
+func (b *eventBus) Subscribe(eventName string, handler interfaces.EventHandler) {
+	b.subscribe(eventName, eventSubscriber{handler: handler})
+}
+
+// SubscribeAsync registers a handler that runs in its own goroutine
+func (b *eventBus) SubscribeAsync(eventName string, handler interfaces.EventHandler) {
+	b.subscribe(eventName, eventSubscriber{handler: handler, async: true})
+}
+
+// subscribe adds a subscriber under an event name
+func (b *eventBus) subscribe(eventName string, subscriber eventSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventName] = append(b.subscribers[eventName], subscriber)
+}
+
+// Publish delivers each event to its own subscribers and to those of every event, in subscription order
+func (b *eventBus) Publish(ctx context.Context, events ...interfaces.Event) {
+	for _, event := range events {
+		b.mu.RLock()
+		subscribers := append(append([]eventSubscriber{}, b.subscribers[event.EventName()]...), b.subscribers[constants.EventAll]...)
+		b.mu.RUnlock()
+
+		for _, subscriber := range subscribers {
+			if !subscriber.async || !b.startAsync() {
+				// A request still running when shutdown began delivers in its own goroutine instead
+				b.deliver(ctx, subscriber.handler, event)
+				continue
+			}
+
+			// The delivery outlives the request, so it keeps the request's values but not its cancellation
+			go func(handler interfaces.EventHandler, event interfaces.Event) {
+				defer b.pending.Done()
+				b.deliver(context.WithoutCancel(ctx), handler, event)
+			}(subscriber.handler, event)
+		}
+	}
+}
+
+// startAsync counts an asynchronous delivery in, unless shutdown has started waiting for them
+func (b *eventBus) startAsync() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.stopping {
+		return false
+	}
+	b.pending.Add(1)
+	return true
+}
+
+// deliver runs one handler, so a panicking subscriber can't break the publisher or the other subscribers
+func (b *eventBus) deliver(ctx context.Context, handler interfaces.EventHandler, event interfaces.Event) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(ctx, "Event subscriber panicked",
+				slog.String("event", event.EventName()),
+				slog.String("entity_id", event.EntityID().String()),
+				slog.String("panic", fmt.Sprint(recovered)),
+				slog.String("stack", string(debug.Stack())),
+			)
+		}
+	}()
+
+	handler(ctx, event)
+}
+
+// Shutdown waits for asynchronous deliveries in flight, or until ctx is done
+func (b *eventBus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.stopping = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// LogEvent is a subscriber that records each domain event in the log
+func LogEvent(ctx context.Context, event interfaces.Event) {
+	slog.InfoContext(ctx, "Domain event",
+		slog.String("event", event.EventName()),
+		slog.String("entity_id", event.EntityID().String()),
+	)
+}
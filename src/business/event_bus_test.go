@@ -0,0 +1,52 @@
+package business
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/google/uuid"
+)
+
+func TestEventBusShutdownWaitsForAsyncDeliveries(t *testing.T) {
+	bus := NewEventBus()
+	release := make(chan struct{})
+	var delivered sync.WaitGroup
+	delivered.Add(1)
+	bus.SubscribeAsync(constants.EventAll, func(context.Context, interfaces.Event) {
+		<-release
+		delivered.Done()
+	})
+
+	bus.Publish(context.Background(), TheatreTypeCreated{TheatreType: &dto.TheatreTypeDetails{ID: uuid.New()}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bus.Shutdown(ctx); err == nil {
+		t.Fatal("Shutdown returned before the delivery in flight finished")
+	}
+	close(release)
+	delivered.Wait()
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}
+
+func TestEventBusDeliversInPublisherOnceShuttingDown(t *testing.T) {
+	bus := NewEventBus()
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	delivered := false
+	bus.SubscribeAsync(constants.EventAll, func(context.Context, interfaces.Event) { delivered = true })
+
+	// Published by a request still running after shutdown started, as when http.Server.Shutdown times out
+	bus.Publish(context.Background(), TheatreTypeCreated{TheatreType: &dto.TheatreTypeDetails{ID: uuid.New()}})
+	if !delivered {
+		t.Error("Publish after Shutdown didn't deliver before returning")
+	}
+}
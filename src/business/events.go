@@ -0,0 +1,363 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+)
+
+// LocationCreated is published when a location is created
+type LocationCreated struct {
+	Location *dto.LocationDetails `json:"location"`
+}
+
+// EventName returns "location.created"
+func (e LocationCreated) EventName() string { return constants.EventLocationCreated }
+
+// EntityID returns the location's ID
+func (e LocationCreated) EntityID() uuid.UUID { return e.Location.ID }
+
+// LocationUpdated is published when a location changes, listing the JSON fields that changed
+type LocationUpdated struct {
+	Location      *dto.LocationDetails `json:"location"`
+	ChangedFields []string             `json:"changed_fields"`
+}
+
+// EventName returns "location.updated"
+func (e LocationUpdated) EventName() string { return constants.EventLocationUpdated }
+
+// EntityID returns the location's ID
+func (e LocationUpdated) EntityID() uuid.UUID { return e.Location.ID }
+
+// LocationDeleted is published when a location is deleted
+type LocationDeleted struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// EventName returns "location.deleted"
+func (e LocationDeleted) EventName() string { return constants.EventLocationDeleted }
+
+// EntityID returns the deleted location's ID
+func (e LocationDeleted) EntityID() uuid.UUID { return e.ID }
+
+// LocationRestored is published when a location is taken out of the trash
+type LocationRestored struct {
+	Location *dto.LocationDetails `json:"location"`
+}
+
+// EventName returns "location.restored"
+func (e LocationRestored) EventName() string { return constants.EventLocationRestored }
+
+// EntityID returns the location's ID
+func (e LocationRestored) EntityID() uuid.UUID { return e.Location.ID }
+
+// TheatreTypeCreated is published when a theatre type is created
+type TheatreTypeCreated struct {
+	TheatreType *dto.TheatreTypeDetails `json:"theatre_type"`
+}
+
+// EventName returns "theatre_type.created"
+func (e TheatreTypeCreated) EventName() string { return constants.EventTheatreTypeCreated }
+
+// EntityID returns the theatre type's ID
+func (e TheatreTypeCreated) EntityID() uuid.UUID { return e.TheatreType.ID }
+
+// TheatreTypeUpdated is published when a theatre type changes, listing the JSON fields that changed
+type TheatreTypeUpdated struct {
+	TheatreType   *dto.TheatreTypeDetails `json:"theatre_type"`
+	ChangedFields []string                `json:"changed_fields"`
+}
+
+// EventName returns "theatre_type.updated"
+func (e TheatreTypeUpdated) EventName() string { return constants.EventTheatreTypeUpdated }
+
+// EntityID returns the theatre type's ID
+func (e TheatreTypeUpdated) EntityID() uuid.UUID { return e.TheatreType.ID }
+
+// TheatreTypeDeleted is published when a theatre type is deleted
+type TheatreTypeDeleted struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// EventName returns "theatre_type.deleted"
+func (e TheatreTypeDeleted) EventName() string { return constants.EventTheatreTypeDeleted }
+
+// EntityID returns the deleted theatre type's ID
+func (e TheatreTypeDeleted) EntityID() uuid.UUID { return e.ID }
+
+// TheatreTypeRestored is published when a theatre type is taken out of the trash
+type TheatreTypeRestored struct {
+	TheatreType *dto.TheatreTypeDetails `json:"theatre_type"`
+}
+
+// EventName returns "theatre_type.restored"
+func (e TheatreTypeRestored) EventName() string { return constants.EventTheatreTypeRestored }
+
+// EntityID returns the theatre type's ID
+func (e TheatreTypeRestored) EntityID() uuid.UUID { return e.TheatreType.ID }
+
+// ShowTypeCreated is published when a show type is created
+type ShowTypeCreated struct {
+	ShowType *dto.ShowTypeDetails `json:"show_type"`
+}
+
+// EventName returns "show_type.created"
+func (e ShowTypeCreated) EventName() string { return constants.EventShowTypeCreated }
+
+// EntityID returns the show type's ID
+func (e ShowTypeCreated) EntityID() uuid.UUID { return e.ShowType.ID }
+
+// ShowTypeUpdated is published when a show type changes, listing the JSON fields that changed
+type ShowTypeUpdated struct {
+	ShowType      *dto.ShowTypeDetails `json:"show_type"`
+	ChangedFields []string             `json:"changed_fields"`
+}
+
+// EventName returns "show_type.updated"
+func (e ShowTypeUpdated) EventName() string { return constants.EventShowTypeUpdated }
+
+// EntityID returns the show type's ID
+func (e ShowTypeUpdated) EntityID() uuid.UUID { return e.ShowType.ID }
+
+// ShowTypeDeleted is published when a show type is deleted
+type ShowTypeDeleted struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// EventName returns "show_type.deleted"
+func (e ShowTypeDeleted) EventName() string { return constants.EventShowTypeDeleted }
+
+// EntityID returns the deleted show type's ID
+func (e ShowTypeDeleted) EntityID() uuid.UUID { return e.ID }
+
+// ShowTypeRestored is published when a show type is taken out of the trash
+type ShowTypeRestored struct {
+	ShowType *dto.ShowTypeDetails `json:"show_type"`
+}
+
+// EventName returns "show_type.restored"
+func (e ShowTypeRestored) EventName() string { return constants.EventShowTypeRestored }
+
+// EntityID returns the show type's ID
+func (e ShowTypeRestored) EntityID() uuid.UUID { return e.ShowType.ID }
+
+// TheatreCreated is published when a theatre is created
+type TheatreCreated struct {
+	Theatre *dto.TheatreDetails `json:"theatre"`
+}
+
+// EventName returns "theatre.created"
+func (e TheatreCreated) EventName() string { return constants.EventTheatreCreated }
+
+// EntityID returns the theatre's ID
+func (e TheatreCreated) EntityID() uuid.UUID { return e.Theatre.ID }
+
+// TheatreUpdated is published when a theatre changes, listing the JSON fields that changed
+type TheatreUpdated struct {
+	Theatre       *dto.TheatreDetails `json:"theatre"`
+	ChangedFields []string            `json:"changed_fields"`
+}
+
+// EventName returns "theatre.updated"
+func (e TheatreUpdated) EventName() string { return constants.EventTheatreUpdated }
+
+// EntityID returns the theatre's ID
+func (e TheatreUpdated) EntityID() uuid.UUID { return e.Theatre.ID }
+
+// TheatreDeleted is published when a theatre is deleted
+type TheatreDeleted struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// EventName returns "theatre.deleted"
+func (e TheatreDeleted) EventName() string { return constants.EventTheatreDeleted }
+
+// EntityID returns the deleted theatre's ID
+func (e TheatreDeleted) EntityID() uuid.UUID { return e.ID }
+
+// TheatreRestored is published when a theatre is taken out of the trash
+type TheatreRestored struct {
+	Theatre *dto.TheatreDetails `json:"theatre"`
+}
+
+// EventName returns "theatre.restored"
+func (e TheatreRestored) EventName() string { return constants.EventTheatreRestored }
+
+// EntityID returns the theatre's ID
+func (e TheatreRestored) EntityID() uuid.UUID { return e.Theatre.ID }
+
+// TheatreFeatured is published, alongside TheatreCreated or TheatreUpdated, when a theatre starts being featured
+type TheatreFeatured struct {
+	Theatre *dto.TheatreDetails `json:"theatre"`
+}
+
+// EventName returns "theatre.featured"
+func (e TheatreFeatured) EventName() string { return constants.EventTheatreFeatured }
+
+// EntityID returns the theatre's ID
+func (e TheatreFeatured) EntityID() uuid.UUID { return e.Theatre.ID }
+
+// TheatreUnfeatured is published, alongside TheatreCreated or TheatreUpdated, when a theatre stops being featured
+type TheatreUnfeatured struct {
+	Theatre *dto.TheatreDetails `json:"theatre"`
+}
+
+// EventName returns "theatre.unfeatured"
+func (e TheatreUnfeatured) EventName() string { return constants.EventTheatreUnfeatured }
+
+// EntityID returns the theatre's ID
+func (e TheatreUnfeatured) EntityID() uuid.UUID { return e.Theatre.ID }
+
+// ShowCreated is published when a show is created
+type ShowCreated struct {
+	Show *dto.ShowDetails `json:"show"`
+}
+
+// EventName returns "show.created"
+func (e ShowCreated) EventName() string { return constants.EventShowCreated }
+
+// EntityID returns the show's ID
+func (e ShowCreated) EntityID() uuid.UUID { return e.Show.ID }
+
+// ShowUpdated is published when a show changes, listing the JSON fields that changed
+type ShowUpdated struct {
+	Show          *dto.ShowDetails `json:"show"`
+	ChangedFields []string         `json:"changed_fields"`
+}
+
+// EventName returns "show.updated"
+func (e ShowUpdated) EventName() string { return constants.EventShowUpdated }
+
+// EntityID returns the show's ID
+func (e ShowUpdated) EntityID() uuid.UUID { return e.Show.ID }
+
+// ShowDeleted is published when a show is deleted, naming its theatre and show type so subscribers scoped to them hear of it
+type ShowDeleted struct {
+	ID         uuid.UUID `json:"id"`
+	TheatreID  uuid.UUID `json:"theatre_id"`
+	ShowTypeID uuid.UUID `json:"show_type_id"`
+}
+
+// EventName returns "show.deleted"
+func (e ShowDeleted) EventName() string { return constants.EventShowDeleted }
+
+// EntityID returns the deleted show's ID
+func (e ShowDeleted) EntityID() uuid.UUID { return e.ID }
+
+// ShowRestored is published when a show is taken out of the trash
+type ShowRestored struct {
+	Show *dto.ShowDetails `json:"show"`
+}
+
+// EventName returns "show.restored"
+func (e ShowRestored) EventName() string { return constants.EventShowRestored }
+
+// EntityID returns the show's ID
+func (e ShowRestored) EntityID() uuid.UUID { return e.Show.ID }
+
+// ShowFeatured is published, alongside ShowCreated or ShowUpdated, when a show starts being featured
+type ShowFeatured struct {
+	Show *dto.ShowDetails `json:"show"`
+}
+
+// EventName returns "show.featured"
+func (e ShowFeatured) EventName() string { return constants.EventShowFeatured }
+
+// EntityID returns the show's ID
+func (e ShowFeatured) EntityID() uuid.UUID { return e.Show.ID }
+
+// ShowUnfeatured is published, alongside ShowCreated or ShowUpdated, when a show stops being featured
+type ShowUnfeatured struct {
+	Show *dto.ShowDetails `json:"show"`
+}
+
+// EventName returns "show.unfeatured"
+func (e ShowUnfeatured) EventName() string { return constants.EventShowUnfeatured }
+
+// EntityID returns the show's ID
+func (e ShowUnfeatured) EntityID() uuid.UUID { return e.Show.ID }
+
+// recordEvents writes events to the outbox in tx's transaction, so they commit or roll back with the change,
+// and hands them to the in-process publisher once that transaction commits
+func recordEvents(ctx context.Context, tx interfaces.UnitOfWork, publisher interfaces.EventPublisher, events ...interfaces.Event) error {
+	messages := make([]*models.OutboxMessage, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		aggregateType, _, _ := strings.Cut(event.EventName(), ".")
+		messages[i] = &models.OutboxMessage{
+			AggregateType: aggregateType,
+			AggregateID:   event.EntityID(),
+			EventName:     event.EventName(),
+			Payload:       payload,
+			RequestID:     logging.RequestID(ctx),
+			Status:        constants.OutboxStatusPending,
+		}
+	}
+	if err := tx.Outbox().Append(ctx, messages...); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(func() {
+		publisher.Publish(ctx, events...)
+	})
+	return nil
+}
+
+// changedFields lists the top-level JSON fields that differ between two versions of an entity, ignoring updated_at
+func changedFields(before, after interface{}) []string {
+	beforeFields, afterFields := jsonFields(before), jsonFields(after)
+
+	changed := []string{}
+	for name, value := range afterFields {
+		if name != "updated_at" && !reflect.DeepEqual(beforeFields[name], value) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// jsonFields decodes a DTO into its JSON object fields
+func jsonFields(v interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+// theatreFeaturedEvents reports whether a theatre started or stopped being featured; before is nil for a new theatre
+func theatreFeaturedEvents(before, after *dto.TheatreDetails) []interfaces.Event {
+	wasFeatured := before != nil && before.IsFeatured
+	switch {
+	case after.IsFeatured && !wasFeatured:
+		return []interfaces.Event{TheatreFeatured{Theatre: after}}
+	case !after.IsFeatured && wasFeatured:
+		return []interfaces.Event{TheatreUnfeatured{Theatre: after}}
+	}
+	return nil
+}
+
+// showFeaturedEvents reports whether a show started or stopped being featured; before is nil for a new show
+func showFeaturedEvents(before, after *dto.ShowDetails) []interfaces.Event {
+	wasFeatured := before != nil && before.IsFeatured
+	switch {
+	case after.IsFeatured && !wasFeatured:
+		return []interfaces.Event{ShowFeatured{Show: after}}
+	case !after.IsFeatured && wasFeatured:
+		return []interfaces.Event{ShowUnfeatured{Show: after}}
+	}
+	return nil
+}
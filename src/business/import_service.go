@@ -0,0 +1,799 @@
+package business
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// errImportRolledBack signals that a dry run or failed atomic import must discard its transaction
+	errImportRolledBack = errors.New("import rolled back")
+
+	// errImportRowFailed discards a failed row's writes without aborting the rest of the import
+	errImportRowFailed = errors.New("import row failed")
+)
+
+// importJobRetention is how long a job stays pollable after its last update
+const importJobRetention = time.Duration(constants.ImportJobRetention) * time.Second
+
+// importRow is one parsed record of an uploaded file
+type importRow struct {
+	line   int
+	fields map[string]interface{}
+	err    error
+}
+
+// importService implements the ImportService interface
+type importService struct {
+	uow     interfaces.UnitOfWork
+	events  interfaces.EventPublisher
+	metrics interfaces.BusinessMetrics
+	mu      sync.Mutex
+
+	// Background jobs are tracked so shutdown can wait for them, and cancelled if it can't wait any longer.
+	// Each holds its parsed file in memory, so only ImportMaxBackgroundJobs run at once
+	background sync.WaitGroup
+	running    int
+	stopping   bool
+	stop       context.Context
+	cancel     context.CancelFunc
+}
+
+// NewImportService creates a new import service
+func NewImportService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher) interfaces.ImportService {
+	stop, cancel := context.WithCancel(context.Background())
+	return &importService{
+		uow:     uow,
+		events:  events,
+		metrics: metrics,
+		stop:    stop,
+		cancel:  cancel,
+	}
+}
+
+// StartImport parses an uploaded file and imports it, in the background when the file is large
+func (s *importService) StartImport(ctx context.Context, data []byte, options dto.ImportOptions) (*dto.ImportJob, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.StartImport")
+	defer span.End()
+
+	if err := s.normalizeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	rows, err := parseImportRows(data, options.Format)
+	if err != nil {
+		return nil, errors.New(constants.ErrorImportInvalid + ": " + err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrorImportInvalid + ": file contains no rows")
+	}
+
+	s.purgeJobs(ctx)
+	job := &models.ImportJob{
+		Status: constants.ImportJobPending,
+		Total:  len(rows),
+	}
+
+	if options.Async || len(rows) > constants.ImportAsyncThreshold {
+		if err := s.acquireBackgroundSlot(); err != nil {
+			return nil, err
+		}
+		if err := s.uow.ImportJobs().Create(ctx, job); err != nil {
+			s.releaseBackgroundSlot()
+			return nil, err
+		}
+		accepted, err := toImportJobDTO(job)
+		if err != nil {
+			s.releaseBackgroundSlot()
+			return nil, err
+		}
+
+		// Background jobs outlive the request, so they keep its values but not its cancellation;
+		// only a shutdown that runs out of time cancels them
+		jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		stopJob := context.AfterFunc(s.stop, cancel)
+
+		go func() {
+			defer s.releaseBackgroundSlot()
+			defer cancel()
+			defer stopJob()
+			s.runJob(jobCtx, job, rows, options)
+		}()
+		return accepted, nil
+	}
+
+	if err := s.uow.ImportJobs().Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.runJob(ctx, job, rows, options)
+	return toImportJobDTO(job)
+}
+
+// acquireBackgroundSlot reserves one of the background job slots, unless shutting down or all are taken
+func (s *importService) acquireBackgroundSlot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping {
+		return errors.New(constants.ErrorShuttingDown)
+	}
+	if s.running >= constants.ImportMaxBackgroundJobs {
+		return errors.New(constants.ErrorTooManyImports)
+	}
+	s.running++
+	s.background.Add(1)
+	return nil
+}
+
+// releaseBackgroundSlot frees a slot taken by acquireBackgroundSlot
+func (s *importService) releaseBackgroundSlot() {
+	s.mu.Lock()
+	s.running--
+	s.mu.Unlock()
+	s.background.Done()
+}
+
+// Shutdown stops accepting background jobs and waits for running ones, cancelling them if ctx expires first
+func (s *importService) Shutdown(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "ImportService.Shutdown")
+	defer span.End()
+
+	s.mu.Lock()
+	s.stopping = true
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// GetImportJob returns an import job's progress and report, whichever instance is running it
+func (s *importService) GetImportJob(ctx context.Context, id uuid.UUID) (*dto.ImportJob, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.GetImportJob")
+	defer span.End()
+
+	job, err := s.uow.ImportJobs().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorImportJobNotFound)
+		}
+		return nil, err
+	}
+
+	// Jobs past their retention that haven't been purged yet are gone all the same
+	if time.Since(job.UpdatedAt) > importJobRetention {
+		return nil, errors.New(constants.ErrorImportJobNotFound)
+	}
+
+	return toImportJobDTO(job)
+}
+
+// purgeJobs removes jobs past their retention; a failure leaves them for the next import to purge
+func (s *importService) purgeJobs(ctx context.Context) {
+	if _, err := s.uow.ImportJobs().DeleteUpdatedBefore(ctx, time.Now().Add(-importJobRetention)); err != nil {
+		slog.ErrorContext(ctx, "Failed to purge import jobs", "error", err)
+	}
+}
+
+// toImportJobDTO converts a stored job to its response
+func toImportJobDTO(job *models.ImportJob) (*dto.ImportJob, error) {
+	response := &dto.ImportJob{
+		ID:          job.ID,
+		Status:      job.Status,
+		Processed:   job.Processed,
+		Total:       job.Total,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+	if len(job.Report) > 0 {
+		if err := json.Unmarshal(job.Report, &response.Report); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// normalizeOptions applies defaults and rejects unsupported entities, formats and modes
+func (s *importService) normalizeOptions(options *dto.ImportOptions) error {
+	options.Entity = strings.ToLower(options.Entity)
+	options.Format = strings.ToLower(options.Format)
+	options.Mode = strings.ToLower(options.Mode)
+
+	switch options.Entity {
+	case constants.ImportEntityLocations, constants.ImportEntityTheatres, constants.ImportEntityShows:
+	default:
+		return fmt.Errorf("%s: unsupported entity %q", constants.ErrorImportInvalid, options.Entity)
+	}
+
+	switch options.Format {
+	case constants.ImportFormatCSV, constants.ImportFormatNDJSON:
+	default:
+		return fmt.Errorf("%s: unsupported format %q", constants.ErrorImportInvalid, options.Format)
+	}
+
+	switch options.Mode {
+	case "":
+		options.Mode = constants.ImportModeAtomic
+	case constants.ImportModeAtomic, constants.ImportModePartial:
+	default:
+		return fmt.Errorf("%s: unsupported mode %q", constants.ErrorImportInvalid, options.Mode)
+	}
+
+	return nil
+}
+
+// runJob runs an import and records its outcome on the job
+func (s *importService) runJob(ctx context.Context, job *models.ImportJob, rows []importRow, options dto.ImportOptions) {
+	job.Status = constants.ImportJobRunning
+	s.saveJob(ctx, job)
+
+	report, err := s.runImportSafely(ctx, job, rows, options)
+	if err == nil {
+		s.countCreated(report)
+	}
+
+	now := time.Now()
+	job.CompletedAt = &now
+	job.Status = constants.ImportJobCompleted
+	if err != nil {
+		job.Status = constants.ImportJobFailed
+		job.Error = err.Error()
+	}
+	if report != nil {
+		encoded, encodeErr := json.Marshal(report)
+		if encodeErr != nil {
+			job.Status = constants.ImportJobFailed
+			job.Error = encodeErr.Error()
+		}
+		job.Report = encoded
+	}
+
+	// A cancelled job still records that it failed
+	s.saveJob(context.WithoutCancel(ctx), job)
+}
+
+// saveJob stores a job's progress; the import carries on if it can't, and pollers see the next update
+func (s *importService) saveJob(ctx context.Context, job *models.ImportJob) {
+	if err := s.uow.ImportJobs().Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "Failed to save import job", "job_id", job.ID.String(), "error", err)
+	}
+}
+
+// runImportSafely keeps a panicking row from taking down the process when running in the background
+func (s *importService) runImportSafely(ctx context.Context, job *models.ImportJob, rows []importRow, options dto.ImportOptions) (report *dto.ImportReport, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("import aborted: %v", r)
+		}
+	}()
+
+	return s.runImport(ctx, job, rows, options)
+}
+
+// countCreated records the rows that were committed; rolled back and dry-run rows no longer report created
+func (s *importService) countCreated(report *dto.ImportReport) {
+	created := 0
+	for _, row := range report.Rows {
+		if row.Status == constants.ImportRowCreated {
+			created++
+		}
+	}
+	if created > 0 {
+		s.metrics.EntitiesCreated(report.Entity, created)
+	}
+}
+
+// runImport imports every row, either per-row or inside a single transaction
+func (s *importService) runImport(ctx context.Context, job *models.ImportJob, rows []importRow, options dto.ImportOptions) (*dto.ImportReport, error) {
+	report := &dto.ImportReport{
+		Entity: options.Entity,
+		Format: options.Format,
+		Mode:   options.Mode,
+		DryRun: options.DryRun,
+		Total:  len(rows),
+		Rows:   make([]dto.ImportRowResult, 0, len(rows)),
+	}
+
+	// Partial imports commit each valid row on its own
+	if options.Mode == constants.ImportModePartial && !options.DryRun {
+		services := NewServices(s.uow, s.events, noDeletes)
+		resolver := newImportResolver(services)
+		for _, row := range rows {
+			s.recordRow(ctx, job, report, s.importRow(ctx, services, resolver, row, options.Entity))
+		}
+		report.Committed = report.Succeeded > 0
+		return report, nil
+	}
+
+	// Atomic imports and dry runs share one transaction, nesting each row in its own
+	// so a failing row doesn't abort the statements that follow it
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		services := NewServices(tx, s.events, noDeletes)
+		resolver := newImportResolver(services)
+
+		for _, row := range rows {
+			var result dto.ImportRowResult
+			err := tx.Do(ctx, func(interfaces.UnitOfWork) error {
+				result = s.importRow(ctx, services, resolver, row, options.Entity)
+				if result.Status == constants.ImportRowFailed {
+					return errImportRowFailed
+				}
+				return nil
+			})
+			if err != nil && !errors.Is(err, errImportRowFailed) {
+				return err
+			}
+			s.recordRow(ctx, job, report, result)
+		}
+
+		if options.DryRun || report.Failed > 0 {
+			return errImportRolledBack
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return report, err
+	}
+
+	report.Committed = err == nil
+	if !report.Committed {
+		status := constants.ImportRowRolledBack
+		if options.DryRun {
+			status = constants.ImportRowValid
+		}
+		for i := range report.Rows {
+			if report.Rows[i].Status == constants.ImportRowCreated {
+				report.Rows[i].Status = status
+				report.Rows[i].ID = nil
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// recordRow appends a row result to the report and publishes progress periodically. Progress is saved
+// through the service's own unit of work, outside any import transaction, so pollers see it as it happens
+func (s *importService) recordRow(ctx context.Context, job *models.ImportJob, report *dto.ImportReport, result dto.ImportRowResult) {
+	report.Rows = append(report.Rows, result)
+	if result.Status == constants.ImportRowFailed {
+		report.Failed++
+	} else {
+		report.Succeeded++
+	}
+
+	processed := len(report.Rows)
+	if processed%constants.ImportProgressStep == 0 || processed == report.Total {
+		job.Processed = processed
+		s.saveJob(ctx, job)
+	}
+}
+
+// importRow creates one row through the entity's service so the same validation applies
+func (s *importService) importRow(ctx context.Context, services *Services, resolver *importResolver, row importRow, entity string) dto.ImportRowResult {
+	result := dto.ImportRowResult{Row: row.line}
+
+	var id uuid.UUID
+	errs := []string{}
+	if row.err != nil {
+		errs = append(errs, row.err.Error())
+	} else {
+		switch entity {
+		case constants.ImportEntityLocations:
+			id, errs = s.importLocation(ctx, services, row.fields)
+		case constants.ImportEntityTheatres:
+			id, errs = s.importTheatre(ctx, services, resolver, row.fields)
+		case constants.ImportEntityShows:
+			id, errs = s.importShow(ctx, services, resolver, row.fields)
+		}
+	}
+
+	if len(errs) > 0 {
+		result.Status = constants.ImportRowFailed
+		result.Errors = errs
+		return result
+	}
+
+	result.Status = constants.ImportRowCreated
+	result.ID = &id
+	return result
+}
+
+// importLocation creates a location row
+func (s *importService) importLocation(ctx context.Context, services *Services, fields map[string]interface{}) (uuid.UUID, []string) {
+	var locationDTO dto.LocationBase
+	if errs := decodeImportRow(fields, &locationDTO); len(errs) > 0 {
+		return uuid.Nil, errs
+	}
+
+	location, err := services.Locations.CreateLocation(ctx, &locationDTO)
+	if err != nil {
+		return uuid.Nil, []string{err.Error()}
+	}
+
+	return location.ID, nil
+}
+
+// importTheatre resolves a theatre row's type and location by natural key, then creates it
+func (s *importService) importTheatre(ctx context.Context, services *Services, resolver *importResolver, fields map[string]interface{}) (uuid.UUID, []string) {
+	var errs []string
+
+	typeName := takeImportField(fields, "theatre_type")
+	if typeName != "" {
+		typeID, err := resolver.theatreType(ctx, typeName)
+		if err != nil {
+			errs = append(errs, "theatre_type: "+err.Error())
+		} else {
+			fields["theatre_type_id"] = typeID.String()
+		}
+	}
+
+	locationName := takeImportField(fields, "location_name")
+	locationCity := takeImportField(fields, "location_city")
+	if locationName != "" || locationCity != "" {
+		locationID, err := resolver.location(ctx, locationName, locationCity)
+		if err != nil {
+			errs = append(errs, "location_name/location_city: "+err.Error())
+		} else {
+			fields["location_id"] = locationID.String()
+		}
+	}
+
+	var theatreDTO dto.TheatreBase
+	errs = append(errs, decodeImportRow(fields, &theatreDTO)...)
+	if len(errs) > 0 {
+		return uuid.Nil, errs
+	}
+
+	theatre, err := services.Theatres.CreateTheatre(ctx, &theatreDTO)
+	if err != nil {
+		return uuid.Nil, []string{err.Error()}
+	}
+
+	return theatre.ID, nil
+}
+
+// importShow resolves a show row's type and theatre by natural key, then creates it
+func (s *importService) importShow(ctx context.Context, services *Services, resolver *importResolver, fields map[string]interface{}) (uuid.UUID, []string) {
+	var errs []string
+
+	typeName := takeImportField(fields, "show_type")
+	if typeName != "" {
+		typeID, err := resolver.showType(ctx, typeName)
+		if err != nil {
+			errs = append(errs, "show_type: "+err.Error())
+		} else {
+			fields["show_type_id"] = typeID.String()
+		}
+	}
+
+	theatreName := takeImportField(fields, "theatre_name")
+	theatreCity := takeImportField(fields, "theatre_city")
+	if theatreName != "" || theatreCity != "" {
+		theatreID, err := resolver.theatre(ctx, theatreName, theatreCity)
+		if err != nil {
+			errs = append(errs, "theatre_name/theatre_city: "+err.Error())
+		} else {
+			fields["theatre_id"] = theatreID.String()
+		}
+	}
+
+	var showDTO dto.ShowBase
+	errs = append(errs, decodeImportRow(fields, &showDTO)...)
+	if len(errs) > 0 {
+		return uuid.Nil, errs
+	}
+
+	show, err := services.Shows.CreateShow(ctx, &showDTO)
+	if err != nil {
+		return uuid.Nil, []string{err.Error()}
+	}
+
+	return show.ID, nil
+}
+
+// importResolver looks up foreign keys by natural key, remembering answers for the rest of the file
+type importResolver struct {
+	services *Services
+	ids      map[string]uuid.UUID
+	errs     map[string]error
+}
+
+// newImportResolver creates a resolver backed by the given services
+func newImportResolver(services *Services) *importResolver {
+	return &importResolver{
+		services: services,
+		ids:      make(map[string]uuid.UUID),
+		errs:     make(map[string]error),
+	}
+}
+
+// theatreType resolves a theatre type ID by name
+func (r *importResolver) theatreType(ctx context.Context, name string) (uuid.UUID, error) {
+	return r.resolve("theatre_type|"+strings.ToLower(name), func() (uuid.UUID, error) {
+		theatreType, err := r.services.TheatreTypes.GetTheatreTypeByName(ctx, name)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return theatreType.ID, nil
+	})
+}
+
+// showType resolves a show type ID by name
+func (r *importResolver) showType(ctx context.Context, name string) (uuid.UUID, error) {
+	return r.resolve("show_type|"+strings.ToLower(name), func() (uuid.UUID, error) {
+		showType, err := r.services.ShowTypes.GetShowTypeByName(ctx, name)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return showType.ID, nil
+	})
+}
+
+// location resolves a location ID by name and city
+func (r *importResolver) location(ctx context.Context, name, city string) (uuid.UUID, error) {
+	return r.resolve("location|"+strings.ToLower(name)+"|"+strings.ToLower(city), func() (uuid.UUID, error) {
+		location, err := r.services.Locations.GetLocationByNameAndCity(ctx, name, city)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return location.ID, nil
+	})
+}
+
+// theatre resolves a theatre ID by name and city
+func (r *importResolver) theatre(ctx context.Context, name, city string) (uuid.UUID, error) {
+	return r.resolve("theatre|"+strings.ToLower(name)+"|"+strings.ToLower(city), func() (uuid.UUID, error) {
+		theatre, err := r.services.Theatres.GetTheatreByNameAndCity(ctx, name, city)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return theatre.ID, nil
+	})
+}
+
+// resolve returns a cached lookup result, running the lookup on first use
+func (r *importResolver) resolve(key string, lookup func() (uuid.UUID, error)) (uuid.UUID, error) {
+	if id, ok := r.ids[key]; ok {
+		return id, nil
+	}
+	if err, ok := r.errs[key]; ok {
+		return uuid.Nil, err
+	}
+
+	id, err := lookup()
+	if err != nil {
+		r.errs[key] = err
+		return uuid.Nil, err
+	}
+
+	r.ids[key] = id
+	return id, nil
+}
+
+// takeImportField removes a natural-key column from the row and returns its text
+func takeImportField(fields map[string]interface{}, name string) string {
+	value, ok := fields[name]
+	if !ok {
+		return ""
+	}
+	delete(fields, name)
+
+	if text, ok := value.(string); ok {
+		return strings.TrimSpace(text)
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// parseImportRows splits an uploaded file into rows keyed by column name
+func parseImportRows(data []byte, format string) ([]importRow, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 byte order mark from spreadsheet exports
+
+	if format == constants.ImportFormatNDJSON {
+		return parseNDJSONRows(data)
+	}
+	return parseCSVRows(data)
+}
+
+// parseCSVRows reads a CSV file whose first line names the columns
+func parseCSVRows(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		row := importRow{fields: make(map[string]interface{}, len(header))}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.line = parseErr.StartLine
+		} else if len(record) > 0 {
+			row.line, _ = reader.FieldPos(0)
+		}
+
+		switch {
+		case err != nil:
+			row.err = err
+		case len(record) != len(header):
+			row.err = fmt.Errorf("expected %d columns, got %d", len(header), len(record))
+		default:
+			for i, value := range record {
+				row.fields[header[i]] = value
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseNDJSONRows reads one JSON object per line, skipping blank lines
+func parseNDJSONRows(data []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var rows []importRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := importRow{line: line}
+		if err := json.Unmarshal(text, &row.fields); err != nil {
+			row.err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// decodeImportRow converts loosely typed columns to the DTO's field types and decodes them,
+// reporting unknown columns and unparsable values
+func decodeImportRow(fields map[string]interface{}, dest interface{}) []string {
+	var errs []string
+
+	destType := reflect.TypeOf(dest).Elem()
+	for i := 0; i < destType.NumField(); i++ {
+		field := destType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		text, ok := fields[name].(string)
+		if !ok {
+			continue
+		}
+
+		value, err := convertImportValue(strings.TrimSpace(text), field.Type)
+		if err != nil {
+			errs = append(errs, name+": "+err.Error())
+			delete(fields, name)
+			continue
+		}
+		if value == nil {
+			delete(fields, name)
+			continue
+		}
+		fields[name] = value
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dest); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+// convertImportValue parses text for the given field type; empty text means the field is omitted
+func convertImportValue(text string, fieldType reflect.Type) (interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		// Accept bare dates as well as full RFC 3339 timestamps
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if parsed, err := time.Parse(layout, text); err == nil {
+				return parsed.Format(time.RFC3339), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q", text)
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", text)
+		}
+		return number, nil
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", text)
+		}
+		return number, nil
+	case reflect.Bool:
+		flag, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", text)
+		}
+		return flag, nil
+	default:
+		return text, nil
+	}
+}
@@ -0,0 +1,102 @@
+package business_test
+
+import (
+	"context"
+	"testing"
+	"theatre-management-system/src/business"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/testdb"
+	"time"
+)
+
+const locationsCSV = "name,city,country\nPalace,London,United Kingdom\nLyceum,London,United Kingdom\nApollo,London,United Kingdom\n"
+
+// blockingUnitOfWork holds every transaction until release is closed
+type blockingUnitOfWork struct {
+	interfaces.UnitOfWork
+	release chan struct{}
+}
+
+func (u *blockingUnitOfWork) Do(ctx context.Context, fn func(tx interfaces.UnitOfWork) error) error {
+	<-u.release
+	return u.UnitOfWork.Do(ctx, fn)
+}
+
+// waitForImportJob polls service until the job finishes
+func waitForImportJob(t *testing.T, service interfaces.ImportService, job *dto.ImportJob) *dto.ImportJob {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		polled, err := service.GetImportJob(context.Background(), job.ID)
+		if err != nil {
+			t.Fatalf("GetImportJob: %v", err)
+		}
+		if polled.Status == constants.ImportJobCompleted || polled.Status == constants.ImportJobFailed {
+			return polled
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("import job %s did not finish", job.ID)
+	return nil
+}
+
+func TestImportJobIsVisibleToOtherInstances(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	running := business.NewImportService(repo.NewUnitOfWork(db), noMetrics{}, &recordingPublisher{})
+	polling := business.NewImportService(repo.NewUnitOfWork(db), noMetrics{}, &recordingPublisher{})
+
+	options := dto.ImportOptions{Entity: constants.ImportEntityLocations, Format: constants.ImportFormatCSV, Async: true}
+	job, err := running.StartImport(ctx, []byte(locationsCSV), options)
+	if err != nil {
+		t.Fatalf("StartImport: %v", err)
+	}
+
+	finished := waitForImportJob(t, polling, job)
+	if finished.Status != constants.ImportJobCompleted || finished.Processed != 3 || finished.Report == nil || finished.Report.Succeeded != 3 {
+		t.Errorf("polled %+v, want a completed job with 3 rows created", finished)
+	}
+	if err := running.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}
+
+func TestImportCapsBackgroundJobs(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	uow := &blockingUnitOfWork{UnitOfWork: repo.NewUnitOfWork(db), release: make(chan struct{})}
+	service := business.NewImportService(uow, noMetrics{}, &recordingPublisher{})
+
+	options := dto.ImportOptions{Entity: constants.ImportEntityLocations, Format: constants.ImportFormatCSV, Async: true, DryRun: true}
+	var jobs []*dto.ImportJob
+	for i := 0; i < constants.ImportMaxBackgroundJobs; i++ {
+		job, err := service.StartImport(ctx, []byte(locationsCSV), options)
+		if err != nil {
+			t.Fatalf("StartImport %d: %v", i+1, err)
+		}
+		jobs = append(jobs, job)
+	}
+	if _, err := service.StartImport(ctx, []byte(locationsCSV), options); err == nil || err.Error() != constants.ErrorTooManyImports {
+		t.Fatalf("StartImport past the cap returned %v, want %s", err, constants.ErrorTooManyImports)
+	}
+
+	// Finished jobs free their slots, just after recording that they finished
+	close(uow.release)
+	for _, job := range jobs {
+		waitForImportJob(t, service, job)
+	}
+	_, err := service.StartImport(ctx, []byte(locationsCSV), options)
+	for deadline := time.Now().Add(time.Second); err != nil && err.Error() == constants.ErrorTooManyImports && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		_, err = service.StartImport(ctx, []byte(locationsCSV), options)
+	}
+	if err != nil {
+		t.Errorf("StartImport after the jobs finished: %v", err)
+	}
+	if err := service.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+}
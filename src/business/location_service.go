@@ -1,6 +1,7 @@
 package business
 
 import (
+	"context"
 	"errors"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
@@ -14,22 +15,35 @@ import (
 
 // locationService implements the LocationService interface
 type locationService struct {
+	uow          interfaces.UnitOfWork
 	locationRepo interfaces.LocationRepository
 	mapper       *mappers.LocationMapper
 	validator    *validator.Validate
+	timezones    *TimezoneService
+	metrics      interfaces.BusinessMetrics
+	events       interfaces.EventPublisher
+	policies     DeletePolicies
 }
 
 // NewLocationService creates a new location service
-func NewLocationService(locationRepo interfaces.LocationRepository) interfaces.LocationService {
+func NewLocationService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher, policies DeletePolicies) interfaces.LocationService {
 	return &locationService{
-		locationRepo: locationRepo,
+		uow:          uow,
+		locationRepo: uow.Locations(),
 		mapper:       mappers.NewLocationMapper(),
 		validator:    validator.New(),
+		timezones:    NewTimezoneService(),
+		metrics:      metrics,
+		events:       events,
+		policies:     policies,
 	}
 }
 
 // CreateLocation creates a new location
-func (s *locationService) CreateLocation(locationDTO *dto.LocationBase) (*dto.LocationDetails, error) {
+func (s *locationService) CreateLocation(ctx context.Context, locationDTO *dto.LocationBase) (*dto.LocationDetails, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.CreateLocation")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(locationDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
@@ -38,18 +52,41 @@ func (s *locationService) CreateLocation(locationDTO *dto.LocationBase) (*dto.Lo
 	// Convert DTO to model
 	location := s.mapper.ToModel(locationDTO)
 
-	// Create in database
-	if err := s.locationRepo.Create(location); err != nil {
+	// Resolve timezone from input or coordinates
+	timezone, err := s.timezones.ResolveTimezone(locationDTO.Timezone, location.Latitude, location.Longitude)
+	if err != nil {
+		return nil, errors.New(constants.ErrorInvalidTimezone + ": " + err.Error())
+	}
+	location.Timezone = timezone
+
+	// Create in database, recording the event in the same transaction
+	var details *dto.LocationDetails
+	err = s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if err := tx.Locations().Create(ctx, location); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(location)
+		if err := recordAudit(ctx, tx, constants.EventAggregateLocation, details.ID, nil, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, LocationCreated{Location: details})
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.EntitiesCreated(constants.ImportEntityLocations, 1)
 
 	// Return created location
-	return s.mapper.ToDetailsDTO(location), nil
+	return details, nil
 }
 
 // GetLocationByID retrieves a location by ID
-func (s *locationService) GetLocationByID(id uuid.UUID) (*dto.LocationDetails, error) {
-	location, err := s.locationRepo.GetByID(id)
+func (s *locationService) GetLocationByID(ctx context.Context, id uuid.UUID) (*dto.LocationDetails, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetLocationByID")
+	defer span.End()
+
+	location, err := s.locationRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorLocationNotFound)
@@ -61,16 +98,19 @@ func (s *locationService) GetLocationByID(id uuid.UUID) (*dto.LocationDetails, e
 }
 
 // GetAllLocations retrieves all locations with pagination
-func (s *locationService) GetAllLocations(limit, offset int) ([]*dto.LocationSummary, error) {
+func (s *locationService) GetAllLocations(ctx context.Context, limit, offset int) ([]*dto.LocationSummary, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetAllLocations")
+	defer span.End()
+
 	// Apply default and max limits
-	if limit <= 0 || limit > constants.MaxLimit {
+	if limit <= 0 || limit > constants.PaginationHardLimit {
 		limit = constants.DefaultLimit
 	}
 	if offset < 0 {
 		offset = constants.DefaultOffset
 	}
 
-	locations, err := s.locationRepo.GetAll(limit, offset)
+	locations, err := s.locationRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -79,48 +119,194 @@ func (s *locationService) GetAllLocations(limit, offset int) ([]*dto.LocationSum
 }
 
 // UpdateLocation updates an existing location
-func (s *locationService) UpdateLocation(id uuid.UUID, locationDTO *dto.LocationBase) (*dto.LocationDetails, error) {
+func (s *locationService) UpdateLocation(ctx context.Context, id uuid.UUID, locationDTO *dto.LocationBase) (*dto.LocationDetails, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.UpdateLocation")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(locationDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Get existing location
-	location, err := s.locationRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New(constants.ErrorLocationNotFound)
+	var details *dto.LocationDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Get existing location
+		location, err := tx.Locations().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorLocationNotFound)
+			}
+			return err
+		}
+
+		// Snapshot the current state so the update event can list what changed
+		before := s.mapper.ToDetailsDTO(location)
+
+		// Update model with new data
+		s.mapper.UpdateModel(location, locationDTO)
+
+		// Resolve timezone from input or coordinates
+		timezone, err := s.timezones.ResolveTimezone(locationDTO.Timezone, location.Latitude, location.Longitude)
+		if err != nil {
+			return errors.New(constants.ErrorInvalidTimezone + ": " + err.Error())
+		}
+		location.Timezone = timezone
+
+		// Save to database
+		if err := tx.Locations().Update(ctx, location); err != nil {
+			return err
 		}
+
+		details = s.mapper.ToDetailsDTO(location)
+		if err := recordAudit(ctx, tx, constants.EventAggregateLocation, id, before, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, LocationUpdated{Location: details, ChangedFields: changedFields(before, details)})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Update model with new data
-	s.mapper.UpdateModel(location, locationDTO)
+	return details, nil
+}
+
+// DeleteLocation soft deletes a location, dealing with its theatres by the location's delete policy
+func (s *locationService) DeleteLocation(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "LocationService.DeleteLocation")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Lock the location so no theatre can be added to it while it is being deleted
+		if err := tx.Locations().LockForUpdate(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorLocationNotFound)
+			}
+			return err
+		}
+		location, err := tx.Locations().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		theatres, err := tx.Theatres().GetByLocationID(ctx, id)
+		if err != nil {
+			return err
+		}
+		services := NewServices(tx, s.events, s.policies)
+		err = applyDeletePolicy(ctx, id, reassignTo, deleteRelationship{
+			policy:     s.policies.LocationTheatres,
+			parent:     constants.EventAggregateLocation,
+			dependents: theatreDependents(theatres),
+			lockTarget: tx.Locations().LockForShare,
+			remove:     func() error { return deleteTheatres(ctx, services, theatres) },
+			move: func(to uuid.UUID) error {
+				return moveTheatres(ctx, services, theatres, func(theatreDTO *dto.TheatreBase) { theatreDTO.LocationID = to })
+			},
+		})
+		if err != nil {
+			return err
+		}
 
-	// Save to database
-	if err := s.locationRepo.Update(location); err != nil {
+		if err := tx.Locations().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx, constants.EventAggregateLocation, id, s.mapper.ToDetailsDTO(location), nil); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, LocationDeleted{ID: id})
+	})
+}
+
+// GetDeletedLocations retrieves locations in the trash with pagination, most recently deleted first
+func (s *locationService) GetDeletedLocations(ctx context.Context, limit, offset int) ([]*dto.LocationSummary, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetDeletedLocations")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	locations, err := s.locationRepo.GetDeleted(ctx, limit, offset)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.mapper.ToDetailsDTO(location), nil
+	return s.mapper.ToSummaryDTOs(locations), nil
 }
 
-// DeleteLocation soft deletes a location
-func (s *locationService) DeleteLocation(id uuid.UUID) error {
-	// Check if location exists
-	_, err := s.locationRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New(constants.ErrorLocationNotFound)
+// RestoreLocation takes a location out of the trash
+func (s *locationService) RestoreLocation(ctx context.Context, id uuid.UUID) (*dto.LocationDetails, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.RestoreLocation")
+	defer span.End()
+
+	var details *dto.LocationDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if _, err := tx.Locations().GetDeletedByID(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		if err := tx.Locations().Restore(ctx, id); err != nil {
+			return err
 		}
-		return err
+
+		// Get restored location with relationships
+		restoredLocation, err := tx.Locations().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(restoredLocation)
+		if err := recordAuditAction(ctx, tx, constants.EventAggregateLocation, id, constants.AuditActionRestore, auditChanges(nil, details)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, LocationRestored{Location: details})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.locationRepo.Delete(id)
+	return details, nil
+}
+
+// PurgeLocation permanently deletes a location from the trash, unless anything still refers to it
+func (s *locationService) PurgeLocation(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "LocationService.PurgeLocation")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		location, err := tx.Locations().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		purged, err := tx.Locations().Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			return errors.New(constants.ErrorStillReferenced + ": theatres, deleted or not, still belong to it")
+		}
+
+		return recordAuditAction(ctx, tx, constants.EventAggregateLocation, id, constants.AuditActionPurge, auditChanges(s.mapper.ToDetailsDTO(location), nil))
+	})
 }
 
 // GetLocationsByCoordinates finds locations within a radius of given coordinates
-func (s *locationService) GetLocationsByCoordinates(latitude, longitude, radius float64) ([]*dto.LocationSummary, error) {
+func (s *locationService) GetLocationsByCoordinates(ctx context.Context, latitude, longitude, radius float64) ([]*dto.LocationSummary, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetLocationsByCoordinates")
+	defer span.End()
+
 	// Validate coordinates
 	if latitude < -90 || latitude > 90 {
 		return nil, errors.New("invalid latitude: must be between -90 and 90")
@@ -132,7 +318,7 @@ func (s *locationService) GetLocationsByCoordinates(latitude, longitude, radius
 		radius = constants.DefaultRadius
 	}
 
-	locations, err := s.locationRepo.GetByCoordinates(latitude, longitude, radius)
+	locations, err := s.locationRepo.GetByCoordinates(ctx, latitude, longitude, radius)
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +326,32 @@ func (s *locationService) GetLocationsByCoordinates(latitude, longitude, radius
 	return s.mapper.ToSummaryDTOs(locations), nil
 }
 
+// GetLocationByNameAndCity retrieves a location by name and city
+func (s *locationService) GetLocationByNameAndCity(ctx context.Context, name, city string) (*dto.LocationDetails, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetLocationByNameAndCity")
+	defer span.End()
+
+	if name == "" || city == "" {
+		return nil, errors.New(constants.ErrorInvalidInput + ": name and city cannot be empty")
+	}
+
+	location, err := s.locationRepo.GetByNameAndCity(ctx, name, city)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorLocationNotFound)
+		}
+		return nil, err
+	}
+
+	return s.mapper.ToDetailsDTO(location), nil
+}
+
 // GetActiveLocations retrieves all active locations
-func (s *locationService) GetActiveLocations() ([]*dto.LocationSummary, error) {
-	locations, err := s.locationRepo.GetActiveLocations()
+func (s *locationService) GetActiveLocations(ctx context.Context) ([]*dto.LocationSummary, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetActiveLocations")
+	defer span.End()
+
+	locations, err := s.locationRepo.GetActiveLocations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -151,15 +360,19 @@ func (s *locationService) GetActiveLocations() ([]*dto.LocationSummary, error) {
 }
 
 // SearchLocations searches locations by query string
-func (s *locationService) SearchLocations(query string) ([]*dto.LocationSummary, error) {
+func (s *locationService) SearchLocations(ctx context.Context, query string) ([]*dto.LocationSummary, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.SearchLocations")
+	defer span.End()
+
 	if query == "" {
 		return []*dto.LocationSummary{}, nil
 	}
 
-	locations, err := s.locationRepo.Search(query)
+	locations, err := s.locationRepo.Search(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.SearchExecuted(constants.ImportEntityLocations)
 
 	return s.mapper.ToSummaryDTOs(locations), nil
 }
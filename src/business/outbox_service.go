@@ -0,0 +1,122 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// outboxService implements the OutboxService interface
+type outboxService struct {
+	uow        interfaces.UnitOfWork
+	outboxRepo interfaces.OutboxRepository
+	mapper     *mappers.OutboxMapper
+}
+
+// NewOutboxService creates a new outbox service
+func NewOutboxService(uow interfaces.UnitOfWork) interfaces.OutboxService {
+	return &outboxService{
+		uow:        uow,
+		outboxRepo: uow.Outbox(),
+		mapper:     mappers.NewOutboxMapper(),
+	}
+}
+
+// ListMessages retrieves outbox messages in delivery order, optionally only those with one status
+func (s *outboxService) ListMessages(ctx context.Context, status string, limit, offset int) ([]*dto.OutboxMessage, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.ListMessages")
+	defer span.End()
+
+	switch status {
+	case "", constants.OutboxStatusPending, constants.OutboxStatusDelivered, constants.OutboxStatusFailed:
+	default:
+		return nil, errors.New(constants.ErrorOutboxInvalidStatus + ": status must be pending, delivered or failed")
+	}
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	messages, err := s.outboxRepo.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDTOs(messages), nil
+}
+
+// GetMessage retrieves an outbox message by ID
+func (s *outboxService) GetMessage(ctx context.Context, id uuid.UUID) (*dto.OutboxMessage, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.GetMessage")
+	defer span.End()
+
+	message, err := s.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorOutboxNotFound)
+		}
+		return nil, err
+	}
+
+	return s.mapper.ToDTO(message), nil
+}
+
+// ReplayMessage makes a failed or retrying message due now with a fresh set of attempts
+func (s *outboxService) ReplayMessage(ctx context.Context, id uuid.UUID) (*dto.OutboxMessage, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.ReplayMessage")
+	defer span.End()
+
+	var replayed *models.OutboxMessage
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		message, err := tx.Outbox().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorOutboxNotFound)
+			}
+			return err
+		}
+		if message.Status == constants.OutboxStatusDelivered {
+			return errors.New(constants.ErrorOutboxDelivered)
+		}
+
+		// The relay may deliver it between the read and the replay
+		if err := tx.Outbox().Replay(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorOutboxDelivered)
+			}
+			return err
+		}
+
+		replayed, err = tx.Outbox().GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDTO(replayed), nil
+}
+
+// ReplayFailed makes every failed message due now
+func (s *outboxService) ReplayFailed(ctx context.Context) (*dto.OutboxReplayResult, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.ReplayFailed")
+	defer span.End()
+
+	replayed, err := s.outboxRepo.ReplayFailed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OutboxReplayResult{Replayed: replayed}, nil
+}
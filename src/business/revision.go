@@ -0,0 +1,32 @@
+package business
+
+import (
+	"reflect"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+)
+
+// revisionAction names how a write enters an entity's history; restoredFrom is set when it copied an earlier revision
+func revisionAction(created bool, restoredFrom *int) string {
+	switch {
+	case created:
+		return constants.RevisionActionCreate
+	case restoredFrom != nil:
+		return constants.RevisionActionRestore
+	}
+	return constants.RevisionActionUpdate
+}
+
+// sameFields reports whether two versions of an entity encode to the same JSON fields, however they are formatted
+func sameFields(a, b interface{}) bool {
+	return reflect.DeepEqual(jsonFields(a), jsonFields(b))
+}
+
+// revisionDiff lists the fields that differ between two revision snapshots
+func revisionDiff(from, to int, fromSnapshot, toSnapshot interface{}) *dto.RevisionDiff {
+	changes := map[string]dto.FieldChange{}
+	for name, change := range auditChanges(fromSnapshot, toSnapshot) {
+		changes[name] = dto.FieldChange{Old: change.Old, New: change.New}
+	}
+	return &dto.RevisionDiff{From: from, To: to, Changes: changes}
+}
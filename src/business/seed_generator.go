@@ -0,0 +1,291 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"time"
+)
+
+// seedCity anchors generated locations; weight reflects how much theatre a city has
+type seedCity struct {
+	city, state, country string
+	latitude, longitude  float64
+	weight               int
+}
+
+var seedCities = []seedCity{
+	{"New York", "New York", "United States", 40.7589, -73.9851, 30},
+	{"London", "England", "United Kingdom", 51.5115, -0.1300, 25},
+	{"Chicago", "Illinois", "United States", 41.8781, -87.6298, 10},
+	{"Los Angeles", "California", "United States", 34.0928, -118.3287, 8},
+	{"Toronto", "Ontario", "Canada", 43.6426, -79.3871, 7},
+	{"Las Vegas", "Nevada", "United States", 36.1162, -115.1739, 6},
+	{"Boston", "Massachusetts", "United States", 42.3505, -71.0621, 5},
+	{"San Francisco", "California", "United States", 37.7877, -122.4074, 5},
+	{"Washington", "District of Columbia", "United States", 38.8955, -77.0563, 4},
+	{"Philadelphia", "Pennsylvania", "United States", 39.9526, -75.1652, 4},
+	{"Seattle", "Washington", "United States", 47.6205, -122.3212, 3},
+	{"Atlanta", "Georgia", "United States", 33.7839, -84.3830, 3},
+	{"Denver", "Colorado", "United States", 39.7392, -104.9903, 2},
+	{"Miami", "Florida", "United States", 25.7907, -80.1300, 2},
+	{"Sydney", "New South Wales", "Australia", -33.8568, 151.2153, 4},
+	{"Melbourne", "Victoria", "Australia", -37.8136, 144.9631, 3},
+	{"Dublin", "Leinster", "Ireland", 53.3438, -6.2546, 2},
+	{"Edinburgh", "Scotland", "United Kingdom", 55.9533, -3.1883, 2},
+}
+
+var seedDistricts = []string{
+	"Theatre District", "Downtown", "Midtown", "Old Town", "Arts Quarter", "Riverside", "Uptown", "Harbourfront",
+}
+
+var seedTheatreNames = []string{
+	"Majestic Theatre", "Palace Theatre", "Lyceum Theatre", "Imperial Theatre", "Ambassador Theatre",
+	"Gershwin Theatre", "Minskoff Theatre", "Winter Garden Theatre", "Booth Theatre", "Music Box Theatre",
+	"Belasco Theatre", "Apollo Theatre", "Dominion Theatre", "Phoenix Theatre", "Savoy Theatre",
+	"Adelphi Theatre", "Oriental Theatre", "Goodman Theatre", "Park Theater", "Elgin Theatre",
+	"Pantages Theatre", "Ahmanson Theatre", "Wang Theatre", "Walnut Street Theatre", "Curran Theatre",
+	"Orpheum Theatre", "Fox Theatre", "Paramount Theatre", "Moore Theatre", "Alliance Theatre",
+	"Regent Theatre", "Princess Theatre", "Gaiety Theatre", "Playhouse", "Empire Theatre",
+	"Garrick Theatre", "Criterion Theatre", "Globe Theatre", "Coliseum", "Royal Court Theatre",
+}
+
+var seedShowTitles = []string{
+	"The Lion King", "Hamilton", "The Phantom of the Opera", "Chicago", "Les Misérables", "Wicked",
+	"The Book of Mormon", "Dear Evan Hansen", "Come From Away", "Hadestown", "Aladdin", "West Side Story",
+	"My Fair Lady", "Oklahoma!", "The Sound of Music", "Romeo and Juliet", "Hamlet", "Macbeth", "King Lear",
+	"A Midsummer Night's Dream", "The Tempest", "Death of a Salesman", "A Streetcar Named Desire",
+	"The Glass Menagerie", "Our Town", "The Crucible", "Rent", "Next to Normal", "In the Heights",
+	"Jersey Boys", "Mamma Mia!", "Matilda", "Mary Poppins", "A Christmas Carol", "The Nutcracker",
+	"Swan Lake", "Giselle", "La Bohème", "Carmen", "The Magic Flute", "Tosca", "Madama Butterfly",
+	"La Traviata", "Aida", "Stomp", "Riverdance", "Improv Comedy Night", "Stand-Up Showcase",
+}
+
+var seedDirectors = []string{
+	"Julie Taymor", "Thomas Kail", "Hal Prince", "Trevor Nunn", "Marianne Elliott", "Sam Mendes",
+	"Rachel Chavkin", "Bartlett Sher", "Ivo van Hove", "Diane Paulus", "Michael Grandage", "Rupert Goold",
+	"Matthew Warchus", "Susan Stroman", "Des McAnuff", "Mary Zimmerman", "Jerry Zaks", "Kenny Leon",
+}
+
+var seedStreets = []string{
+	"Broadway", "Main Street", "King Street", "High Street", "Market Street", "Theatre Row", "Park Avenue",
+	"Shaftesbury Avenue", "State Street", "Queen Street",
+}
+
+// generateSeedRecords appends synthetic locations, theatres and shows to a fixture. Records are
+// drawn from a seeded random source, so the same counts and seed always produce the same records
+func generateSeedRecords(fixture *dto.SeedFixture, count dto.SeedGenerateCount, today time.Time) error {
+	if count.Locations < 0 || count.Theatres < 0 || count.Shows < 0 {
+		return errors.New(constants.ErrorSeedInvalid + ": generate counts cannot be negative")
+	}
+	if count.Theatres > 0 && len(fixture.TheatreTypes) == 0 {
+		return errors.New(constants.ErrorSeedInvalid + ": generating theatres requires theatre_types")
+	}
+	if count.Shows > 0 && len(fixture.ShowTypes) == 0 {
+		return errors.New(constants.ErrorSeedInvalid + ": generating shows requires show_types")
+	}
+
+	rng := rand.New(rand.NewSource(count.RandomSeed))
+
+	locations := generateSeedLocations(rng, count.NamePrefix, count.Locations)
+	fixture.Locations = append(fixture.Locations, locations...)
+	if len(locations) == 0 {
+		locations = fixture.Locations
+	}
+	if count.Theatres > 0 && len(locations) == 0 {
+		return errors.New(constants.ErrorSeedInvalid + ": generating theatres requires locations")
+	}
+
+	theatres := generateSeedTheatres(rng, fixture, locations, count.NamePrefix, count.Theatres)
+	fixture.Theatres = append(fixture.Theatres, theatres...)
+	if len(theatres) == 0 {
+		theatres = fixture.Theatres
+	}
+	if count.Shows > 0 && len(theatres) == 0 {
+		return errors.New(constants.ErrorSeedInvalid + ": generating shows requires theatres")
+	}
+
+	fixture.Shows = append(fixture.Shows, generateSeedShows(rng, fixture, theatres, count.Shows, today)...)
+	return nil
+}
+
+// generateSeedLocations spreads districts over cities in proportion to their weight
+func generateSeedLocations(rng *rand.Rand, prefix string, n int) []dto.LocationBase {
+	totalWeight := 0
+	for _, city := range seedCities {
+		totalWeight += city.weight
+	}
+
+	locations := make([]dto.LocationBase, 0, n)
+	perCity := make(map[string]int)
+	for i := 0; i < n; i++ {
+		pick := rng.Intn(totalWeight)
+		city := seedCities[0]
+		for _, candidate := range seedCities {
+			if pick < candidate.weight {
+				city = candidate
+				break
+			}
+			pick -= candidate.weight
+		}
+
+		k := perCity[city.city]
+		perCity[city.city]++
+		name := prefix + city.city + " " + seedDistricts[k%len(seedDistricts)]
+		if k >= len(seedDistricts) {
+			name = fmt.Sprintf("%s %d", name, k/len(seedDistricts)+1)
+		}
+
+		// Districts sit within a few kilometres of the city centre; the timezone is derived from them
+		latitude := roundTo(city.latitude+rng.NormFloat64()*0.02, 4)
+		longitude := roundTo(city.longitude+rng.NormFloat64()*0.02, 4)
+
+		locations = append(locations, dto.LocationBase{
+			Name:        name,
+			City:        city.city,
+			State:       city.state,
+			Country:     city.country,
+			Latitude:    &latitude,
+			Longitude:   &longitude,
+			Address:     fmt.Sprintf("%s, %s", strings.TrimPrefix(name, prefix), city.city),
+			Description: "Generated location for performance testing",
+		})
+	}
+
+	return locations
+}
+
+// generateSeedTheatres places theatres on a Zipf curve, so a few districts hold most venues
+func generateSeedTheatres(rng *rand.Rand, fixture *dto.SeedFixture, locations []dto.LocationBase, prefix string, n int) []dto.SeedTheatre {
+	if n == 0 {
+		return nil
+	}
+
+	// Skip names the fixture already uses so generated venues never overwrite hand-written ones
+	taken := make(map[string]bool)
+	for _, theatre := range fixture.Theatres {
+		taken[strings.ToLower(theatre.Name)] = true
+	}
+
+	locationPick := rand.NewZipf(rng, 1.1, 2, uint64(len(locations)-1))
+	typePick := rand.NewZipf(rng, 1.3, 1, uint64(len(fixture.TheatreTypes)-1))
+
+	theatres := make([]dto.SeedTheatre, 0, n)
+	for i, k := 0, 0; len(theatres) < n; k++ {
+		name := prefix + seedTheatreNames[k%len(seedTheatreNames)]
+		if k >= len(seedTheatreNames) {
+			name = fmt.Sprintf("%s %d", name, k/len(seedTheatreNames)+1)
+		}
+		if taken[strings.ToLower(name)] {
+			continue
+		}
+		i++
+
+		location := locations[locationPick.Uint64()]
+
+		// Most venues seat a few hundred; a long tail of large houses seat thousands
+		capacity := clampInt(int(math.Exp(6.2+rng.NormFloat64()*0.6)), 50, 6000)
+		featured := rng.Float64() < 0.15
+
+		theatres = append(theatres, dto.SeedTheatre{
+			TheatreBase: dto.TheatreBase{
+				Name:        name,
+				Description: "Professional theatre venue offering world-class productions",
+				Capacity:    &capacity,
+				Address:     fmt.Sprintf("%d %s", 1+rng.Intn(999), seedStreets[rng.Intn(len(seedStreets))]),
+				Phone:       fmt.Sprintf("(%03d) %03d-%04d", 200+rng.Intn(800), 200+rng.Intn(800), rng.Intn(10000)),
+				Email:       fmt.Sprintf("info@theatre%d.example.com", i),
+				Website:     fmt.Sprintf("https://theatre%d.example.com", i),
+				ImageURL:    fmt.Sprintf("https://example.com/theatre%d.jpg", i),
+				IsFeatured:  &featured,
+			},
+			TheatreType:  fixture.TheatreTypes[typePick.Uint64()].Name,
+			LocationName: location.Name,
+			LocationCity: location.City,
+		})
+	}
+
+	return theatres
+}
+
+// generateSeedShows spreads shows evenly over theatres, with runs starting within six months either side of today
+func generateSeedShows(rng *rand.Rand, fixture *dto.SeedFixture, theatres []dto.SeedTheatre, n int, today time.Time) []dto.SeedShow {
+	if n == 0 {
+		return nil
+	}
+
+	typePick := rand.NewZipf(rng, 1.2, 1, uint64(len(fixture.ShowTypes)-1))
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Titles are the natural key within a theatre, so repeated titles become numbered runs
+	seen := make(map[string]int)
+	for _, show := range fixture.Shows {
+		seen[strings.ToLower(show.TheatreName+"|"+show.TheatreCity+"|"+show.Title)]++
+	}
+
+	shows := make([]dto.SeedShow, 0, n)
+	for i := 1; i <= n; i++ {
+		theatre := theatres[rng.Intn(len(theatres))]
+
+		title := seedShowTitles[rng.Intn(len(seedShowTitles))]
+		key := strings.ToLower(theatre.Name + "|" + theatre.LocationCity + "|" + title)
+		if runs := seen[key]; runs > 0 {
+			title = fmt.Sprintf("%s (Run %d)", title, runs+1)
+		}
+		seen[key]++
+
+		// Runs last about three months on average; a few run for a year
+		startDate := day.AddDate(0, 0, rng.Intn(361)-180)
+		endDate := startDate.AddDate(0, 0, clampInt(int(rng.ExpFloat64()*90), 1, 365))
+		duration := clampInt(int(140+rng.NormFloat64()*30)/5*5, 60, 240)
+		price := roundTo(math.Max(10, math.Min(500, math.Exp(math.Log(75)+rng.NormFloat64()*0.5))), 2)
+		featured := rng.Float64() < 0.10
+
+		trailerURL := ""
+		if rng.Float64() < 0.7 {
+			trailerURL = fmt.Sprintf("https://youtube.com/watch?v=show%d", i)
+		}
+
+		shows = append(shows, dto.SeedShow{
+			ShowBase: dto.ShowBase{
+				Title:       title,
+				Description: "A captivating theatrical experience with outstanding performances and stunning production values.",
+				Director:    seedDirectors[rng.Intn(len(seedDirectors))],
+				Cast:        "Talented ensemble cast featuring award-winning performers",
+				Duration:    &duration,
+				StartDate:   &startDate,
+				EndDate:     &endDate,
+				Price:       &price,
+				ImageURL:    fmt.Sprintf("https://example.com/show%d.jpg", i),
+				TrailerURL:  trailerURL,
+				IsFeatured:  &featured,
+			},
+			ShowType:    fixture.ShowTypes[typePick.Uint64()].Name,
+			TheatreName: theatre.Name,
+			TheatreCity: theatre.LocationCity,
+		})
+	}
+
+	return shows
+}
+
+// clampInt limits value to the range [min, max]
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// roundTo rounds value to the given number of decimal places
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
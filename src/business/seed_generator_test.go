@@ -0,0 +1,125 @@
+package business
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"theatre-management-system/src/dto"
+	"time"
+)
+
+// generatorFixture is a fixture with the types generated records draw on and one hand-written theatre
+func generatorFixture() *dto.SeedFixture {
+	return &dto.SeedFixture{
+		TheatreTypes: []dto.TheatreTypeBase{{Name: "Broadway"}, {Name: "Off-Broadway"}},
+		ShowTypes:    []dto.ShowTypeBase{{Name: "Musical"}, {Name: "Play"}},
+		Theatres: []dto.SeedTheatre{{
+			TheatreBase:  dto.TheatreBase{Name: "Majestic Theatre"},
+			LocationName: "Manhattan Theater District",
+			LocationCity: "New York",
+		}},
+	}
+}
+
+func TestGenerateSeedRecordsIsDeterministic(t *testing.T) {
+	today := time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+	count := dto.SeedGenerateCount{Locations: 30, Theatres: 120, Shows: 400, RandomSeed: 7}
+
+	first, second := generatorFixture(), generatorFixture()
+	if err := generateSeedRecords(first, count, today); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateSeedRecords(second, count, today); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("the same counts and seed generated different records")
+	}
+	if len(first.Locations) != 30 || len(first.Theatres) != 121 || len(first.Shows) != 400 {
+		t.Errorf("generated %d locations, %d theatres and %d shows, want 30, 120 + 1 and 400", len(first.Locations), len(first.Theatres), len(first.Shows))
+	}
+
+	other := generatorFixture()
+	count.RandomSeed = 8
+	if err := generateSeedRecords(other, count, today); err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(first.Shows, other.Shows) {
+		t.Error("different seeds generated the same shows")
+	}
+}
+
+func TestGenerateSeedRecordsKeepsNaturalKeysUnique(t *testing.T) {
+	fixture := generatorFixture()
+	count := dto.SeedGenerateCount{Locations: 50, Theatres: 300, Shows: 2000, RandomSeed: 1}
+	if err := generateSeedRecords(fixture, count, time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := map[string]bool{}
+	unique := func(kind string, parts ...string) {
+		key := kind + "|" + strings.ToLower(strings.Join(parts, "|"))
+		if keys[key] {
+			t.Errorf("generated %s %v twice", kind, parts)
+		}
+		keys[key] = true
+	}
+	for _, location := range fixture.Locations {
+		unique("location", location.Name, location.City)
+	}
+	for _, theatre := range fixture.Theatres {
+		// Theatres are matched by name and city; names alone are unique, which is stricter
+		unique("theatre", theatre.Name)
+	}
+	for _, show := range fixture.Shows {
+		unique("show", show.Title, show.TheatreName, show.TheatreCity)
+	}
+}
+
+func TestGenerateSeedRecordsPrefixesNames(t *testing.T) {
+	fixture := generatorFixture()
+	count := dto.SeedGenerateCount{Locations: 5, Theatres: 10, Shows: 20, RandomSeed: 42, NamePrefix: "Load-test "}
+	if err := generateSeedRecords(fixture, count, time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, location := range fixture.Locations {
+		if !strings.HasPrefix(location.Name, count.NamePrefix) {
+			t.Errorf("location %q lacks the prefix", location.Name)
+		}
+	}
+	for _, theatre := range fixture.Theatres[1:] {
+		if !strings.HasPrefix(theatre.Name, count.NamePrefix) || !strings.HasPrefix(theatre.LocationName, count.NamePrefix) {
+			t.Errorf("theatre %q at %q lacks the prefix", theatre.Name, theatre.LocationName)
+		}
+	}
+	for _, show := range fixture.Shows {
+		if !strings.HasPrefix(show.TheatreName, count.NamePrefix) {
+			t.Errorf("show %q is at %q, not a generated theatre", show.Title, show.TheatreName)
+		}
+	}
+	if fixture.Theatres[0].Name != "Majestic Theatre" {
+		t.Errorf("the hand-written theatre was renamed to %q", fixture.Theatres[0].Name)
+	}
+}
+
+func TestGenerateSeedRecordsRejectsMissingReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture dto.SeedFixture
+		count   dto.SeedGenerateCount
+	}{
+		{name: "negative count", count: dto.SeedGenerateCount{Shows: -1}},
+		{name: "theatres without types", count: dto.SeedGenerateCount{Locations: 1, Theatres: 1}},
+		{name: "shows without types", fixture: dto.SeedFixture{Theatres: generatorFixture().Theatres}, count: dto.SeedGenerateCount{Shows: 1}},
+		{name: "theatres without locations", fixture: dto.SeedFixture{TheatreTypes: []dto.TheatreTypeBase{{Name: "Broadway"}}}, count: dto.SeedGenerateCount{Theatres: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := generateSeedRecords(&tt.fixture, tt.count, time.Now().UTC()); err == nil {
+				t.Error("generateSeedRecords succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// seedService implements the SeedService interface
+type seedService struct {
+	uow          interfaces.UnitOfWork
+	theatreTypes *mappers.TheatreTypeMapper
+	showTypes    *mappers.ShowTypeMapper
+	locations    *mappers.LocationMapper
+	theatres     *mappers.TheatreMapper
+	shows        *mappers.ShowMapper
+	timezones    *TimezoneService
+}
+
+// seedStep upserts one fixture record inside a batch's transaction
+type seedStep func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, resolver *importResolver) error
+
+// NewSeedService creates a new seed service. Seeded records are audited but raise no events, so loading
+// a large set doesn't flood the outbox sinks and webhook subscribers
+func NewSeedService(uow interfaces.UnitOfWork) interfaces.SeedService {
+	return &seedService{
+		uow:          seedUnitOfWork{uow},
+		theatreTypes: mappers.NewTheatreTypeMapper(),
+		showTypes:    mappers.NewShowTypeMapper(),
+		locations:    mappers.NewLocationMapper(),
+		theatres:     mappers.NewTheatreMapper(),
+		shows:        mappers.NewShowMapper(),
+		timezones:    NewTimezoneService(),
+	}
+}
+
+// Seed upserts a fixture's records by natural key, so running it twice changes nothing. Records are committed
+// constants.SeedBatchSize at a time; a failure keeps the batches before it, and running the set again
+// finishes the job
+func (s *seedService) Seed(ctx context.Context, fixture *dto.SeedFixture) (*dto.SeedReport, error) {
+	ctx, span := tracer.Start(ctx, "SeedService.Seed")
+	defer span.End()
+
+	if fixture.Generate != nil {
+		if err := generateSeedRecords(fixture, *fixture.Generate, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &dto.SeedReport{}
+	steps := s.seedSteps(fixture, report)
+
+	for start := 0; start < len(steps); start += constants.SeedBatchSize {
+		batch := steps[start:min(start+constants.SeedBatchSize, len(steps))]
+		err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+			services := NewServices(tx, discardEvents{}, noDeletes)
+			resolver := newImportResolver(services)
+
+			for _, step := range batch {
+				if err := step(ctx, tx, services, resolver); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if start > 0 {
+				return nil, fmt.Errorf("%w (the first %d of %d records were committed)", err, start, len(steps))
+			}
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// seedSteps lists a fixture's upserts in dependency order: types and locations before the theatres that
+// reference them, and theatres before their shows
+func (s *seedService) seedSteps(fixture *dto.SeedFixture, report *dto.SeedReport) []seedStep {
+	steps := make([]seedStep, 0, len(fixture.TheatreTypes)+len(fixture.ShowTypes)+len(fixture.Locations)+len(fixture.Theatres)+len(fixture.Shows))
+
+	for i := range fixture.TheatreTypes {
+		theatreType := &fixture.TheatreTypes[i]
+		steps = append(steps, func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, _ *importResolver) error {
+			if err := s.upsertTheatreType(ctx, tx, services, theatreType, &report.TheatreTypes); err != nil {
+				return fmt.Errorf("theatre type %q: %w", theatreType.Name, err)
+			}
+			return nil
+		})
+	}
+
+	for i := range fixture.ShowTypes {
+		showType := &fixture.ShowTypes[i]
+		steps = append(steps, func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, _ *importResolver) error {
+			if err := s.upsertShowType(ctx, tx, services, showType, &report.ShowTypes); err != nil {
+				return fmt.Errorf("show type %q: %w", showType.Name, err)
+			}
+			return nil
+		})
+	}
+
+	for i := range fixture.Locations {
+		location := &fixture.Locations[i]
+		steps = append(steps, func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, _ *importResolver) error {
+			if err := s.upsertLocation(ctx, tx, services, location, &report.Locations); err != nil {
+				return fmt.Errorf("location %q: %w", location.Name, err)
+			}
+			return nil
+		})
+	}
+
+	for i := range fixture.Theatres {
+		theatre := &fixture.Theatres[i]
+		steps = append(steps, func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, resolver *importResolver) error {
+			if err := s.upsertTheatre(ctx, tx, services, resolver, theatre, &report.Theatres); err != nil {
+				return fmt.Errorf("theatre %q: %w", theatre.Name, err)
+			}
+			return nil
+		})
+	}
+
+	for i := range fixture.Shows {
+		show := &fixture.Shows[i]
+		steps = append(steps, func(ctx context.Context, tx interfaces.UnitOfWork, services *Services, resolver *importResolver) error {
+			if err := s.upsertShow(ctx, tx, services, resolver, show, &report.Shows); err != nil {
+				return fmt.Errorf("show %q: %w", show.Title, err)
+			}
+			return nil
+		})
+	}
+
+	return steps
+}
+
+// upsertTheatreType creates or updates a theatre type by name
+func (s *seedService) upsertTheatreType(ctx context.Context, tx interfaces.UnitOfWork, services *Services, theatreType *dto.TheatreTypeBase, counts *dto.SeedEntityCounts) error {
+	existing, err := tx.TheatreTypes().GetByName(ctx, theatreType.Name)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := services.TheatreTypes.CreateTheatreType(ctx, theatreType); err != nil {
+			return err
+		}
+		counts.Created++
+		return nil
+	}
+
+	updated := *existing
+	s.theatreTypes.UpdateModel(&updated, theatreType)
+	if seedUnchanged(s.theatreTypes.ToBaseDTO(existing), s.theatreTypes.ToBaseDTO(&updated)) {
+		counts.Unchanged++
+		return nil
+	}
+
+	if _, err := services.TheatreTypes.UpdateTheatreType(ctx, existing.ID, theatreType); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+// upsertShowType creates or updates a show type by name
+func (s *seedService) upsertShowType(ctx context.Context, tx interfaces.UnitOfWork, services *Services, showType *dto.ShowTypeBase, counts *dto.SeedEntityCounts) error {
+	existing, err := tx.ShowTypes().GetByName(ctx, showType.Name)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := services.ShowTypes.CreateShowType(ctx, showType); err != nil {
+			return err
+		}
+		counts.Created++
+		return nil
+	}
+
+	updated := *existing
+	s.showTypes.UpdateModel(&updated, showType)
+	if seedUnchanged(s.showTypes.ToBaseDTO(existing), s.showTypes.ToBaseDTO(&updated)) {
+		counts.Unchanged++
+		return nil
+	}
+
+	if _, err := services.ShowTypes.UpdateShowType(ctx, existing.ID, showType); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+// upsertLocation creates or updates a location by name and city
+func (s *seedService) upsertLocation(ctx context.Context, tx interfaces.UnitOfWork, services *Services, location *dto.LocationBase, counts *dto.SeedEntityCounts) error {
+	existing, err := tx.Locations().GetByNameAndCity(ctx, location.Name, location.City)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := services.Locations.CreateLocation(ctx, location); err != nil {
+			return err
+		}
+		counts.Created++
+		return nil
+	}
+
+	// The timezone the update would store, as UpdateLocation resolves it
+	updated := *existing
+	s.locations.UpdateModel(&updated, location)
+	if updated.Timezone, err = s.timezones.ResolveTimezone(location.Timezone, updated.Latitude, updated.Longitude); err != nil {
+		return errors.New(constants.ErrorInvalidTimezone + ": " + err.Error())
+	}
+	if seedUnchanged(s.locations.ToBaseDTO(existing), s.locations.ToBaseDTO(&updated)) {
+		counts.Unchanged++
+		return nil
+	}
+
+	if _, err := services.Locations.UpdateLocation(ctx, existing.ID, location); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+// upsertTheatre resolves a theatre's type and location, then creates or updates it by name and city
+func (s *seedService) upsertTheatre(ctx context.Context, tx interfaces.UnitOfWork, services *Services, resolver *importResolver, theatre *dto.SeedTheatre, counts *dto.SeedEntityCounts) error {
+	var err error
+	if theatre.TheatreType != "" {
+		if theatre.TheatreTypeID, err = resolver.theatreType(ctx, theatre.TheatreType); err != nil {
+			return fmt.Errorf("theatre_type: %w", err)
+		}
+	}
+	if theatre.LocationName != "" || theatre.LocationCity != "" {
+		if theatre.LocationID, err = resolver.location(ctx, theatre.LocationName, theatre.LocationCity); err != nil {
+			return fmt.Errorf("location_name/location_city: %w", err)
+		}
+	}
+	if theatre.LocationID == uuid.Nil {
+		return errors.New(constants.ErrorSeedInvalid + ": location_name and location_city are required")
+	}
+
+	location, err := services.Locations.GetLocationByID(ctx, theatre.LocationID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := tx.Theatres().GetByNameAndCity(ctx, theatre.Name, location.City)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := services.Theatres.CreateTheatre(ctx, &theatre.TheatreBase); err != nil {
+			return err
+		}
+		counts.Created++
+		return nil
+	}
+
+	updated := *existing
+	s.theatres.UpdateModel(&updated, &theatre.TheatreBase)
+	if seedUnchanged(s.theatres.ToBaseDTO(existing), s.theatres.ToBaseDTO(&updated)) {
+		counts.Unchanged++
+		return nil
+	}
+
+	if _, err := services.Theatres.UpdateTheatre(ctx, existing.ID, &theatre.TheatreBase); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+// upsertShow resolves a show's type and theatre, then creates or updates it by title and theatre
+func (s *seedService) upsertShow(ctx context.Context, tx interfaces.UnitOfWork, services *Services, resolver *importResolver, show *dto.SeedShow, counts *dto.SeedEntityCounts) error {
+	var err error
+	if show.ShowType != "" {
+		if show.ShowTypeID, err = resolver.showType(ctx, show.ShowType); err != nil {
+			return fmt.Errorf("show_type: %w", err)
+		}
+	}
+	if show.TheatreName != "" || show.TheatreCity != "" {
+		if show.TheatreID, err = resolver.theatre(ctx, show.TheatreName, show.TheatreCity); err != nil {
+			return fmt.Errorf("theatre_name/theatre_city: %w", err)
+		}
+	}
+	if show.TheatreID == uuid.Nil {
+		return errors.New(constants.ErrorSeedInvalid + ": theatre_name and theatre_city are required")
+	}
+
+	existing, err := tx.Shows().GetByTitleAndTheatreID(ctx, show.Title, show.TheatreID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if _, err := services.Shows.CreateShow(ctx, &show.ShowBase); err != nil {
+			return err
+		}
+		counts.Created++
+		return nil
+	}
+
+	updated := *existing
+	s.shows.UpdateModel(&updated, &show.ShowBase)
+	current, desired := s.shows.ToBaseDTO(existing), s.shows.ToBaseDTO(&updated)
+	for _, showDTO := range []*dto.ShowBase{current, desired} {
+		// The fixture may write a date in another zone than the database reads it back in
+		showDTO.StartDate, showDTO.EndDate = inUTC(showDTO.StartDate), inUTC(showDTO.EndDate)
+	}
+	if seedUnchanged(current, desired) {
+		counts.Unchanged++
+		return nil
+	}
+
+	if _, err := services.Shows.UpdateShow(ctx, existing.ID, &show.ShowBase); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+// seedUnchanged reports whether a record's DTO after applying a fixture matches the one it has now, so the
+// update can be skipped along with its audit entry, revision, events and calendar sequence bump
+func seedUnchanged(current, updated interface{}) bool {
+	return len(auditChanges(current, updated)) == 0
+}
+
+// inUTC returns a copy of t in UTC, or nil for nil
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// seedUnitOfWork leaves seeded changes out of the outbox, so the relay never delivers them to the event
+// sinks or fans them out to webhook subscriptions
+type seedUnitOfWork struct {
+	interfaces.UnitOfWork
+}
+
+// Do keeps the transaction's repositories seed-scoped too
+func (u seedUnitOfWork) Do(ctx context.Context, fn func(tx interfaces.UnitOfWork) error) error {
+	return u.UnitOfWork.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		return fn(seedUnitOfWork{tx})
+	})
+}
+
+// Outbox drops the messages seeded changes would record
+func (u seedUnitOfWork) Outbox() interfaces.OutboxRepository {
+	return discardOutbox{u.UnitOfWork.Outbox()}
+}
+
+// discardOutbox is an outbox repository that records nothing
+type discardOutbox struct {
+	interfaces.OutboxRepository
+}
+
+// Append drops the messages
+func (discardOutbox) Append(context.Context, ...*models.OutboxMessage) error {
+	return nil
+}
+
+// discardEvents is an event publisher that delivers nothing, so in-process subscribers skip seeded changes too
+type discardEvents struct{}
+
+// Publish drops the events
+func (discardEvents) Publish(context.Context, ...interfaces.Event) {}
@@ -0,0 +1,80 @@
+package business_test
+
+import (
+	"context"
+	"testing"
+	"theatre-management-system/src/business"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/seed"
+	"theatre-management-system/src/testdb"
+)
+
+// loadSeedFixture loads a built-in set with the given generate block, fresh for each run since seeding
+// appends the generated records to the fixture
+func loadSeedFixture(t *testing.T, name string, generate *dto.SeedGenerateCount) *dto.SeedFixture {
+	t.Helper()
+	fixture, err := seed.LoadFixture(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture.Generate = generate
+	return fixture
+}
+
+func TestSeedTwiceLeavesEverythingUnchanged(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	service := business.NewSeedService(repo.NewUnitOfWork(db))
+
+	// Enough shows to take more than one batch
+	generate := &dto.SeedGenerateCount{Locations: 3, Theatres: 10, Shows: constants.SeedBatchSize, RandomSeed: 3}
+	first, err := service.Seed(ctx, loadSeedFixture(t, constants.SeedSetMinimal, generate))
+	if err != nil {
+		t.Fatalf("first Seed: %v", err)
+	}
+	if first.Locations.Created != 4 || first.Theatres.Created != 11 || first.Shows.Created != 1+constants.SeedBatchSize {
+		t.Errorf("first run created %+v", first)
+	}
+
+	second, err := service.Seed(ctx, loadSeedFixture(t, constants.SeedSetMinimal, generate))
+	if err != nil {
+		t.Fatalf("second Seed: %v", err)
+	}
+	want := dto.SeedReport{
+		TheatreTypes: dto.SeedEntityCounts{Unchanged: 1},
+		ShowTypes:    dto.SeedEntityCounts{Unchanged: 1},
+		Locations:    dto.SeedEntityCounts{Unchanged: 4},
+		Theatres:     dto.SeedEntityCounts{Unchanged: 11},
+		Shows:        dto.SeedEntityCounts{Unchanged: 1 + constants.SeedBatchSize},
+	}
+	if *second != want {
+		t.Errorf("second run reported %+v, want %+v", *second, want)
+	}
+
+	// Seeding is audited but records nothing for the outbox relay or webhooks
+	assertRowCount(t, db, &models.OutboxMessage{}, 0)
+	assertRowCount(t, db, &models.AuditEntry{}, int64(1+1+4+11+1+constants.SeedBatchSize))
+}
+
+func TestSeedPrefixedSetLeavesOtherSetsAlone(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	service := business.NewSeedService(repo.NewUnitOfWork(db))
+
+	if _, err := service.Seed(ctx, loadSeedFixture(t, constants.SeedSetMinimal, &dto.SeedGenerateCount{Locations: 2, Theatres: 8, RandomSeed: 1})); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	// Another set drawing generated names from the same pool, in the same cities
+	report, err := service.Seed(ctx, loadSeedFixture(t, constants.SeedSetMinimal, &dto.SeedGenerateCount{Locations: 2, Theatres: 8, RandomSeed: 2, NamePrefix: "Load-test "}))
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if report.Locations.Created != 2 || report.Locations.Updated != 0 || report.Theatres.Created != 8 || report.Theatres.Updated != 0 {
+		t.Errorf("prefixed set reported locations %+v and theatres %+v, want only creates", report.Locations, report.Theatres)
+	}
+	assertRowCount(t, db, &models.Theatre{}, 1+8+8)
+}
@@ -0,0 +1,29 @@
+package business
+
+import (
+	"theatre-management-system/src/interfaces"
+)
+
+// Services bundles the entity services, for operations that span several of them
+type Services struct {
+	Locations    interfaces.LocationService
+	TheatreTypes interfaces.TheatreTypeService
+	ShowTypes    interfaces.ShowTypeService
+	Theatres     interfaces.TheatreService
+	Shows        interfaces.ShowService
+}
+
+// noDeletes stands in for the delete policies where services only create and update, as imports and seeding do
+var noDeletes = DeletePolicies{}
+
+// NewServices builds every entity service on the given unit of work, so they can share its transaction;
+// they record no metrics because their caller decides whether the work commits, and their events wait for it
+func NewServices(uow interfaces.UnitOfWork, events interfaces.EventPublisher, policies DeletePolicies) *Services {
+	return &Services{
+		Locations:    NewLocationService(uow, nopMetrics{}, events, policies),
+		TheatreTypes: NewTheatreTypeService(uow, nopMetrics{}, events, policies),
+		ShowTypes:    NewShowTypeService(uow, nopMetrics{}, events, policies),
+		Theatres:     NewTheatreService(uow, nopMetrics{}, events, policies),
+		Shows:        NewShowService(uow, nopMetrics{}, events),
+	}
+}
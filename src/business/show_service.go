@@ -1,11 +1,15 @@
 package business
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
 	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
 	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -15,30 +19,35 @@ import (
 
 // showService implements the ShowService interface
 type showService struct {
-	showRepo     interfaces.ShowRepository
-	theatreRepo  interfaces.TheatreRepository
-	showTypeRepo interfaces.ShowTypeRepository
-	mapper       *mappers.ShowMapper
-	validator    *validator.Validate
+	uow            interfaces.UnitOfWork
+	showRepo       interfaces.ShowRepository
+	mapper         *mappers.ShowMapper
+	jsonLDMapper   *mappers.JSONLDMapper
+	revisionMapper *mappers.RevisionMapper
+	validator      *validator.Validate
+	metrics        interfaces.BusinessMetrics
+	events         interfaces.EventPublisher
 }
 
 // NewShowService creates a new show service
-func NewShowService(
-	showRepo interfaces.ShowRepository,
-	theatreRepo interfaces.TheatreRepository,
-	showTypeRepo interfaces.ShowTypeRepository,
-) interfaces.ShowService {
+func NewShowService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher) interfaces.ShowService {
 	return &showService{
-		showRepo:     showRepo,
-		theatreRepo:  theatreRepo,
-		showTypeRepo: showTypeRepo,
-		mapper:       mappers.NewShowMapper(),
-		validator:    validator.New(),
+		uow:            uow,
+		showRepo:       uow.Shows(),
+		mapper:         mappers.NewShowMapper(),
+		jsonLDMapper:   mappers.NewJSONLDMapper(),
+		revisionMapper: mappers.NewRevisionMapper(),
+		validator:      validator.New(),
+		metrics:        metrics,
+		events:         events,
 	}
 }
 
 // CreateShow creates a new show
-func (s *showService) CreateShow(showDTO *dto.ShowBase) (*dto.ShowDetails, error) {
+func (s *showService) CreateShow(ctx context.Context, showDTO *dto.ShowBase) (*dto.ShowDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.CreateShow")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(showDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
@@ -49,31 +58,51 @@ func (s *showService) CreateShow(showDTO *dto.ShowBase) (*dto.ShowDetails, error
 		return nil, err
 	}
 
-	// Validate foreign key relationships
-	if err := s.validateRelationships(showDTO.TheatreID, showDTO.ShowTypeID); err != nil {
-		return nil, err
-	}
+	var details *dto.ShowDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Validate foreign key relationships
+		if err := s.validateRelationships(ctx, tx, showDTO.TheatreID, showDTO.ShowTypeID); err != nil {
+			return err
+		}
 
-	// Convert DTO to model
-	show := s.mapper.ToModel(showDTO)
+		// Convert DTO to model
+		show := s.mapper.ToModel(showDTO)
 
-	// Create in database
-	if err := s.showRepo.Create(show); err != nil {
-		return nil, err
-	}
+		// Create in database
+		if err := tx.Shows().Create(ctx, show); err != nil {
+			return err
+		}
 
-	// Get created show with relationships
-	createdShow, err := s.showRepo.GetByID(show.ID)
+		// Get created show with relationships
+		createdShow, err := tx.Shows().GetByID(ctx, show.ID)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(createdShow)
+		if err := recordAudit(ctx, tx, constants.EventAggregateShow, details.ID, nil, details); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, tx, details.ID, nil, s.mapper.ToBaseDTO(createdShow), nil); err != nil {
+			return err
+		}
+		events := append([]interfaces.Event{ShowCreated{Show: details}}, showFeaturedEvents(nil, details)...)
+		return recordEvents(ctx, tx, s.events, events...)
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.EntitiesCreated(constants.ImportEntityShows, 1)
 
-	return s.mapper.ToDetailsDTO(createdShow), nil
+	return details, nil
 }
 
 // GetShowByID retrieves a show by ID
-func (s *showService) GetShowByID(id uuid.UUID) (*dto.ShowDetails, error) {
-	show, err := s.showRepo.GetByID(id)
+func (s *showService) GetShowByID(ctx context.Context, id uuid.UUID) (*dto.ShowDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowByID")
+	defer span.End()
+
+	show, err := s.showRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorShowNotFound)
@@ -84,17 +113,36 @@ func (s *showService) GetShowByID(id uuid.UUID) (*dto.ShowDetails, error) {
 	return s.mapper.ToDetailsDTO(show), nil
 }
 
+// GetShowStructuredData retrieves a show as a schema.org TheaterEvent document
+func (s *showService) GetShowStructuredData(ctx context.Context, id uuid.UUID) (*dto.TheaterEventLD, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowStructuredData")
+	defer span.End()
+
+	show, err := s.showRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorShowNotFound)
+		}
+		return nil, err
+	}
+
+	return s.jsonLDMapper.ToTheaterEvent(show), nil
+}
+
 // GetAllShows retrieves all shows with pagination
-func (s *showService) GetAllShows(limit, offset int) ([]*dto.ShowSummary, error) {
+func (s *showService) GetAllShows(ctx context.Context, limit, offset int) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetAllShows")
+	defer span.End()
+
 	// Apply default and max limits
-	if limit <= 0 || limit > constants.MaxLimit {
+	if limit <= 0 || limit > constants.PaginationHardLimit {
 		limit = constants.DefaultLimit
 	}
 	if offset < 0 {
 		offset = constants.DefaultOffset
 	}
 
-	shows, err := s.showRepo.GetAll(limit, offset)
+	shows, err := s.showRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -103,65 +151,280 @@ func (s *showService) GetAllShows(limit, offset int) ([]*dto.ShowSummary, error)
 }
 
 // UpdateShow updates an existing show
-func (s *showService) UpdateShow(id uuid.UUID, showDTO *dto.ShowBase) (*dto.ShowDetails, error) {
+func (s *showService) UpdateShow(ctx context.Context, id uuid.UUID, showDTO *dto.ShowBase) (*dto.ShowDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.UpdateShow")
+	defer span.End()
+
+	return s.updateShow(ctx, id, showDTO, nil)
+}
+
+// updateShow validates and applies an update, recording it as a restore of restoredFrom when that is set
+func (s *showService) updateShow(ctx context.Context, id uuid.UUID, showDTO *dto.ShowBase, restoredFrom *int) (*dto.ShowDetails, error) {
 	// Validate input
 	if err := s.validator.Struct(showDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Get existing show
-	show, err := s.showRepo.GetByID(id)
+	// Validate business rules
+	if err := s.validateShowDates(showDTO.StartDate, showDTO.EndDate); err != nil {
+		return nil, err
+	}
+
+	var details *dto.ShowDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Get existing show
+		show, err := tx.Shows().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorShowNotFound)
+			}
+			return err
+		}
+
+		// Snapshot the current state so the update event can list what changed
+		before := s.mapper.ToDetailsDTO(show)
+		beforeFields := s.mapper.ToBaseDTO(show)
+
+		// Validate foreign key relationships
+		if err := s.validateRelationships(ctx, tx, showDTO.TheatreID, showDTO.ShowTypeID); err != nil {
+			return err
+		}
+
+		// Update model with new data
+		s.mapper.UpdateModel(show, showDTO)
+
+		// Bump the revision so calendar subscribers pick up the change
+		show.Sequence++
+
+		// Save to database
+		if err := tx.Shows().Update(ctx, show); err != nil {
+			return err
+		}
+
+		// Get updated show with relationships
+		updatedShow, err := tx.Shows().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(updatedShow)
+		if err := recordAudit(ctx, tx, constants.EventAggregateShow, id, before, details); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, tx, id, beforeFields, s.mapper.ToBaseDTO(updatedShow), restoredFrom); err != nil {
+			return err
+		}
+		events := append([]interfaces.Event{ShowUpdated{Show: details, ChangedFields: changedFields(before, details)}}, showFeaturedEvents(before, details)...)
+		return recordEvents(ctx, tx, s.events, events...)
+	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New(constants.ErrorShowNotFound)
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// DeleteShow soft deletes a show; nothing belongs to a show, so there is nothing to reassign
+func (s *showService) DeleteShow(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ShowService.DeleteShow")
+	defer span.End()
+
+	if reassignTo != nil {
+		return errors.New(constants.ErrorInvalidReassignTarget + ": nothing belongs to a show")
+	}
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Check if show exists
+		show, err := tx.Shows().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorShowNotFound)
+			}
+			return err
+		}
+
+		if err := tx.Shows().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx, constants.EventAggregateShow, id, s.mapper.ToDetailsDTO(show), nil); err != nil {
+			return err
 		}
+		return recordEvents(ctx, tx, s.events, ShowDeleted{ID: id, TheatreID: show.TheatreID, ShowTypeID: show.ShowTypeID})
+	})
+}
+
+// GetDeletedShows retrieves shows in the trash with pagination, most recently deleted first
+func (s *showService) GetDeletedShows(ctx context.Context, limit, offset int) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetDeletedShows")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	shows, err := s.showRepo.GetDeleted(ctx, limit, offset)
+	if err != nil {
 		return nil, err
 	}
 
-	// Validate business rules
-	if err := s.validateShowDates(showDTO.StartDate, showDTO.EndDate); err != nil {
+	return s.mapper.ToSummaryDTOs(shows), nil
+}
+
+// RestoreShow takes a show out of the trash, provided what it belongs to still exists
+func (s *showService) RestoreShow(ctx context.Context, id uuid.UUID) (*dto.ShowDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.RestoreShow")
+	defer span.End()
+
+	var details *dto.ShowDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		show, err := tx.Shows().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		// What it belongs to may have been deleted too
+		if err := s.validateRelationships(ctx, tx, show.TheatreID, show.ShowTypeID); err != nil {
+			return err
+		}
+
+		if err := tx.Shows().Restore(ctx, id); err != nil {
+			return err
+		}
+
+		// Get restored show with relationships
+		restoredShow, err := tx.Shows().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(restoredShow)
+		if err := recordAuditAction(ctx, tx, constants.EventAggregateShow, id, constants.AuditActionRestore, auditChanges(nil, details)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, ShowRestored{Show: details})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// PurgeShow permanently deletes a show from the trash, along with its revision history
+func (s *showService) PurgeShow(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ShowService.PurgeShow")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		show, err := tx.Shows().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		purged, err := tx.Shows().Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			return errors.New(constants.ErrorNotInTrash)
+		}
+
+		return recordAuditAction(ctx, tx, constants.EventAggregateShow, id, constants.AuditActionPurge, auditChanges(s.mapper.ToDetailsDTO(show), nil))
+	})
+}
+
+// GetShowRevisions retrieves a show's revision history with pagination, newest first
+func (s *showService) GetShowRevisions(ctx context.Context, id uuid.UUID, limit, offset int) ([]*dto.RevisionSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowRevisions")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	if _, err := s.GetShowByID(ctx, id); err != nil {
 		return nil, err
 	}
 
-	// Validate foreign key relationships
-	if err := s.validateRelationships(showDTO.TheatreID, showDTO.ShowTypeID); err != nil {
+	revisions, err := s.uow.ShowRevisions().GetByShowID(ctx, id, limit, offset)
+	if err != nil {
 		return nil, err
 	}
 
-	// Update model with new data
-	s.mapper.UpdateModel(show, showDTO)
+	return s.revisionMapper.ShowRevisionsToSummaryDTOs(revisions), nil
+}
+
+// GetShowRevision retrieves one revision of a show
+func (s *showService) GetShowRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.RevisionDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowRevision")
+	defer span.End()
 
-	// Save to database
-	if err := s.showRepo.Update(show); err != nil {
+	showRevision, err := s.getRevision(ctx, id, revision)
+	if err != nil {
 		return nil, err
 	}
 
-	// Get updated show with relationships
-	updatedShow, err := s.showRepo.GetByID(id)
+	return s.revisionMapper.ShowRevisionToDetailsDTO(showRevision), nil
+}
+
+// DiffShowRevisions lists the fields that differ between two revisions of a show
+func (s *showService) DiffShowRevisions(ctx context.Context, id uuid.UUID, from, to int) (*dto.RevisionDiff, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.DiffShowRevisions")
+	defer span.End()
+
+	fromRevision, err := s.getRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.getRevision(ctx, id, to)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapper.ToDetailsDTO(updatedShow), nil
+	return revisionDiff(from, to, fromRevision.Snapshot, toRevision.Snapshot), nil
 }
 
-// DeleteShow soft deletes a show
-func (s *showService) DeleteShow(id uuid.UUID) error {
-	// Check if show exists
-	_, err := s.showRepo.GetByID(id)
+// RestoreShowRevision updates a show back to the fields it had at a revision, recording a new revision
+func (s *showService) RestoreShowRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.ShowDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.RestoreShowRevision")
+	defer span.End()
+
+	showRevision, err := s.getRevision(ctx, id, revision)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New(constants.ErrorShowNotFound)
-		}
-		return err
+		return nil, err
 	}
 
-	return s.showRepo.Delete(id)
+	var showDTO dto.ShowBase
+	if err := json.Unmarshal(showRevision.Snapshot, &showDTO); err != nil {
+		return nil, err
+	}
+
+	// Goes through the normal update, so a revision that no longer validates, say because its theatre has
+	// since been deleted, is refused rather than restored
+	return s.updateShow(ctx, id, &showDTO, &revision)
 }
 
 // GetShowsByTheatreID retrieves shows by theatre ID
-func (s *showService) GetShowsByTheatreID(theatreID uuid.UUID) ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetByTheatreID(theatreID)
+func (s *showService) GetShowsByTheatreID(ctx context.Context, theatreID uuid.UUID) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowsByTheatreID")
+	defer span.End()
+
+	shows, err := s.showRepo.GetByTheatreID(ctx, theatreID)
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +433,11 @@ func (s *showService) GetShowsByTheatreID(theatreID uuid.UUID) ([]*dto.ShowSumma
 }
 
 // GetShowsByShowTypeID retrieves shows by show type ID
-func (s *showService) GetShowsByShowTypeID(showTypeID uuid.UUID) ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetByShowTypeID(showTypeID)
+func (s *showService) GetShowsByShowTypeID(ctx context.Context, showTypeID uuid.UUID) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetShowsByShowTypeID")
+	defer span.End()
+
+	shows, err := s.showRepo.GetByShowTypeID(ctx, showTypeID)
 	if err != nil {
 		return nil, err
 	}
@@ -180,8 +446,11 @@ func (s *showService) GetShowsByShowTypeID(showTypeID uuid.UUID) ([]*dto.ShowSum
 }
 
 // GetFeaturedShows retrieves all featured shows
-func (s *showService) GetFeaturedShows() ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetFeaturedShows()
+func (s *showService) GetFeaturedShows(ctx context.Context) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetFeaturedShows")
+	defer span.End()
+
+	shows, err := s.showRepo.GetFeaturedShows(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -190,8 +459,11 @@ func (s *showService) GetFeaturedShows() ([]*dto.ShowSummary, error) {
 }
 
 // GetActiveShows retrieves all active shows
-func (s *showService) GetActiveShows() ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetActiveShows()
+func (s *showService) GetActiveShows(ctx context.Context) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetActiveShows")
+	defer span.End()
+
+	shows, err := s.showRepo.GetActiveShows(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -200,8 +472,11 @@ func (s *showService) GetActiveShows() ([]*dto.ShowSummary, error) {
 }
 
 // GetCurrentShows retrieves shows that are currently running
-func (s *showService) GetCurrentShows() ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetCurrentShows()
+func (s *showService) GetCurrentShows(ctx context.Context) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetCurrentShows")
+	defer span.End()
+
+	shows, err := s.showRepo.GetCurrentShows(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -210,8 +485,11 @@ func (s *showService) GetCurrentShows() ([]*dto.ShowSummary, error) {
 }
 
 // GetUpcomingShows retrieves shows that will start in the future
-func (s *showService) GetUpcomingShows() ([]*dto.ShowSummary, error) {
-	shows, err := s.showRepo.GetUpcomingShows()
+func (s *showService) GetUpcomingShows(ctx context.Context) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.GetUpcomingShows")
+	defer span.End()
+
+	shows, err := s.showRepo.GetUpcomingShows(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -220,19 +498,86 @@ func (s *showService) GetUpcomingShows() ([]*dto.ShowSummary, error) {
 }
 
 // SearchShows searches shows by query string
-func (s *showService) SearchShows(query string) ([]*dto.ShowSummary, error) {
+func (s *showService) SearchShows(ctx context.Context, query string) ([]*dto.ShowSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowService.SearchShows")
+	defer span.End()
+
 	if query == "" {
 		return []*dto.ShowSummary{}, nil
 	}
 
-	shows, err := s.showRepo.Search(query)
+	shows, err := s.showRepo.Search(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.SearchExecuted(constants.ImportEntityShows)
 
 	return s.mapper.ToSummaryDTOs(shows), nil
 }
 
+// getRevision retrieves one revision of an existing show
+func (s *showService) getRevision(ctx context.Context, id uuid.UUID, revision int) (*models.ShowRevision, error) {
+	if _, err := s.GetShowByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	showRevision, err := s.uow.ShowRevisions().GetByRevision(ctx, id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorRevisionNotFound)
+		}
+		return nil, err
+	}
+	return showRevision, nil
+}
+
+// recordRevision adds a show's fields after a write to its history. A show that predates revision history first
+// gets the fields the write replaced as a baseline; a write that changed nothing is only recorded if it is a restore.
+func (s *showService) recordRevision(ctx context.Context, tx interfaces.UnitOfWork, id uuid.UUID, before, after *dto.ShowBase, restoredFrom *int) error {
+	latest, err := tx.ShowRevisions().GetLatest(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		latest, err = nil, nil
+		if before != nil {
+			latest, err = s.appendRevision(ctx, tx, id, 1, constants.RevisionActionBaseline, before, nil)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if latest != nil {
+		if restoredFrom == nil && sameFields(latest.Snapshot, after) {
+			return nil
+		}
+		next = latest.Revision + 1
+	}
+	_, err = s.appendRevision(ctx, tx, id, next, revisionAction(before == nil, restoredFrom), after, restoredFrom)
+	return err
+}
+
+// appendRevision stores fields as the given revision of a show
+func (s *showService) appendRevision(ctx context.Context, tx interfaces.UnitOfWork, id uuid.UUID, number int, action string, fields *dto.ShowBase, restoredFrom *int) (*models.ShowRevision, error) {
+	snapshot, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &models.ShowRevision{
+		ShowID:       id,
+		Revision:     number,
+		Action:       action,
+		RestoredFrom: restoredFrom,
+		Actor:        auditActor(ctx),
+		RequestID:    logging.RequestID(ctx),
+		Snapshot:     snapshot,
+	}
+	if err := tx.ShowRevisions().Append(ctx, revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
 // validateShowDates validates that start and end dates make sense
 func (s *showService) validateShowDates(startDate, endDate *time.Time) error {
 	if startDate != nil && endDate != nil {
@@ -243,10 +588,11 @@ func (s *showService) validateShowDates(startDate, endDate *time.Time) error {
 	return nil
 }
 
-// validateRelationships validates that theatre and show type exist
-func (s *showService) validateRelationships(theatreID, showTypeID uuid.UUID) error {
+// validateRelationships validates that theatre and show type exist, locking them
+// so they can't be deleted before the transaction commits
+func (s *showService) validateRelationships(ctx context.Context, tx interfaces.UnitOfWork, theatreID, showTypeID uuid.UUID) error {
 	// Validate theatre exists
-	_, err := s.theatreRepo.GetByID(theatreID)
+	err := tx.Theatres().LockForShare(ctx, theatreID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New(constants.ErrorTheatreNotFound)
@@ -255,7 +601,7 @@ func (s *showService) validateRelationships(theatreID, showTypeID uuid.UUID) err
 	}
 
 	// Validate show type exists
-	_, err = s.showTypeRepo.GetByID(showTypeID)
+	err = tx.ShowTypes().LockForShare(ctx, showTypeID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New(constants.ErrorShowTypeNotFound)
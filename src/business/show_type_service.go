@@ -1,6 +1,7 @@
 package business
 
 import (
+	"context"
 	"errors"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
@@ -14,48 +15,75 @@ import (
 
 // showTypeService implements the ShowTypeService interface
 type showTypeService struct {
+	uow          interfaces.UnitOfWork
 	showTypeRepo interfaces.ShowTypeRepository
 	mapper       *mappers.ShowTypeMapper
 	validator    *validator.Validate
+	metrics      interfaces.BusinessMetrics
+	events       interfaces.EventPublisher
+	policies     DeletePolicies
 }
 
 // NewShowTypeService creates a new show type service
-func NewShowTypeService(showTypeRepo interfaces.ShowTypeRepository) interfaces.ShowTypeService {
+func NewShowTypeService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher, policies DeletePolicies) interfaces.ShowTypeService {
 	return &showTypeService{
-		showTypeRepo: showTypeRepo,
+		uow:          uow,
+		showTypeRepo: uow.ShowTypes(),
 		mapper:       mappers.NewShowTypeMapper(),
 		validator:    validator.New(),
+		metrics:      metrics,
+		events:       events,
+		policies:     policies,
 	}
 }
 
 // CreateShowType creates a new show type
-func (s *showTypeService) CreateShowType(showTypeDTO *dto.ShowTypeBase) (*dto.ShowTypeDetails, error) {
+func (s *showTypeService) CreateShowType(ctx context.Context, showTypeDTO *dto.ShowTypeBase) (*dto.ShowTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.CreateShowType")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(showTypeDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Check if show type with same name already exists
-	existing, err := s.showTypeRepo.GetByName(showTypeDTO.Name)
-	if err == nil && existing != nil {
-		return nil, errors.New(constants.ErrorDuplicateEntry + ": show type name already exists")
-	}
-
 	// Convert DTO to model
 	showType := s.mapper.ToModel(showTypeDTO)
 
-	// Create in database
-	if err := s.showTypeRepo.Create(showType); err != nil {
+	var details *dto.ShowTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Check if show type with same name already exists
+		existing, err := tx.ShowTypes().GetByName(ctx, showTypeDTO.Name)
+		if err == nil && existing != nil {
+			return errors.New(constants.ErrorDuplicateEntry + ": show type name already exists")
+		}
+
+		// Create in database
+		if err := tx.ShowTypes().Create(ctx, showType); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(showType)
+		if err := recordAudit(ctx, tx, constants.EventAggregateShowType, details.ID, nil, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, ShowTypeCreated{ShowType: details})
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.EntitiesCreated(constants.BatchResourceShowTypes, 1)
 
 	// Return created show type
-	return s.mapper.ToDetailsDTO(showType), nil
+	return details, nil
 }
 
 // GetShowTypeByID retrieves a show type by ID
-func (s *showTypeService) GetShowTypeByID(id uuid.UUID) (*dto.ShowTypeDetails, error) {
-	showType, err := s.showTypeRepo.GetByID(id)
+func (s *showTypeService) GetShowTypeByID(ctx context.Context, id uuid.UUID) (*dto.ShowTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.GetShowTypeByID")
+	defer span.End()
+
+	showType, err := s.showTypeRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorShowTypeNotFound)
@@ -67,16 +95,19 @@ func (s *showTypeService) GetShowTypeByID(id uuid.UUID) (*dto.ShowTypeDetails, e
 }
 
 // GetAllShowTypes retrieves all show types with pagination
-func (s *showTypeService) GetAllShowTypes(limit, offset int) ([]*dto.ShowTypeSummary, error) {
+func (s *showTypeService) GetAllShowTypes(ctx context.Context, limit, offset int) ([]*dto.ShowTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.GetAllShowTypes")
+	defer span.End()
+
 	// Apply default and max limits
-	if limit <= 0 || limit > constants.MaxLimit {
+	if limit <= 0 || limit > constants.PaginationHardLimit {
 		limit = constants.DefaultLimit
 	}
 	if offset < 0 {
 		offset = constants.DefaultOffset
 	}
 
-	showTypes, err := s.showTypeRepo.GetAll(limit, offset)
+	showTypes, err := s.showTypeRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -85,61 +116,207 @@ func (s *showTypeService) GetAllShowTypes(limit, offset int) ([]*dto.ShowTypeSum
 }
 
 // UpdateShowType updates an existing show type
-func (s *showTypeService) UpdateShowType(id uuid.UUID, showTypeDTO *dto.ShowTypeBase) (*dto.ShowTypeDetails, error) {
+func (s *showTypeService) UpdateShowType(ctx context.Context, id uuid.UUID, showTypeDTO *dto.ShowTypeBase) (*dto.ShowTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.UpdateShowType")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(showTypeDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Get existing show type
-	showType, err := s.showTypeRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New(constants.ErrorShowTypeNotFound)
+	var details *dto.ShowTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Get existing show type
+		showType, err := tx.ShowTypes().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorShowTypeNotFound)
+			}
+			return err
+		}
+
+		// Snapshot the current state so the update event can list what changed
+		before := s.mapper.ToDetailsDTO(showType)
+
+		// Check if name conflicts with another show type
+		if showType.Name != showTypeDTO.Name {
+			existing, err := tx.ShowTypes().GetByName(ctx, showTypeDTO.Name)
+			if err == nil && existing != nil && existing.ID != id {
+				return errors.New(constants.ErrorDuplicateEntry + ": show type name already exists")
+			}
 		}
+
+		// Update model with new data
+		s.mapper.UpdateModel(showType, showTypeDTO)
+
+		// Save to database
+		if err := tx.ShowTypes().Update(ctx, showType); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(showType)
+		if err := recordAudit(ctx, tx, constants.EventAggregateShowType, id, before, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, ShowTypeUpdated{ShowType: details, ChangedFields: changedFields(before, details)})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Check if name conflicts with another show type
-	if showType.Name != showTypeDTO.Name {
-		existing, err := s.showTypeRepo.GetByName(showTypeDTO.Name)
-		if err == nil && existing != nil && existing.ID != id {
-			return nil, errors.New(constants.ErrorDuplicateEntry + ": show type name already exists")
+	return details, nil
+}
+
+// DeleteShowType soft deletes a show type, dealing with its shows by the show type's delete policy
+func (s *showTypeService) DeleteShowType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.DeleteShowType")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Lock the show type so no show can be given it while it is being deleted
+		if err := tx.ShowTypes().LockForUpdate(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorShowTypeNotFound)
+			}
+			return err
+		}
+		showType, err := tx.ShowTypes().GetByID(ctx, id)
+		if err != nil {
+			return err
 		}
-	}
 
-	// Update model with new data
-	s.mapper.UpdateModel(showType, showTypeDTO)
+		shows, err := tx.Shows().GetByShowTypeID(ctx, id)
+		if err != nil {
+			return err
+		}
+		services := NewServices(tx, s.events, s.policies)
+		err = applyDeletePolicy(ctx, id, reassignTo, deleteRelationship{
+			policy:     s.policies.ShowTypeShows,
+			parent:     constants.EventAggregateShowType,
+			dependents: showDependents(shows),
+			lockTarget: tx.ShowTypes().LockForShare,
+			remove:     func() error { return deleteShows(ctx, services, shows) },
+			move: func(to uuid.UUID) error {
+				return moveShows(ctx, services, shows, func(showDTO *dto.ShowBase) { showDTO.ShowTypeID = to })
+			},
+		})
+		if err != nil {
+			return err
+		}
 
-	// Save to database
-	if err := s.showTypeRepo.Update(showType); err != nil {
+		if err := tx.ShowTypes().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx, constants.EventAggregateShowType, id, s.mapper.ToDetailsDTO(showType), nil); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, ShowTypeDeleted{ID: id})
+	})
+}
+
+// GetDeletedShowTypes retrieves show types in the trash with pagination, most recently deleted first
+func (s *showTypeService) GetDeletedShowTypes(ctx context.Context, limit, offset int) ([]*dto.ShowTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.GetDeletedShowTypes")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	showTypes, err := s.showTypeRepo.GetDeleted(ctx, limit, offset)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.mapper.ToDetailsDTO(showType), nil
+	return s.mapper.ToSummaryDTOs(showTypes), nil
 }
 
-// DeleteShowType soft deletes a show type
-func (s *showTypeService) DeleteShowType(id uuid.UUID) error {
-	// Check if show type exists
-	_, err := s.showTypeRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New(constants.ErrorShowTypeNotFound)
+// RestoreShowType takes a show type out of the trash, unless another one has taken its name meanwhile
+func (s *showTypeService) RestoreShowType(ctx context.Context, id uuid.UUID) (*dto.ShowTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.RestoreShowType")
+	defer span.End()
+
+	var details *dto.ShowTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		showType, err := tx.ShowTypes().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		// Its name may have been taken while it was deleted
+		existing, err := tx.ShowTypes().GetByName(ctx, showType.Name)
+		if err == nil && existing != nil {
+			return errors.New(constants.ErrorDuplicateEntry + ": show type name already exists")
 		}
-		return err
+
+		if err := tx.ShowTypes().Restore(ctx, id); err != nil {
+			return err
+		}
+
+		// Get restored show type with relationships
+		restoredShowType, err := tx.ShowTypes().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(restoredShowType)
+		if err := recordAuditAction(ctx, tx, constants.EventAggregateShowType, id, constants.AuditActionRestore, auditChanges(nil, details)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, ShowTypeRestored{ShowType: details})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.showTypeRepo.Delete(id)
+	return details, nil
+}
+
+// PurgeShowType permanently deletes a show type from the trash, unless anything still refers to it
+func (s *showTypeService) PurgeShowType(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.PurgeShowType")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		showType, err := tx.ShowTypes().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		purged, err := tx.ShowTypes().Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			return errors.New(constants.ErrorStillReferenced + ": shows, deleted or not, still have this type")
+		}
+
+		return recordAuditAction(ctx, tx, constants.EventAggregateShowType, id, constants.AuditActionPurge, auditChanges(s.mapper.ToDetailsDTO(showType), nil))
+	})
 }
 
 // GetShowTypeByName retrieves a show type by name
-func (s *showTypeService) GetShowTypeByName(name string) (*dto.ShowTypeDetails, error) {
+func (s *showTypeService) GetShowTypeByName(ctx context.Context, name string) (*dto.ShowTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.GetShowTypeByName")
+	defer span.End()
+
 	if name == "" {
 		return nil, errors.New(constants.ErrorInvalidInput + ": name cannot be empty")
 	}
 
-	showType, err := s.showTypeRepo.GetByName(name)
+	showType, err := s.showTypeRepo.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorShowTypeNotFound)
@@ -151,8 +328,11 @@ func (s *showTypeService) GetShowTypeByName(name string) (*dto.ShowTypeDetails,
 }
 
 // GetActiveShowTypes retrieves all active show types
-func (s *showTypeService) GetActiveShowTypes() ([]*dto.ShowTypeSummary, error) {
-	showTypes, err := s.showTypeRepo.GetActiveTypes()
+func (s *showTypeService) GetActiveShowTypes(ctx context.Context) ([]*dto.ShowTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "ShowTypeService.GetActiveShowTypes")
+	defer span.End()
+
+	showTypes, err := s.showTypeRepo.GetActiveTypes(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,11 +1,15 @@
 package business
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
 	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
 	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -14,60 +18,87 @@ import (
 
 // theatreService implements the TheatreService interface
 type theatreService struct {
-	theatreRepo     interfaces.TheatreRepository
-	locationRepo    interfaces.LocationRepository
-	theatreTypeRepo interfaces.TheatreTypeRepository
-	mapper          *mappers.TheatreMapper
-	validator       *validator.Validate
+	uow            interfaces.UnitOfWork
+	theatreRepo    interfaces.TheatreRepository
+	mapper         *mappers.TheatreMapper
+	jsonLDMapper   *mappers.JSONLDMapper
+	revisionMapper *mappers.RevisionMapper
+	validator      *validator.Validate
+	metrics        interfaces.BusinessMetrics
+	events         interfaces.EventPublisher
+	policies       DeletePolicies
 }
 
 // NewTheatreService creates a new theatre service
-func NewTheatreService(
-	theatreRepo interfaces.TheatreRepository,
-	locationRepo interfaces.LocationRepository,
-	theatreTypeRepo interfaces.TheatreTypeRepository,
-) interfaces.TheatreService {
+func NewTheatreService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher, policies DeletePolicies) interfaces.TheatreService {
 	return &theatreService{
-		theatreRepo:     theatreRepo,
-		locationRepo:    locationRepo,
-		theatreTypeRepo: theatreTypeRepo,
-		mapper:          mappers.NewTheatreMapper(),
-		validator:       validator.New(),
+		uow:            uow,
+		theatreRepo:    uow.Theatres(),
+		mapper:         mappers.NewTheatreMapper(),
+		jsonLDMapper:   mappers.NewJSONLDMapper(),
+		revisionMapper: mappers.NewRevisionMapper(),
+		validator:      validator.New(),
+		metrics:        metrics,
+		events:         events,
+		policies:       policies,
 	}
 }
 
 // CreateTheatre creates a new theatre
-func (s *theatreService) CreateTheatre(theatreDTO *dto.TheatreBase) (*dto.TheatreDetails, error) {
+func (s *theatreService) CreateTheatre(ctx context.Context, theatreDTO *dto.TheatreBase) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.CreateTheatre")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(theatreDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Validate foreign key relationships
-	if err := s.validateRelationships(theatreDTO.LocationID, theatreDTO.TheatreTypeID); err != nil {
-		return nil, err
-	}
+	var details *dto.TheatreDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Validate foreign key relationships
+		if err := s.validateRelationships(ctx, tx, theatreDTO.LocationID, theatreDTO.TheatreTypeID); err != nil {
+			return err
+		}
 
-	// Convert DTO to model
-	theatre := s.mapper.ToModel(theatreDTO)
+		// Convert DTO to model
+		theatre := s.mapper.ToModel(theatreDTO)
 
-	// Create in database
-	if err := s.theatreRepo.Create(theatre); err != nil {
-		return nil, err
-	}
+		// Create in database
+		if err := tx.Theatres().Create(ctx, theatre); err != nil {
+			return err
+		}
+
+		// Get created theatre with relationships
+		createdTheatre, err := tx.Theatres().GetByID(ctx, theatre.ID)
+		if err != nil {
+			return err
+		}
 
-	// Get created theatre with relationships
-	createdTheatre, err := s.theatreRepo.GetByID(theatre.ID)
+		details = s.mapper.ToDetailsDTO(createdTheatre)
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatre, details.ID, nil, details); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, tx, details.ID, nil, s.mapper.ToBaseDTO(createdTheatre), nil); err != nil {
+			return err
+		}
+		events := append([]interfaces.Event{TheatreCreated{Theatre: details}}, theatreFeaturedEvents(nil, details)...)
+		return recordEvents(ctx, tx, s.events, events...)
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.EntitiesCreated(constants.ImportEntityTheatres, 1)
 
-	return s.mapper.ToDetailsDTO(createdTheatre), nil
+	return details, nil
 }
 
 // GetTheatreByID retrieves a theatre by ID
-func (s *theatreService) GetTheatreByID(id uuid.UUID) (*dto.TheatreDetails, error) {
-	theatre, err := s.theatreRepo.GetByID(id)
+func (s *theatreService) GetTheatreByID(ctx context.Context, id uuid.UUID) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatreByID")
+	defer span.End()
+
+	theatre, err := s.theatreRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorTheatreNotFound)
@@ -78,17 +109,36 @@ func (s *theatreService) GetTheatreByID(id uuid.UUID) (*dto.TheatreDetails, erro
 	return s.mapper.ToDetailsDTO(theatre), nil
 }
 
+// GetTheatreStructuredData retrieves a theatre as a schema.org PerformingArtsTheater document
+func (s *theatreService) GetTheatreStructuredData(ctx context.Context, id uuid.UUID) (*dto.PerformingArtsTheaterLD, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatreStructuredData")
+	defer span.End()
+
+	theatre, err := s.theatreRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorTheatreNotFound)
+		}
+		return nil, err
+	}
+
+	return s.jsonLDMapper.ToPerformingArtsTheater(theatre), nil
+}
+
 // GetAllTheatres retrieves all theatres with pagination
-func (s *theatreService) GetAllTheatres(limit, offset int) ([]*dto.TheatreSummary, error) {
+func (s *theatreService) GetAllTheatres(ctx context.Context, limit, offset int) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetAllTheatres")
+	defer span.End()
+
 	// Apply default and max limits
-	if limit <= 0 || limit > constants.MaxLimit {
+	if limit <= 0 || limit > constants.PaginationHardLimit {
 		limit = constants.DefaultLimit
 	}
 	if offset < 0 {
 		offset = constants.DefaultOffset
 	}
 
-	theatres, err := s.theatreRepo.GetAll(limit, offset)
+	theatres, err := s.theatreRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -97,60 +147,215 @@ func (s *theatreService) GetAllTheatres(limit, offset int) ([]*dto.TheatreSummar
 }
 
 // UpdateTheatre updates an existing theatre
-func (s *theatreService) UpdateTheatre(id uuid.UUID, theatreDTO *dto.TheatreBase) (*dto.TheatreDetails, error) {
+func (s *theatreService) UpdateTheatre(ctx context.Context, id uuid.UUID, theatreDTO *dto.TheatreBase) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.UpdateTheatre")
+	defer span.End()
+
+	return s.updateTheatre(ctx, id, theatreDTO, nil)
+}
+
+// updateTheatre validates and applies an update, recording it as a restore of restoredFrom when that is set
+func (s *theatreService) updateTheatre(ctx context.Context, id uuid.UUID, theatreDTO *dto.TheatreBase, restoredFrom *int) (*dto.TheatreDetails, error) {
 	// Validate input
 	if err := s.validator.Struct(theatreDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Get existing theatre
-	theatre, err := s.theatreRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New(constants.ErrorTheatreNotFound)
+	var details *dto.TheatreDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Get existing theatre
+		theatre, err := tx.Theatres().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorTheatreNotFound)
+			}
+			return err
+		}
+
+		// Snapshot the current state so the update event can list what changed
+		before := s.mapper.ToDetailsDTO(theatre)
+		beforeFields := s.mapper.ToBaseDTO(theatre)
+
+		// Validate foreign key relationships
+		if err := s.validateRelationships(ctx, tx, theatreDTO.LocationID, theatreDTO.TheatreTypeID); err != nil {
+			return err
+		}
+
+		// Update model with new data
+		s.mapper.UpdateModel(theatre, theatreDTO)
+
+		// Save to database
+		if err := tx.Theatres().Update(ctx, theatre); err != nil {
+			return err
+		}
+
+		// Get updated theatre with relationships
+		updatedTheatre, err := tx.Theatres().GetByID(ctx, id)
+		if err != nil {
+			return err
 		}
-		return nil, err
-	}
 
-	// Validate foreign key relationships
-	if err := s.validateRelationships(theatreDTO.LocationID, theatreDTO.TheatreTypeID); err != nil {
+		details = s.mapper.ToDetailsDTO(updatedTheatre)
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatre, id, before, details); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, tx, id, beforeFields, s.mapper.ToBaseDTO(updatedTheatre), restoredFrom); err != nil {
+			return err
+		}
+		events := append([]interfaces.Event{TheatreUpdated{Theatre: details, ChangedFields: changedFields(before, details)}}, theatreFeaturedEvents(before, details)...)
+		return recordEvents(ctx, tx, s.events, events...)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Update model with new data
-	s.mapper.UpdateModel(theatre, theatreDTO)
+	return details, nil
+}
 
-	// Save to database
-	if err := s.theatreRepo.Update(theatre); err != nil {
-		return nil, err
+// DeleteTheatre soft deletes a theatre, dealing with its shows by the theatre's delete policy
+func (s *theatreService) DeleteTheatre(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "TheatreService.DeleteTheatre")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Lock the theatre so no show can be added to it while it is being deleted
+		if err := tx.Theatres().LockForUpdate(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorTheatreNotFound)
+			}
+			return err
+		}
+		theatre, err := tx.Theatres().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		shows, err := tx.Shows().GetByTheatreID(ctx, id)
+		if err != nil {
+			return err
+		}
+		services := NewServices(tx, s.events, s.policies)
+		err = applyDeletePolicy(ctx, id, reassignTo, deleteRelationship{
+			policy:     s.policies.TheatreShows,
+			parent:     constants.EventAggregateTheatre,
+			dependents: showDependents(shows),
+			lockTarget: tx.Theatres().LockForShare,
+			remove:     func() error { return deleteShows(ctx, services, shows) },
+			move: func(to uuid.UUID) error {
+				return moveShows(ctx, services, shows, func(showDTO *dto.ShowBase) { showDTO.TheatreID = to })
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Theatres().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatre, id, s.mapper.ToDetailsDTO(theatre), nil); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreDeleted{ID: id})
+	})
+}
+
+// GetDeletedTheatres retrieves theatres in the trash with pagination, most recently deleted first
+func (s *theatreService) GetDeletedTheatres(ctx context.Context, limit, offset int) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetDeletedTheatres")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
 	}
 
-	// Get updated theatre with relationships
-	updatedTheatre, err := s.theatreRepo.GetByID(id)
+	theatres, err := s.theatreRepo.GetDeleted(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapper.ToDetailsDTO(updatedTheatre), nil
+	return s.mapper.ToSummaryDTOs(theatres), nil
 }
 
-// DeleteTheatre soft deletes a theatre
-func (s *theatreService) DeleteTheatre(id uuid.UUID) error {
-	// Check if theatre exists
-	_, err := s.theatreRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New(constants.ErrorTheatreNotFound)
+// RestoreTheatre takes a theatre out of the trash, provided what it belongs to still exists
+func (s *theatreService) RestoreTheatre(ctx context.Context, id uuid.UUID) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.RestoreTheatre")
+	defer span.End()
+
+	var details *dto.TheatreDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		theatre, err := tx.Theatres().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
 		}
-		return err
+
+		// What it belongs to may have been deleted too
+		if err := s.validateRelationships(ctx, tx, theatre.LocationID, theatre.TheatreTypeID); err != nil {
+			return err
+		}
+
+		if err := tx.Theatres().Restore(ctx, id); err != nil {
+			return err
+		}
+
+		// Get restored theatre with relationships
+		restoredTheatre, err := tx.Theatres().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(restoredTheatre)
+		if err := recordAuditAction(ctx, tx, constants.EventAggregateTheatre, id, constants.AuditActionRestore, auditChanges(nil, details)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreRestored{Theatre: details})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.theatreRepo.Delete(id)
+	return details, nil
+}
+
+// PurgeTheatre permanently deletes a theatre and its revision history from the trash, unless shows still refer to it
+func (s *theatreService) PurgeTheatre(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "TheatreService.PurgeTheatre")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		theatre, err := tx.Theatres().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		purged, err := tx.Theatres().Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			return errors.New(constants.ErrorStillReferenced + ": shows, deleted or not, still play at it")
+		}
+
+		return recordAuditAction(ctx, tx, constants.EventAggregateTheatre, id, constants.AuditActionPurge, auditChanges(s.mapper.ToDetailsDTO(theatre), nil))
+	})
 }
 
 // GetTheatresByLocationID retrieves theatres by location ID
-func (s *theatreService) GetTheatresByLocationID(locationID uuid.UUID) ([]*dto.TheatreSummary, error) {
-	theatres, err := s.theatreRepo.GetByLocationID(locationID)
+func (s *theatreService) GetTheatresByLocationID(ctx context.Context, locationID uuid.UUID) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatresByLocationID")
+	defer span.End()
+
+	theatres, err := s.theatreRepo.GetByLocationID(ctx, locationID)
 	if err != nil {
 		return nil, err
 	}
@@ -158,9 +363,32 @@ func (s *theatreService) GetTheatresByLocationID(locationID uuid.UUID) ([]*dto.T
 	return s.mapper.ToSummaryDTOs(theatres), nil
 }
 
+// GetTheatreByNameAndCity retrieves a theatre by name and the city of its location
+func (s *theatreService) GetTheatreByNameAndCity(ctx context.Context, name, city string) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatreByNameAndCity")
+	defer span.End()
+
+	if name == "" || city == "" {
+		return nil, errors.New(constants.ErrorInvalidInput + ": name and city cannot be empty")
+	}
+
+	theatre, err := s.theatreRepo.GetByNameAndCity(ctx, name, city)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorTheatreNotFound)
+		}
+		return nil, err
+	}
+
+	return s.mapper.ToDetailsDTO(theatre), nil
+}
+
 // GetTheatresByTheatreTypeID retrieves theatres by theatre type ID
-func (s *theatreService) GetTheatresByTheatreTypeID(theatreTypeID uuid.UUID) ([]*dto.TheatreSummary, error) {
-	theatres, err := s.theatreRepo.GetByTheatreTypeID(theatreTypeID)
+func (s *theatreService) GetTheatresByTheatreTypeID(ctx context.Context, theatreTypeID uuid.UUID) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatresByTheatreTypeID")
+	defer span.End()
+
+	theatres, err := s.theatreRepo.GetByTheatreTypeID(ctx, theatreTypeID)
 	if err != nil {
 		return nil, err
 	}
@@ -169,8 +397,11 @@ func (s *theatreService) GetTheatresByTheatreTypeID(theatreTypeID uuid.UUID) ([]
 }
 
 // GetFeaturedTheatres retrieves all featured theatres
-func (s *theatreService) GetFeaturedTheatres() ([]*dto.TheatreSummary, error) {
-	theatres, err := s.theatreRepo.GetFeaturedTheatres()
+func (s *theatreService) GetFeaturedTheatres(ctx context.Context) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetFeaturedTheatres")
+	defer span.End()
+
+	theatres, err := s.theatreRepo.GetFeaturedTheatres(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -179,8 +410,11 @@ func (s *theatreService) GetFeaturedTheatres() ([]*dto.TheatreSummary, error) {
 }
 
 // GetActiveTheatres retrieves all active theatres
-func (s *theatreService) GetActiveTheatres() ([]*dto.TheatreSummary, error) {
-	theatres, err := s.theatreRepo.GetActiveTheatres()
+func (s *theatreService) GetActiveTheatres(ctx context.Context) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetActiveTheatres")
+	defer span.End()
+
+	theatres, err := s.theatreRepo.GetActiveTheatres(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -189,21 +423,28 @@ func (s *theatreService) GetActiveTheatres() ([]*dto.TheatreSummary, error) {
 }
 
 // SearchTheatres searches theatres by query string
-func (s *theatreService) SearchTheatres(query string) ([]*dto.TheatreSummary, error) {
+func (s *theatreService) SearchTheatres(ctx context.Context, query string) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.SearchTheatres")
+	defer span.End()
+
 	if query == "" {
 		return []*dto.TheatreSummary{}, nil
 	}
 
-	theatres, err := s.theatreRepo.Search(query)
+	theatres, err := s.theatreRepo.Search(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	s.metrics.SearchExecuted(constants.ImportEntityTheatres)
 
 	return s.mapper.ToSummaryDTOs(theatres), nil
 }
 
 // GetNearbyTheatres finds theatres within a radius of given coordinates
-func (s *theatreService) GetNearbyTheatres(latitude, longitude, radius float64) ([]*dto.TheatreSummary, error) {
+func (s *theatreService) GetNearbyTheatres(ctx context.Context, latitude, longitude, radius float64) ([]*dto.TheatreSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetNearbyTheatres")
+	defer span.End()
+
 	// Validate coordinates
 	if latitude < -90 || latitude > 90 {
 		return nil, errors.New("invalid latitude: must be between -90 and 90")
@@ -215,7 +456,7 @@ func (s *theatreService) GetNearbyTheatres(latitude, longitude, radius float64)
 		radius = constants.DefaultRadius
 	}
 
-	theatres, err := s.theatreRepo.GetNearbyTheatres(latitude, longitude, radius)
+	theatres, err := s.theatreRepo.GetNearbyTheatres(ctx, latitude, longitude, radius)
 	if err != nil {
 		return nil, err
 	}
@@ -223,10 +464,149 @@ func (s *theatreService) GetNearbyTheatres(latitude, longitude, radius float64)
 	return s.mapper.ToSummaryDTOs(theatres), nil
 }
 
-// validateRelationships validates that location and theatre type exist
-func (s *theatreService) validateRelationships(locationID, theatreTypeID uuid.UUID) error {
+// GetTheatreRevisions retrieves a theatre's revision history with pagination, newest first
+func (s *theatreService) GetTheatreRevisions(ctx context.Context, id uuid.UUID, limit, offset int) ([]*dto.RevisionSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatreRevisions")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	if _, err := s.GetTheatreByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.uow.TheatreRevisions().GetByTheatreID(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.revisionMapper.TheatreRevisionsToSummaryDTOs(revisions), nil
+}
+
+// GetTheatreRevision retrieves one revision of a theatre
+func (s *theatreService) GetTheatreRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.RevisionDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.GetTheatreRevision")
+	defer span.End()
+
+	theatreRevision, err := s.getRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.revisionMapper.TheatreRevisionToDetailsDTO(theatreRevision), nil
+}
+
+// DiffTheatreRevisions lists the fields that differ between two revisions of a theatre
+func (s *theatreService) DiffTheatreRevisions(ctx context.Context, id uuid.UUID, from, to int) (*dto.RevisionDiff, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.DiffTheatreRevisions")
+	defer span.End()
+
+	fromRevision, err := s.getRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.getRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisionDiff(from, to, fromRevision.Snapshot, toRevision.Snapshot), nil
+}
+
+// RestoreTheatreRevision updates a theatre back to the fields it had at a revision, recording a new revision
+func (s *theatreService) RestoreTheatreRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.TheatreDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreService.RestoreTheatreRevision")
+	defer span.End()
+
+	theatreRevision, err := s.getRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var theatreDTO dto.TheatreBase
+	if err := json.Unmarshal(theatreRevision.Snapshot, &theatreDTO); err != nil {
+		return nil, err
+	}
+
+	// Goes through the normal update, so a revision that no longer validates, say because its location has
+	// since been deleted, is refused rather than restored
+	return s.updateTheatre(ctx, id, &theatreDTO, &revision)
+}
+
+// getRevision retrieves one revision of an existing theatre
+func (s *theatreService) getRevision(ctx context.Context, id uuid.UUID, revision int) (*models.TheatreRevision, error) {
+	if _, err := s.GetTheatreByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	theatreRevision, err := s.uow.TheatreRevisions().GetByRevision(ctx, id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorRevisionNotFound)
+		}
+		return nil, err
+	}
+	return theatreRevision, nil
+}
+
+// recordRevision adds a theatre's fields after a write to its history. A theatre that predates revision history first
+// gets the fields the write replaced as a baseline; a write that changed nothing is only recorded if it is a restore.
+func (s *theatreService) recordRevision(ctx context.Context, tx interfaces.UnitOfWork, id uuid.UUID, before, after *dto.TheatreBase, restoredFrom *int) error {
+	latest, err := tx.TheatreRevisions().GetLatest(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		latest, err = nil, nil
+		if before != nil {
+			latest, err = s.appendRevision(ctx, tx, id, 1, constants.RevisionActionBaseline, before, nil)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if latest != nil {
+		if restoredFrom == nil && sameFields(latest.Snapshot, after) {
+			return nil
+		}
+		next = latest.Revision + 1
+	}
+	_, err = s.appendRevision(ctx, tx, id, next, revisionAction(before == nil, restoredFrom), after, restoredFrom)
+	return err
+}
+
+// appendRevision stores fields as the given revision of a theatre
+func (s *theatreService) appendRevision(ctx context.Context, tx interfaces.UnitOfWork, id uuid.UUID, number int, action string, fields *dto.TheatreBase, restoredFrom *int) (*models.TheatreRevision, error) {
+	snapshot, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &models.TheatreRevision{
+		TheatreID:    id,
+		Revision:     number,
+		Action:       action,
+		RestoredFrom: restoredFrom,
+		Actor:        auditActor(ctx),
+		RequestID:    logging.RequestID(ctx),
+		Snapshot:     snapshot,
+	}
+	if err := tx.TheatreRevisions().Append(ctx, revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// validateRelationships validates that location and theatre type exist, locking them
+// so they can't be deleted before the transaction commits
+func (s *theatreService) validateRelationships(ctx context.Context, tx interfaces.UnitOfWork, locationID, theatreTypeID uuid.UUID) error {
 	// Validate location exists
-	_, err := s.locationRepo.GetByID(locationID)
+	err := tx.Locations().LockForShare(ctx, locationID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New(constants.ErrorLocationNotFound)
@@ -235,7 +615,7 @@ func (s *theatreService) validateRelationships(locationID, theatreTypeID uuid.UU
 	}
 
 	// Validate theatre type exists
-	_, err = s.theatreTypeRepo.GetByID(theatreTypeID)
+	err = tx.TheatreTypes().LockForShare(ctx, theatreTypeID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New(constants.ErrorTheatreTypeNotFound)
@@ -1,6 +1,7 @@
 package business
 
 import (
+	"context"
 	"errors"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
@@ -14,48 +15,75 @@ import (
 
 // theatreTypeService implements the TheatreTypeService interface
 type theatreTypeService struct {
+	uow             interfaces.UnitOfWork
 	theatreTypeRepo interfaces.TheatreTypeRepository
 	mapper          *mappers.TheatreTypeMapper
 	validator       *validator.Validate
+	metrics         interfaces.BusinessMetrics
+	events          interfaces.EventPublisher
+	policies        DeletePolicies
 }
 
 // NewTheatreTypeService creates a new theatre type service
-func NewTheatreTypeService(theatreTypeRepo interfaces.TheatreTypeRepository) interfaces.TheatreTypeService {
+func NewTheatreTypeService(uow interfaces.UnitOfWork, metrics interfaces.BusinessMetrics, events interfaces.EventPublisher, policies DeletePolicies) interfaces.TheatreTypeService {
 	return &theatreTypeService{
-		theatreTypeRepo: theatreTypeRepo,
+		uow:             uow,
+		theatreTypeRepo: uow.TheatreTypes(),
 		mapper:          mappers.NewTheatreTypeMapper(),
 		validator:       validator.New(),
+		metrics:         metrics,
+		events:          events,
+		policies:        policies,
 	}
 }
 
 // CreateTheatreType creates a new theatre type
-func (s *theatreTypeService) CreateTheatreType(theatreTypeDTO *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error) {
+func (s *theatreTypeService) CreateTheatreType(ctx context.Context, theatreTypeDTO *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.CreateTheatreType")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(theatreTypeDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Check if theatre type with same name already exists
-	existing, err := s.theatreTypeRepo.GetByName(theatreTypeDTO.Name)
-	if err == nil && existing != nil {
-		return nil, errors.New(constants.ErrorDuplicateEntry + ": theatre type name already exists")
-	}
-
 	// Convert DTO to model
 	theatreType := s.mapper.ToModel(theatreTypeDTO)
 
-	// Create in database
-	if err := s.theatreTypeRepo.Create(theatreType); err != nil {
+	var details *dto.TheatreTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Check if theatre type with same name already exists
+		existing, err := tx.TheatreTypes().GetByName(ctx, theatreTypeDTO.Name)
+		if err == nil && existing != nil {
+			return errors.New(constants.ErrorDuplicateEntry + ": theatre type name already exists")
+		}
+
+		// Create in database
+		if err := tx.TheatreTypes().Create(ctx, theatreType); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(theatreType)
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatreType, details.ID, nil, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreTypeCreated{TheatreType: details})
+	})
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.EntitiesCreated(constants.BatchResourceTheatreTypes, 1)
 
 	// Return created theatre type
-	return s.mapper.ToDetailsDTO(theatreType), nil
+	return details, nil
 }
 
 // GetTheatreTypeByID retrieves a theatre type by ID
-func (s *theatreTypeService) GetTheatreTypeByID(id uuid.UUID) (*dto.TheatreTypeDetails, error) {
-	theatreType, err := s.theatreTypeRepo.GetByID(id)
+func (s *theatreTypeService) GetTheatreTypeByID(ctx context.Context, id uuid.UUID) (*dto.TheatreTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.GetTheatreTypeByID")
+	defer span.End()
+
+	theatreType, err := s.theatreTypeRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorTheatreTypeNotFound)
@@ -67,16 +95,19 @@ func (s *theatreTypeService) GetTheatreTypeByID(id uuid.UUID) (*dto.TheatreTypeD
 }
 
 // GetAllTheatreTypes retrieves all theatre types with pagination
-func (s *theatreTypeService) GetAllTheatreTypes(limit, offset int) ([]*dto.TheatreTypeSummary, error) {
+func (s *theatreTypeService) GetAllTheatreTypes(ctx context.Context, limit, offset int) ([]*dto.TheatreTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.GetAllTheatreTypes")
+	defer span.End()
+
 	// Apply default and max limits
-	if limit <= 0 || limit > constants.MaxLimit {
+	if limit <= 0 || limit > constants.PaginationHardLimit {
 		limit = constants.DefaultLimit
 	}
 	if offset < 0 {
 		offset = constants.DefaultOffset
 	}
 
-	theatreTypes, err := s.theatreTypeRepo.GetAll(limit, offset)
+	theatreTypes, err := s.theatreTypeRepo.GetAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -85,61 +116,207 @@ func (s *theatreTypeService) GetAllTheatreTypes(limit, offset int) ([]*dto.Theat
 }
 
 // UpdateTheatreType updates an existing theatre type
-func (s *theatreTypeService) UpdateTheatreType(id uuid.UUID, theatreTypeDTO *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error) {
+func (s *theatreTypeService) UpdateTheatreType(ctx context.Context, id uuid.UUID, theatreTypeDTO *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.UpdateTheatreType")
+	defer span.End()
+
 	// Validate input
 	if err := s.validator.Struct(theatreTypeDTO); err != nil {
 		return nil, errors.New(constants.ErrorValidationFailed + ": " + err.Error())
 	}
 
-	// Get existing theatre type
-	theatreType, err := s.theatreTypeRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New(constants.ErrorTheatreTypeNotFound)
+	var details *dto.TheatreTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Get existing theatre type
+		theatreType, err := tx.TheatreTypes().GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorTheatreTypeNotFound)
+			}
+			return err
+		}
+
+		// Snapshot the current state so the update event can list what changed
+		before := s.mapper.ToDetailsDTO(theatreType)
+
+		// Check if name conflicts with another theatre type
+		if theatreType.Name != theatreTypeDTO.Name {
+			existing, err := tx.TheatreTypes().GetByName(ctx, theatreTypeDTO.Name)
+			if err == nil && existing != nil && existing.ID != id {
+				return errors.New(constants.ErrorDuplicateEntry + ": theatre type name already exists")
+			}
 		}
+
+		// Update model with new data
+		s.mapper.UpdateModel(theatreType, theatreTypeDTO)
+
+		// Save to database
+		if err := tx.TheatreTypes().Update(ctx, theatreType); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(theatreType)
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatreType, id, before, details); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreTypeUpdated{TheatreType: details, ChangedFields: changedFields(before, details)})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Check if name conflicts with another theatre type
-	if theatreType.Name != theatreTypeDTO.Name {
-		existing, err := s.theatreTypeRepo.GetByName(theatreTypeDTO.Name)
-		if err == nil && existing != nil && existing.ID != id {
-			return nil, errors.New(constants.ErrorDuplicateEntry + ": theatre type name already exists")
+	return details, nil
+}
+
+// DeleteTheatreType soft deletes a theatre type, dealing with its theatres by the theatre type's delete policy
+func (s *theatreTypeService) DeleteTheatreType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.DeleteTheatreType")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		// Lock the theatre type so no theatre can be given it while it is being deleted
+		if err := tx.TheatreTypes().LockForUpdate(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorTheatreTypeNotFound)
+			}
+			return err
+		}
+		theatreType, err := tx.TheatreTypes().GetByID(ctx, id)
+		if err != nil {
+			return err
 		}
-	}
 
-	// Update model with new data
-	s.mapper.UpdateModel(theatreType, theatreTypeDTO)
+		theatres, err := tx.Theatres().GetByTheatreTypeID(ctx, id)
+		if err != nil {
+			return err
+		}
+		services := NewServices(tx, s.events, s.policies)
+		err = applyDeletePolicy(ctx, id, reassignTo, deleteRelationship{
+			policy:     s.policies.TheatreTypeTheatres,
+			parent:     constants.EventAggregateTheatreType,
+			dependents: theatreDependents(theatres),
+			lockTarget: tx.TheatreTypes().LockForShare,
+			remove:     func() error { return deleteTheatres(ctx, services, theatres) },
+			move: func(to uuid.UUID) error {
+				return moveTheatres(ctx, services, theatres, func(theatreDTO *dto.TheatreBase) { theatreDTO.TheatreTypeID = to })
+			},
+		})
+		if err != nil {
+			return err
+		}
 
-	// Save to database
-	if err := s.theatreTypeRepo.Update(theatreType); err != nil {
+		if err := tx.TheatreTypes().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx, constants.EventAggregateTheatreType, id, s.mapper.ToDetailsDTO(theatreType), nil); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreTypeDeleted{ID: id})
+	})
+}
+
+// GetDeletedTheatreTypes retrieves theatre types in the trash with pagination, most recently deleted first
+func (s *theatreTypeService) GetDeletedTheatreTypes(ctx context.Context, limit, offset int) ([]*dto.TheatreTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.GetDeletedTheatreTypes")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	theatreTypes, err := s.theatreTypeRepo.GetDeleted(ctx, limit, offset)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.mapper.ToDetailsDTO(theatreType), nil
+	return s.mapper.ToSummaryDTOs(theatreTypes), nil
 }
 
-// DeleteTheatreType soft deletes a theatre type
-func (s *theatreTypeService) DeleteTheatreType(id uuid.UUID) error {
-	// Check if theatre type exists
-	_, err := s.theatreTypeRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New(constants.ErrorTheatreTypeNotFound)
+// RestoreTheatreType takes a theatre type out of the trash, unless another one has taken its name meanwhile
+func (s *theatreTypeService) RestoreTheatreType(ctx context.Context, id uuid.UUID) (*dto.TheatreTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.RestoreTheatreType")
+	defer span.End()
+
+	var details *dto.TheatreTypeDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		theatreType, err := tx.TheatreTypes().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		// Its name may have been taken while it was deleted
+		existing, err := tx.TheatreTypes().GetByName(ctx, theatreType.Name)
+		if err == nil && existing != nil {
+			return errors.New(constants.ErrorDuplicateEntry + ": theatre type name already exists")
 		}
-		return err
+
+		if err := tx.TheatreTypes().Restore(ctx, id); err != nil {
+			return err
+		}
+
+		// Get restored theatre type with relationships
+		restoredTheatreType, err := tx.TheatreTypes().GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(restoredTheatreType)
+		if err := recordAuditAction(ctx, tx, constants.EventAggregateTheatreType, id, constants.AuditActionRestore, auditChanges(nil, details)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, s.events, TheatreTypeRestored{TheatreType: details})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.theatreTypeRepo.Delete(id)
+	return details, nil
+}
+
+// PurgeTheatreType permanently deletes a theatre type from the trash, unless anything still refers to it
+func (s *theatreTypeService) PurgeTheatreType(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.PurgeTheatreType")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		theatreType, err := tx.TheatreTypes().GetDeletedByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorNotInTrash)
+			}
+			return err
+		}
+
+		purged, err := tx.TheatreTypes().Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			return errors.New(constants.ErrorStillReferenced + ": theatres, deleted or not, still have this type")
+		}
+
+		return recordAuditAction(ctx, tx, constants.EventAggregateTheatreType, id, constants.AuditActionPurge, auditChanges(s.mapper.ToDetailsDTO(theatreType), nil))
+	})
 }
 
 // GetTheatreTypeByName retrieves a theatre type by name
-func (s *theatreTypeService) GetTheatreTypeByName(name string) (*dto.TheatreTypeDetails, error) {
+func (s *theatreTypeService) GetTheatreTypeByName(ctx context.Context, name string) (*dto.TheatreTypeDetails, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.GetTheatreTypeByName")
+	defer span.End()
+
 	if name == "" {
 		return nil, errors.New(constants.ErrorInvalidInput + ": name cannot be empty")
 	}
 
-	theatreType, err := s.theatreTypeRepo.GetByName(name)
+	theatreType, err := s.theatreTypeRepo.GetByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New(constants.ErrorTheatreTypeNotFound)
@@ -151,8 +328,11 @@ func (s *theatreTypeService) GetTheatreTypeByName(name string) (*dto.TheatreType
 }
 
 // GetActiveTheatreTypes retrieves all active theatre types
-func (s *theatreTypeService) GetActiveTheatreTypes() ([]*dto.TheatreTypeSummary, error) {
-	theatreTypes, err := s.theatreTypeRepo.GetActiveTypes()
+func (s *theatreTypeService) GetActiveTheatreTypes(ctx context.Context) ([]*dto.TheatreTypeSummary, error) {
+	ctx, span := tracer.Start(ctx, "TheatreTypeService.GetActiveTheatreTypes")
+	defer span.End()
+
+	theatreTypes, err := s.theatreTypeRepo.GetActiveTypes(ctx)
 	if err != nil {
 		return nil, err
 	}
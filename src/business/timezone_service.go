@@ -0,0 +1,33 @@
+package business
+
+import (
+	"fmt"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/timezone"
+	"time"
+)
+
+// TimezoneService resolves IANA timezones for venues
+type TimezoneService struct{}
+
+// NewTimezoneService creates a new timezone service
+func NewTimezoneService() *TimezoneService {
+	return &TimezoneService{}
+}
+
+// ResolveTimezone returns the explicit timezone when set, otherwise derives one from coordinates.
+// Falls back to the default timezone when neither is available.
+func (ts *TimezoneService) ResolveTimezone(explicit string, latitude, longitude *float64) (string, error) {
+	if explicit != "" {
+		if _, err := time.LoadLocation(explicit); err != nil {
+			return "", fmt.Errorf("invalid timezone: %s", explicit)
+		}
+		return explicit, nil
+	}
+
+	if latitude == nil || longitude == nil {
+		return constants.DefaultTimezone, nil
+	}
+
+	return timezone.FromCoordinates(*latitude, *longitude)
+}
@@ -0,0 +1,53 @@
+package business
+
+import (
+	"testing"
+	"theatre-management-system/src/constants"
+)
+
+func TestResolveTimezone(t *testing.T) {
+	coordinates := func(latitude, longitude float64) (*float64, *float64) {
+		return &latitude, &longitude
+	}
+	timesSquareLat, timesSquareLng := coordinates(40.7589, -73.9851)
+	stripLat, stripLng := coordinates(36.1162, -115.1739)
+	torontoLat, torontoLng := coordinates(43.6426, -79.3871)
+	westEndLat, westEndLng := coordinates(51.5142, -0.1506)
+
+	tests := []struct {
+		name      string
+		explicit  string
+		latitude  *float64
+		longitude *float64
+		want      string
+	}{
+		{name: "explicit zone wins over coordinates", explicit: "Europe/London", latitude: timesSquareLat, longitude: timesSquareLng, want: "Europe/London"},
+		{name: "explicit zone without coordinates", explicit: "Asia/Tokyo", want: "Asia/Tokyo"},
+		{name: "New York from coordinates", latitude: timesSquareLat, longitude: timesSquareLng, want: "America/New_York"},
+		{name: "Las Vegas from coordinates", latitude: stripLat, longitude: stripLng, want: "America/Los_Angeles"},
+		{name: "Toronto from coordinates", latitude: torontoLat, longitude: torontoLng, want: "America/Toronto"},
+		{name: "London from coordinates", latitude: westEndLat, longitude: westEndLng, want: "Europe/London"},
+		{name: "no coordinates falls back to UTC", want: constants.DefaultTimezone},
+		{name: "half the coordinates falls back to UTC", latitude: timesSquareLat, want: constants.DefaultTimezone},
+	}
+
+	service := NewTimezoneService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.ResolveTimezone(tt.explicit, tt.latitude, tt.longitude)
+			if err != nil {
+				t.Fatalf("ResolveTimezone returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTimezone = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTimezoneRejectsUnknownZone(t *testing.T) {
+	latitude, longitude := 40.7589, -73.9851
+	if _, err := NewTimezoneService().ResolveTimezone("Mars/Olympus_Mons", &latitude, &longitude); err == nil {
+		t.Error("ResolveTimezone accepted an unknown timezone")
+	}
+}
@@ -0,0 +1,10 @@
+package business
+
+import (
+	"theatre-management-system/src/constants"
+
+	"go.opentelemetry.io/otel"
+)
+
+// tracer starts a span for every public service method; it follows whichever provider main installs
+var tracer = otel.Tracer(constants.TracerName)
@@ -0,0 +1,188 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"theatre-management-system/src/business"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/testdb"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// testSchema keeps these tests' tables apart from other packages' running at the same time
+const testSchema = "business_test"
+
+var errUnavailable = errors.New("unavailable")
+
+// failingUnitOfWork makes the audit or outbox repository of every transaction it starts fail
+type failingUnitOfWork struct {
+	interfaces.UnitOfWork
+	failAudit  bool
+	failOutbox bool
+}
+
+func (u *failingUnitOfWork) Do(ctx context.Context, fn func(tx interfaces.UnitOfWork) error) error {
+	return u.UnitOfWork.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		return fn(&failingUnitOfWork{UnitOfWork: tx, failAudit: u.failAudit, failOutbox: u.failOutbox})
+	})
+}
+
+func (u *failingUnitOfWork) Audit() interfaces.AuditRepository {
+	if u.failAudit {
+		return failingAudit{u.UnitOfWork.Audit()}
+	}
+	return u.UnitOfWork.Audit()
+}
+
+func (u *failingUnitOfWork) Outbox() interfaces.OutboxRepository {
+	if u.failOutbox {
+		return failingOutbox{u.UnitOfWork.Outbox()}
+	}
+	return u.UnitOfWork.Outbox()
+}
+
+type failingAudit struct{ interfaces.AuditRepository }
+
+func (failingAudit) Append(context.Context, ...*models.AuditEntry) error { return errUnavailable }
+
+type failingOutbox struct{ interfaces.OutboxRepository }
+
+func (failingOutbox) Append(context.Context, ...*models.OutboxMessage) error { return errUnavailable }
+
+// recordingPublisher remembers the events published to it
+type recordingPublisher struct{ events []interfaces.Event }
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...interfaces.Event) {
+	p.events = append(p.events, events...)
+}
+
+type noMetrics struct{}
+
+func (noMetrics) EntitiesCreated(string, int) {}
+func (noMetrics) SearchExecuted(string)       {}
+
+// fixtures are the records a theatre or show is created against, written straight to the database
+type fixtures struct {
+	location    *models.Location
+	theatreType *models.TheatreType
+	showType    *models.ShowType
+	theatre     *models.Theatre
+}
+
+func createFixtures(t *testing.T, db *gorm.DB) fixtures {
+	t.Helper()
+	f := fixtures{
+		location:    &models.Location{Name: "Theater District", City: "New York", Country: "United States"},
+		theatreType: &models.TheatreType{Name: "Broadway"},
+		showType:    &models.ShowType{Name: "Musical"},
+	}
+	for _, record := range []interface{}{f.location, f.theatreType, f.showType} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.theatre = &models.Theatre{Name: "Majestic", LocationID: f.location.ID, TheatreTypeID: f.theatreType.ID}
+	if err := db.Create(f.theatre).Error; err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// assertRowCount fails the test unless model's table holds want rows, soft-deleted ones included
+func assertRowCount(t *testing.T, db *gorm.DB, model interface{}, want int64) {
+	t.Helper()
+	var count int64
+	if err := db.Unscoped().Model(model).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != want {
+		t.Errorf("%T rows = %d, want %d", model, count, want)
+	}
+}
+
+func TestCreateTheatreLeavesNothingWhenItFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		uow     failingUnitOfWork
+		missing bool
+		wantErr string
+	}{
+		{name: "unknown theatre type", missing: true, wantErr: constants.ErrorTheatreTypeNotFound},
+		{name: "audit insert fails", uow: failingUnitOfWork{failAudit: true}, wantErr: errUnavailable.Error()},
+		{name: "outbox insert fails", uow: failingUnitOfWork{failOutbox: true}, wantErr: errUnavailable.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := testdb.Migrated(t, testSchema)
+			f := createFixtures(t, db)
+			publisher := &recordingPublisher{}
+			tt.uow.UnitOfWork = repo.NewUnitOfWork(db)
+			service := business.NewTheatreService(&tt.uow, noMetrics{}, publisher, business.DeletePolicies{})
+
+			theatre := &dto.TheatreBase{Name: "Lyceum", LocationID: f.location.ID, TheatreTypeID: f.theatreType.ID}
+			if tt.missing {
+				theatre.TheatreTypeID = uuid.New()
+			}
+			if _, err := service.CreateTheatre(ctx, theatre); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("CreateTheatre returned %v, want %s", err, tt.wantErr)
+			}
+
+			assertRowCount(t, db, &models.Theatre{}, 1) // the fixture
+			assertRowCount(t, db, &models.AuditEntry{}, 0)
+			assertRowCount(t, db, &models.TheatreRevision{}, 0)
+			assertRowCount(t, db, &models.OutboxMessage{}, 0)
+			if len(publisher.events) != 0 {
+				t.Errorf("published %d events for a rolled back create", len(publisher.events))
+			}
+		})
+	}
+}
+
+func TestCreateShowLeavesNothingWhenItFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		uow     failingUnitOfWork
+		missing bool
+		wantErr string
+	}{
+		{name: "unknown theatre", missing: true, wantErr: constants.ErrorTheatreNotFound},
+		{name: "audit insert fails", uow: failingUnitOfWork{failAudit: true}, wantErr: errUnavailable.Error()},
+		{name: "outbox insert fails", uow: failingUnitOfWork{failOutbox: true}, wantErr: errUnavailable.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := testdb.Migrated(t, testSchema)
+			f := createFixtures(t, db)
+			publisher := &recordingPublisher{}
+			tt.uow.UnitOfWork = repo.NewUnitOfWork(db)
+			service := business.NewShowService(&tt.uow, noMetrics{}, publisher)
+
+			show := &dto.ShowBase{Title: "Hamilton", TheatreID: f.theatre.ID, ShowTypeID: f.showType.ID}
+			if tt.missing {
+				show.TheatreID = uuid.New()
+			}
+			if _, err := service.CreateShow(ctx, show); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("CreateShow returned %v, want %s", err, tt.wantErr)
+			}
+
+			assertRowCount(t, db, &models.Show{}, 0)
+			assertRowCount(t, db, &models.AuditEntry{}, 0)
+			assertRowCount(t, db, &models.ShowRevision{}, 0)
+			assertRowCount(t, db, &models.OutboxMessage{}, 0)
+			if len(publisher.events) != 0 {
+				t.Errorf("published %d events for a rolled back create", len(publisher.events))
+			}
+		})
+	}
+}
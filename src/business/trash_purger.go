@@ -0,0 +1,128 @@
+package business
+
+import (
+	"context"
+	"log/slog"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// trashBins lists what the trash holds, children before parents, so a parent whose children
+// expire in the same run is purged with them rather than an hour later
+var trashBins = []struct {
+	entityType string
+	purge      func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error)
+}{
+	{constants.EventAggregateShow, func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error) {
+		return tx.Shows().PurgeDeletedBefore(ctx, cutoff)
+	}},
+	{constants.EventAggregateTheatre, func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error) {
+		return tx.Theatres().PurgeDeletedBefore(ctx, cutoff)
+	}},
+	{constants.EventAggregateShowType, func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error) {
+		return tx.ShowTypes().PurgeDeletedBefore(ctx, cutoff)
+	}},
+	{constants.EventAggregateTheatreType, func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error) {
+		return tx.TheatreTypes().PurgeDeletedBefore(ctx, cutoff)
+	}},
+	{constants.EventAggregateLocation, func(ctx context.Context, tx interfaces.UnitOfWork, cutoff time.Time) ([]uuid.UUID, error) {
+		return tx.Locations().PurgeDeletedBefore(ctx, cutoff)
+	}},
+}
+
+// trashPurger permanently deletes records that have been in the trash longer than the retention period
+type trashPurger struct {
+	uow       interfaces.UnitOfWork
+	retention time.Duration
+
+	// stop ends the purge loop, which closes done once it has returned; cancel aborts a purge in progress
+	stop   chan struct{}
+	done   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewTrashPurger starts a worker that, with a positive retention, purges records deleted longer ago than that
+func NewTrashPurger(uow interfaces.UnitOfWork, retention time.Duration) interfaces.Worker {
+	// Purges are audited as the trash's own doing
+	ctx, cancel := context.WithCancel(logging.WithActor(context.Background(), constants.AuditActorTrash))
+	p := &trashPurger{
+		uow:       uow,
+		retention: retention,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	go p.enforceRetention()
+	return p
+}
+
+// enforceRetention purges expired records now and once per purge interval until stopped
+func (p *trashPurger) enforceRetention() {
+	defer close(p.done)
+	if p.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(constants.TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(time.Now().Add(-p.retention))
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge permanently deletes whatever was deleted before cutoff and nothing refers to any more, auditing each record
+func (p *trashPurger) purge(cutoff time.Time) {
+	for _, bin := range trashBins {
+		var purged int
+		err := p.uow.Do(p.ctx, func(tx interfaces.UnitOfWork) error {
+			ids, err := bin.purge(p.ctx, tx, cutoff)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := recordAuditAction(p.ctx, tx, bin.entityType, id, constants.AuditActionPurge, auditChanges(nil, nil)); err != nil {
+					return err
+				}
+			}
+			purged = len(ids)
+			return nil
+		})
+		if err != nil {
+			if p.ctx.Err() == nil {
+				slog.Error("Failed to purge the trash", "entity", bin.entityType, "error", err)
+			}
+			continue
+		}
+		if purged > 0 {
+			slog.Info("Purged deleted records past their trash retention", "entity", bin.entityType, "count", purged)
+		}
+	}
+}
+
+// Shutdown stops the purge loop, waiting for a purge in progress and cancelling it if ctx expires first
+func (p *trashPurger) Shutdown(ctx context.Context) error {
+	close(p.stop)
+	defer p.cancel()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-p.done
+		return ctx.Err()
+	}
+}
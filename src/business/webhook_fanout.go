@@ -0,0 +1,130 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+)
+
+// webhookEventNames lists every domain event a webhook can subscribe to
+var webhookEventNames = []string{
+	constants.EventLocationCreated, constants.EventLocationUpdated, constants.EventLocationDeleted, constants.EventLocationRestored,
+	constants.EventTheatreTypeCreated, constants.EventTheatreTypeUpdated, constants.EventTheatreTypeDeleted, constants.EventTheatreTypeRestored,
+	constants.EventShowTypeCreated, constants.EventShowTypeUpdated, constants.EventShowTypeDeleted, constants.EventShowTypeRestored,
+	constants.EventTheatreCreated, constants.EventTheatreUpdated, constants.EventTheatreDeleted, constants.EventTheatreRestored,
+	constants.EventTheatreFeatured, constants.EventTheatreUnfeatured,
+	constants.EventShowCreated, constants.EventShowUpdated, constants.EventShowDeleted, constants.EventShowRestored,
+	constants.EventShowFeatured, constants.EventShowUnfeatured,
+}
+
+// validWebhookEventType reports whether a subscription filter names an event, every event of an entity ("show.*") or everything ("*")
+func validWebhookEventType(eventType string) bool {
+	if eventType == constants.EventAll || slices.Contains(webhookEventNames, eventType) {
+		return true
+	}
+	entity, found := strings.CutSuffix(eventType, ".*")
+	return found && slices.ContainsFunc(webhookEventNames, func(name string) bool {
+		return strings.HasPrefix(name, entity+".")
+	})
+}
+
+// webhookFanOut is the outbox sink that queues a delivery for every active subscription an event matches
+type webhookFanOut struct {
+	uow interfaces.UnitOfWork
+}
+
+// NewWebhookFanOut creates the outbox sink feeding webhook subscriptions; the dispatcher sends what it queues
+func NewWebhookFanOut(uow interfaces.UnitOfWork) interfaces.OutboxSink {
+	return &webhookFanOut{uow: uow}
+}
+
+// Name returns "subscriptions"
+func (f *webhookFanOut) Name() string { return constants.OutboxSinkWebhookSubscriptions }
+
+// Deliver queues the envelope for each matching subscription; redelivering a message queues nothing new
+func (f *webhookFanOut) Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error {
+	subscriptions, err := f.uow.Webhooks().GetActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	theatreID, showTypeID := eventScope(envelope)
+	var payload json.RawMessage
+	var deliveries []*models.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscriptionMatches(subscription, envelope.Event, theatreID, showTypeID) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(envelope); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			SubscriptionID:  subscription.ID,
+			OutboxMessageID: &envelope.ID,
+			EventName:       envelope.Event,
+			Payload:         payload,
+			Status:          constants.WebhookDeliveryPending,
+		})
+	}
+
+	return f.uow.Webhooks().CreateDeliveries(ctx, deliveries...)
+}
+
+// Close does nothing
+func (f *webhookFanOut) Close() error { return nil }
+
+// subscriptionMatches reports whether a subscription wants an event about the given theatre and show type
+func subscriptionMatches(subscription *models.WebhookSubscription, event string, theatreID, showTypeID uuid.UUID) bool {
+	if subscription.TheatreID != nil && *subscription.TheatreID != theatreID {
+		return false
+	}
+	if subscription.ShowTypeID != nil && *subscription.ShowTypeID != showTypeID {
+		return false
+	}
+
+	entity, _, _ := strings.Cut(event, ".")
+	for _, eventType := range subscription.EventTypes {
+		if eventType == constants.EventAll || eventType == event || eventType == entity+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// eventScope finds the theatre and show type an event is about; either is uuid.Nil when the event has none,
+// so scoped subscriptions never see events about locations, theatre types or unrelated entities
+func eventScope(envelope *dto.OutboxEnvelope) (theatreID, showTypeID uuid.UUID) {
+	switch envelope.AggregateType {
+	case constants.EventAggregateTheatre:
+		return envelope.AggregateID, uuid.Nil
+	case constants.EventAggregateShowType:
+		return uuid.Nil, envelope.AggregateID
+	case constants.EventAggregateShow:
+		// Show events carry the show, except deletes, which carry the IDs directly
+		var data struct {
+			Show *struct {
+				TheatreID  uuid.UUID `json:"theatre_id"`
+				ShowTypeID uuid.UUID `json:"show_type_id"`
+			} `json:"show"`
+			TheatreID  uuid.UUID `json:"theatre_id"`
+			ShowTypeID uuid.UUID `json:"show_type_id"`
+		}
+		if err := json.Unmarshal(envelope.Data, &data); err != nil {
+			return uuid.Nil, uuid.Nil
+		}
+		if data.Show != nil {
+			return data.Show.TheatreID, data.Show.ShowTypeID
+		}
+		return data.TheatreID, data.ShowTypeID
+	}
+	return uuid.Nil, uuid.Nil
+}
@@ -0,0 +1,345 @@
+package business
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/logging"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// webhookService implements the WebhookService interface
+type webhookService struct {
+	uow         interfaces.UnitOfWork
+	webhookRepo interfaces.WebhookRepository
+	sender      interfaces.WebhookSender
+	mapper      *mappers.WebhookMapper
+	validator   *validator.Validate
+}
+
+// NewWebhookService creates a new webhook service; test events are sent through sender
+func NewWebhookService(uow interfaces.UnitOfWork, sender interfaces.WebhookSender) interfaces.WebhookService {
+	return &webhookService{
+		uow:         uow,
+		webhookRepo: uow.Webhooks(),
+		sender:      sender,
+		mapper:      mappers.NewWebhookMapper(),
+		validator:   validator.New(),
+	}
+}
+
+// CreateWebhook creates a new webhook subscription, generating a secret when none is given;
+// the secret is only ever returned here
+func (s *webhookService) CreateWebhook(ctx context.Context, webhookDTO *dto.WebhookBase) (*dto.WebhookDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	if err := s.validateWebhook(ctx, webhookDTO); err != nil {
+		return nil, err
+	}
+
+	subscription := s.mapper.ToModel(webhookDTO)
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if err := s.validateScope(ctx, tx, webhookDTO.TheatreID, webhookDTO.ShowTypeID); err != nil {
+			return err
+		}
+		return tx.Webhooks().Create(ctx, subscription)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := s.mapper.ToDetailsDTO(subscription)
+	details.Secret = subscription.Secret
+	return details, nil
+}
+
+// GetWebhookByID retrieves a webhook subscription by ID
+func (s *webhookService) GetWebhookByID(ctx context.Context, id uuid.UUID) (*dto.WebhookDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetWebhookByID")
+	defer span.End()
+
+	subscription, err := s.getSubscription(ctx, s.webhookRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDetailsDTO(subscription), nil
+}
+
+// GetAllWebhooks retrieves all webhook subscriptions with pagination
+func (s *webhookService) GetAllWebhooks(ctx context.Context, limit, offset int) ([]*dto.WebhookDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetAllWebhooks")
+	defer span.End()
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	subscriptions, err := s.webhookRepo.GetAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDetailsDTOs(subscriptions), nil
+}
+
+// UpdateWebhook updates a webhook subscription, keeping its secret unless a new one is given;
+// re-enabling a disabled webhook clears its failures, and its pending deliveries resume
+func (s *webhookService) UpdateWebhook(ctx context.Context, id uuid.UUID, webhookDTO *dto.WebhookBase) (*dto.WebhookDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.UpdateWebhook")
+	defer span.End()
+
+	if err := s.validateWebhook(ctx, webhookDTO); err != nil {
+		return nil, err
+	}
+
+	var details *dto.WebhookDetails
+	err := s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		subscription, err := s.getSubscription(ctx, tx.Webhooks(), id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.validateScope(ctx, tx, webhookDTO.TheatreID, webhookDTO.ShowTypeID); err != nil {
+			return err
+		}
+
+		wasActive := subscription.IsActive
+		s.mapper.UpdateModel(subscription, webhookDTO)
+		if subscription.IsActive && !wasActive {
+			subscription.ConsecutiveFailures = 0
+			subscription.DisabledAt = nil
+			subscription.DisabledReason = ""
+		}
+
+		if err := tx.Webhooks().Update(ctx, subscription); err != nil {
+			return err
+		}
+
+		details = s.mapper.ToDetailsDTO(subscription)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// DeleteWebhook deletes a webhook subscription; deliveries still pending are never sent
+func (s *webhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	return s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if _, err := s.getSubscription(ctx, tx.Webhooks(), id); err != nil {
+			return err
+		}
+		return tx.Webhooks().Delete(ctx, id)
+	})
+}
+
+// SendTestEvent sends a webhook.test event straight away, once, and logs it as a delivery.
+// Disabled webhooks can be tested, and a failed test does not count towards disabling.
+func (s *webhookService) SendTestEvent(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.SendTestEvent")
+	defer span.End()
+
+	subscription, err := s.getSubscription(ctx, s.webhookRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]string{"message": "This is a test event; no data changed."})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(&dto.OutboxEnvelope{
+		ID:            uuid.New(),
+		Event:         constants.WebhookEventTest,
+		AggregateType: constants.WebhookAggregateTest,
+		AggregateID:   subscription.ID,
+		OccurredAt:    time.Now(),
+		RequestID:     logging.RequestID(ctx),
+		Data:          data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		EventName:      constants.WebhookEventTest,
+		Payload:        payload,
+		Attempts:       1,
+	}
+	attempt := s.sender.Send(ctx, subscription, delivery)
+	// The caller sees the result straight away, so the endpoint's reply isn't passed on to them
+	attempt.ResponseBody = ""
+
+	// Stored already complete, so the dispatcher never picks it up
+	now := time.Now()
+	delivery.Status = constants.WebhookDeliveryFailed
+	if attempt.Succeeded() {
+		delivery.Status = constants.WebhookDeliverySucceeded
+	}
+	delivery.LastResponseStatus = attempt.ResponseStatus
+	delivery.LastError = attempt.Error
+	delivery.CompletedAt = &now
+
+	err = s.uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if err := tx.Webhooks().CreateDeliveries(ctx, delivery); err != nil {
+			return err
+		}
+		return tx.Webhooks().SaveAttempt(ctx, delivery, attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Log = []models.WebhookDeliveryAttempt{*attempt}
+	return s.mapper.ToDeliveryDetailsDTO(delivery), nil
+}
+
+// GetDeliveries retrieves a webhook's deliveries, newest first, optionally only those with one status
+func (s *webhookService) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, limit, offset int) ([]*dto.WebhookDeliverySummary, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDeliveries")
+	defer span.End()
+
+	switch status {
+	case "", constants.WebhookDeliveryPending, constants.WebhookDeliverySucceeded, constants.WebhookDeliveryFailed:
+	default:
+		return nil, errors.New(constants.ErrorWebhookInvalidDeliveryStatus + ": status must be pending, succeeded or failed")
+	}
+
+	// Apply default and max limits
+	if limit <= 0 || limit > constants.PaginationHardLimit {
+		limit = constants.DefaultLimit
+	}
+	if offset < 0 {
+		offset = constants.DefaultOffset
+	}
+
+	if _, err := s.getSubscription(ctx, s.webhookRepo, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveries(ctx, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDeliverySummaryDTOs(deliveries), nil
+}
+
+// GetDelivery retrieves one of a webhook's deliveries with every attempt made
+func (s *webhookService) GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*dto.WebhookDeliveryDetails, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDelivery")
+	defer span.End()
+
+	if _, err := s.getSubscription(ctx, s.webhookRepo, webhookID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookRepo.GetDelivery(ctx, webhookID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorWebhookDeliveryNotFound)
+		}
+		return nil, err
+	}
+
+	return s.mapper.ToDeliveryDetailsDTO(delivery), nil
+}
+
+// getSubscription loads a subscription, reporting a missing one as ErrorWebhookNotFound
+func (s *webhookService) getSubscription(ctx context.Context, repo interfaces.WebhookRepository, id uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription, err := repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(constants.ErrorWebhookNotFound)
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// validateWebhook checks the request shape, that the URL is http(s) on a public address and that every event
+// type filter means something
+func (s *webhookService) validateWebhook(ctx context.Context, webhookDTO *dto.WebhookBase) error {
+	if err := s.validator.Struct(webhookDTO); err != nil {
+		return errors.New(constants.ErrorValidationFailed + ": " + err.Error())
+	}
+
+	if u, err := url.Parse(webhookDTO.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New(constants.ErrorWebhookInvalid + ": url must be an http:// or https:// URL")
+	}
+	if err := s.sender.CheckURL(ctx, webhookDTO.URL); err != nil {
+		return err
+	}
+
+	for _, eventType := range webhookDTO.EventTypes {
+		if !validWebhookEventType(eventType) {
+			return fmt.Errorf("%s: unknown event type %q; use an event name such as %q, \"show.*\" or \"*\"",
+				constants.ErrorWebhookInvalid, eventType, constants.EventShowUpdated)
+		}
+	}
+	return nil
+}
+
+// validateScope checks that the theatre and show type a webhook is scoped to exist, locking them until the transaction ends
+func (s *webhookService) validateScope(ctx context.Context, tx interfaces.UnitOfWork, theatreID, showTypeID *uuid.UUID) error {
+	if theatreID != nil {
+		if err := tx.Theatres().LockForShare(ctx, *theatreID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorTheatreNotFound)
+			}
+			return err
+		}
+	}
+	if showTypeID != nil {
+		if err := tx.ShowTypes().LockForShare(ctx, *showTypeID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(constants.ErrorShowTypeNotFound)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// generateWebhookSecret returns a random signing secret
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, constants.WebhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return constants.WebhookSecretPrefix + hex.EncodeToString(secret), nil
+}
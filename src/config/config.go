@@ -0,0 +1,592 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the application's effective configuration
+type Config struct {
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Pagination PaginationConfig `yaml:"pagination"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	Webhooks   WebhooksConfig   `yaml:"webhooks"`
+	Audit      AuditConfig      `yaml:"audit"`
+	Trash      TrashConfig      `yaml:"trash"`
+	Delete     DeleteConfig     `yaml:"delete"`
+	Contract   ContractConfig   `yaml:"contract"`
+}
+
+// ServerConfig holds HTTP server settings
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT" usage:"HTTP listen port"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"REQUEST_TIMEOUT" usage:"deadline for a request's database queries"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" usage:"maximum time to read a whole request, body included"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" usage:"maximum time to read request headers"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" usage:"maximum time to write a response"`
+	ExportTimeout     time.Duration `yaml:"export_timeout" env:"SERVER_EXPORT_TIMEOUT" usage:"deadline for a ?format=csv|xlsx export, replacing request_timeout and extending write_timeout by the difference"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" usage:"how long keep-alive connections wait for the next request"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES" usage:"largest accepted request header block"`
+	MaxBodyBytes      int           `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES" usage:"largest accepted request body (imports have their own limit)"`
+	DrainDelay        time.Duration `yaml:"drain_delay" env:"SERVER_DRAIN_DELAY" usage:"time readiness reports 503 before the listener closes on shutdown"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" usage:"time in-flight requests and background jobs get to finish on shutdown"`
+}
+
+// DatabaseConfig holds the connection string and pool settings
+type DatabaseConfig struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL" usage:"PostgreSQL connection URL"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"maximum open connections (0 is unlimited)"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"maximum idle connections kept in the pool"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"maximum time a connection is reused (0 is forever)"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"maximum time a connection sits idle (0 is forever)"`
+	ConnectAttempts int           `yaml:"connect_attempts" env:"DB_CONNECT_ATTEMPTS" usage:"connection attempts at startup before giving up"`
+	ConnectBackoff  time.Duration `yaml:"connect_backoff" env:"DB_CONNECT_BACKOFF" usage:"wait before the second connection attempt; doubles after each failure"`
+	ReplicaURL      string        `yaml:"replica_url" env:"DATABASE_REPLICA_URL" usage:"PostgreSQL read replica URL for list and search queries (empty reads from the primary)"`
+	StickyWindow    time.Duration `yaml:"sticky_window" env:"DB_STICKY_WINDOW" usage:"how long a client's reads stay on the primary after it writes (0 disables)"`
+}
+
+// CORSConfig holds cross-origin resource sharing settings
+type CORSConfig struct {
+	AllowOrigins     []string      `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS" usage:"comma-separated allowed origins, or *"`
+	AllowMethods     []string      `yaml:"allow_methods" env:"CORS_ALLOW_METHODS" usage:"comma-separated allowed methods"`
+	AllowHeaders     []string      `yaml:"allow_headers" env:"CORS_ALLOW_HEADERS" usage:"comma-separated allowed request headers"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" usage:"allow cookies and auth headers (requires explicit origins)"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" usage:"how long browsers may cache preflight responses"`
+}
+
+// CacheConfig holds in-memory cache settings
+type CacheConfig struct {
+	DefaultTTL      time.Duration `yaml:"default_ttl" env:"CACHE_DEFAULT_TTL" usage:"default cache entry lifetime"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"CACHE_CLEANUP_INTERVAL" usage:"interval between expired entry sweeps"`
+}
+
+// PaginationConfig holds list endpoint page sizes
+type PaginationConfig struct {
+	DefaultLimit int `yaml:"default_limit" env:"PAGINATION_DEFAULT_LIMIT" usage:"page size when limit is omitted"`
+	MaxLimit     int `yaml:"max_limit" env:"PAGINATION_MAX_LIMIT" usage:"largest page size a client may request"`
+}
+
+// LoggingConfig holds log output settings
+type LoggingConfig struct {
+	Level              string        `yaml:"level" env:"LOG_LEVEL" usage:"debug, info, warn or error"`
+	Format             string        `yaml:"format" env:"LOG_FORMAT" usage:"text or json"`
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"LOG_SLOW_QUERY_THRESHOLD" usage:"queries slower than this are logged as warnings (0 disables)"`
+}
+
+// AuthConfig holds API key authentication settings
+type AuthConfig struct {
+	Enabled bool     `yaml:"enabled" env:"AUTH_ENABLED" usage:"require an API key for write requests"`
+	Header  string   `yaml:"header" env:"AUTH_HEADER" usage:"request header carrying the API key"`
+	APIKeys []string `yaml:"api_keys" env:"AUTH_API_KEYS" usage:"comma-separated name:key pairs"`
+}
+
+// MetricsConfig holds Prometheus endpoint settings
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED" usage:"serve Prometheus metrics"`
+	Path    string `yaml:"path" env:"METRICS_PATH" usage:"path of the Prometheus metrics endpoint"`
+}
+
+// TracingConfig holds OpenTelemetry trace export settings
+type TracingConfig struct {
+	Enabled       bool   `yaml:"enabled" env:"TRACING_ENABLED" usage:"export OpenTelemetry traces"`
+	Exporter      string `yaml:"exporter" env:"TRACING_EXPORTER" usage:"otlp (OTLP over HTTP) or stdout"`
+	Endpoint      string `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"OTLP collector host:port"`
+	Insecure      bool   `yaml:"insecure" env:"TRACING_INSECURE" usage:"send OTLP over plain HTTP"`
+	ServiceName   string `yaml:"service_name" env:"OTEL_SERVICE_NAME" usage:"service.name reported on every span"`
+	SamplePercent int    `yaml:"sample_percent" env:"TRACING_SAMPLE_PERCENT" usage:"percentage of new traces recorded; requests joining a trace follow its decision"`
+}
+
+// OutboxConfig holds transactional outbox relay settings
+type OutboxConfig struct {
+	RelayEnabled    bool          `yaml:"relay_enabled" env:"OUTBOX_RELAY_ENABLED" usage:"deliver outbox messages from this instance (events are recorded either way)"`
+	Sinks           []string      `yaml:"sinks" env:"OUTBOX_SINKS" usage:"comma-separated destinations: stdout, webhook, nats, kafka, subscriptions (queues deliveries for webhook subscriptions)"`
+	PollInterval    time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" usage:"wait between polls once the outbox is drained"`
+	BatchSize       int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" usage:"messages claimed and delivered concurrently per poll"`
+	Lease           time.Duration `yaml:"lease" env:"OUTBOX_LEASE" usage:"how long a claimed message is hidden from other relays before it is retried"`
+	DeliveryTimeout time.Duration `yaml:"delivery_timeout" env:"OUTBOX_DELIVERY_TIMEOUT" usage:"deadline for handing one message to one sink"`
+	MaxAttempts     int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" usage:"delivery attempts before a message is marked failed and waits for a replay"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff" env:"OUTBOX_RETRY_BACKOFF" usage:"wait before the first retry; doubles after each failure"`
+	MaxBackoff      time.Duration `yaml:"max_backoff" env:"OUTBOX_MAX_BACKOFF" usage:"longest wait between retries"`
+	Retention       time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" usage:"how long delivered messages are kept for inspection (0 keeps them forever)"`
+	WebhookURL      string        `yaml:"webhook_url" env:"OUTBOX_WEBHOOK_URL" usage:"URL the webhook sink POSTs each message to"`
+	NATSURL         string        `yaml:"nats_url" env:"OUTBOX_NATS_URL" usage:"NATS server URL for the nats sink"`
+	NATSSubject     string        `yaml:"nats_subject" env:"OUTBOX_NATS_SUBJECT" usage:"subject prefix; messages are published to <prefix>.<event name>"`
+	KafkaBrokers    []string      `yaml:"kafka_brokers" env:"OUTBOX_KAFKA_BROKERS" usage:"comma-separated Kafka bootstrap brokers (host:port) for the kafka sink"`
+	KafkaTopic      string        `yaml:"kafka_topic" env:"OUTBOX_KAFKA_TOPIC" usage:"Kafka topic; messages are keyed by aggregate ID"`
+}
+
+// WebhooksConfig holds webhook subscription delivery settings
+type WebhooksConfig struct {
+	DispatcherEnabled bool          `yaml:"dispatcher_enabled" env:"WEBHOOKS_DISPATCHER_ENABLED" usage:"send queued webhook deliveries from this instance"`
+	PollInterval      time.Duration `yaml:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL" usage:"wait between polls once no deliveries are due"`
+	BatchSize         int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" usage:"deliveries claimed and sent concurrently per poll"`
+	Lease             time.Duration `yaml:"lease" env:"WEBHOOKS_LEASE" usage:"how long a claimed delivery is hidden from other dispatchers before it is retried"`
+	Timeout           time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" usage:"deadline for one delivery request, including reading the response"`
+	MaxAttempts       int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" usage:"attempts before a delivery is marked failed"`
+	RetryBackoff      time.Duration `yaml:"retry_backoff" env:"WEBHOOKS_RETRY_BACKOFF" usage:"wait before the first retry; doubles after each failure"`
+	MaxBackoff        time.Duration `yaml:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF" usage:"longest wait between retries"`
+	DisableAfter      int           `yaml:"disable_after" env:"WEBHOOKS_DISABLE_AFTER" usage:"failed attempts in a row after which a webhook is disabled"`
+	Retention         time.Duration `yaml:"retention" env:"WEBHOOKS_RETENTION" usage:"how long completed deliveries and their attempts are kept (0 keeps them forever)"`
+}
+
+// AuditConfig holds audit log settings
+type AuditConfig struct {
+	Retention time.Duration `yaml:"retention" env:"AUDIT_RETENTION" usage:"how long audit entries are kept (0 keeps them forever)"`
+}
+
+// TrashConfig holds settings for soft-deleted records
+type TrashConfig struct {
+	Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION" usage:"how long deleted records stay restorable before they are purged (0 keeps them forever)"`
+}
+
+// DeleteConfig holds the policy for each relationship, deciding what deleting a record does to the records that belong to it
+type DeleteConfig struct {
+	LocationTheatres    string `yaml:"location_theatres" env:"DELETE_LOCATION_THEATRES" usage:"what deleting a location does to its theatres: restrict, cascade or reassign"`
+	TheatreTypeTheatres string `yaml:"theatre_type_theatres" env:"DELETE_THEATRE_TYPE_THEATRES" usage:"what deleting a theatre type does to its theatres: restrict, cascade or reassign"`
+	ShowTypeShows       string `yaml:"show_type_shows" env:"DELETE_SHOW_TYPE_SHOWS" usage:"what deleting a show type does to its shows: restrict, cascade or reassign"`
+	TheatreShows        string `yaml:"theatre_shows" env:"DELETE_THEATRE_SHOWS" usage:"what deleting a theatre does to its shows: restrict, cascade or reassign"`
+}
+
+// ContractConfig holds settings for checking requests and responses against the OpenAPI document
+type ContractConfig struct {
+	Enabled           bool `yaml:"enabled" env:"CONTRACT_ENABLED" usage:"reject requests whose parameters or JSON body don't match the OpenAPI document with 400"`
+	Strict            bool `yaml:"strict" env:"CONTRACT_STRICT" usage:"with contract.enabled, also reject unknown query parameters and JSON fields"`
+	ValidateResponses bool `yaml:"validate_responses" env:"CONTRACT_VALIDATE_RESPONSES" usage:"with contract.enabled, also log responses that don't match the OpenAPI document"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:              8080,
+			RequestTimeout:    time.Duration(constants.DefaultRequestTimeout) * time.Second,
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			ExportTimeout:     10 * time.Minute,
+			IdleTimeout:       120 * time.Second,
+			MaxHeaderBytes:    1 << 20, // 1 MB
+			MaxBodyBytes:      2 << 20, // 2 MB
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			URL:             constants.DefaultDatabaseURL,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectAttempts: 5,
+			ConnectBackoff:  time.Second,
+			StickyWindow:    5 * time.Second,
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{"*"},
+			AllowMethods: []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
+			AllowHeaders: []string{"Origin", "Content-Type", "Authorization"},
+			MaxAge:       12 * time.Hour,
+		},
+		Cache: CacheConfig{
+			DefaultTTL:      time.Duration(constants.CacheDefaultExpiration) * time.Second,
+			CleanupInterval: time.Duration(constants.CacheCleanupInterval) * time.Second,
+		},
+		Pagination: PaginationConfig{
+			DefaultLimit: constants.DefaultLimit,
+			MaxLimit:     constants.MaxLimit,
+		},
+		Logging: LoggingConfig{
+			Level:              constants.LogLevelInfo,
+			Format:             constants.LogFormatJSON,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		Auth: AuthConfig{
+			Header: constants.DefaultAPIKeyHeader,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    constants.DefaultMetricsPath,
+		},
+		Tracing: TracingConfig{
+			Exporter:      constants.TracingExporterOTLP,
+			Endpoint:      "localhost:4318",
+			Insecure:      true,
+			ServiceName:   "theatre-api",
+			SamplePercent: 100,
+		},
+		Outbox: OutboxConfig{
+			RelayEnabled:    true,
+			Sinks:           []string{constants.OutboxSinkStdout, constants.OutboxSinkWebhookSubscriptions},
+			PollInterval:    time.Second,
+			BatchSize:       100,
+			Lease:           time.Minute,
+			DeliveryTimeout: 10 * time.Second,
+			MaxAttempts:     12,
+			RetryBackoff:    time.Second,
+			MaxBackoff:      5 * time.Minute,
+			Retention:       7 * 24 * time.Hour,
+			NATSSubject:     "theatre.events",
+			KafkaTopic:      "theatre-events",
+		},
+		Webhooks: WebhooksConfig{
+			DispatcherEnabled: true,
+			PollInterval:      time.Second,
+			BatchSize:         50,
+			Lease:             time.Minute,
+			Timeout:           10 * time.Second,
+			MaxAttempts:       8,
+			RetryBackoff:      30 * time.Second,
+			MaxBackoff:        time.Hour,
+			DisableAfter:      25,
+			Retention:         30 * 24 * time.Hour,
+		},
+		Audit: AuditConfig{
+			Retention: 365 * 24 * time.Hour,
+		},
+		Trash: TrashConfig{
+			Retention: 30 * 24 * time.Hour,
+		},
+		Delete: DeleteConfig{
+			LocationTheatres:    constants.DeletePolicyRestrict,
+			TheatreTypeTheatres: constants.DeletePolicyRestrict,
+			ShowTypeShows:       constants.DeletePolicyRestrict,
+			TheatreShows:        constants.DeletePolicyRestrict,
+		},
+		Contract: ContractConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// Validate checks every setting and reports all problems at once
+func (c *Config) Validate() error {
+	var problems []string
+	fail := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.RequestTimeout <= 0 {
+		fail("server.request_timeout must be positive")
+	}
+	if c.Server.ReadTimeout <= 0 || c.Server.ReadHeaderTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0 {
+		fail("server.read_timeout, read_header_timeout, write_timeout and idle_timeout must be positive")
+	}
+	if c.Server.ReadHeaderTimeout > c.Server.ReadTimeout {
+		fail("server.read_header_timeout (%s) cannot exceed server.read_timeout (%s)", c.Server.ReadHeaderTimeout, c.Server.ReadTimeout)
+	}
+	if c.Server.WriteTimeout <= c.Server.RequestTimeout {
+		fail("server.write_timeout (%s) must exceed server.request_timeout (%s) so timed-out requests can still answer", c.Server.WriteTimeout, c.Server.RequestTimeout)
+	}
+	if c.Server.ExportTimeout < c.Server.RequestTimeout {
+		fail("server.export_timeout (%s) cannot be shorter than server.request_timeout (%s)", c.Server.ExportTimeout, c.Server.RequestTimeout)
+	}
+	if c.Server.MaxHeaderBytes <= 0 || c.Server.MaxBodyBytes <= 0 {
+		fail("server.max_header_bytes and server.max_body_bytes must be positive")
+	}
+	if c.Server.DrainDelay < 0 || c.Server.ShutdownTimeout <= 0 {
+		fail("server.drain_delay cannot be negative and server.shutdown_timeout must be positive")
+	}
+
+	if c.Database.URL == "" {
+		fail("database.url is required")
+	} else if u, err := url.Parse(c.Database.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		fail("database.url must be a postgres:// URL")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		fail("database.max_open_conns and database.max_idle_conns cannot be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.max_idle_conns (%d) cannot exceed database.max_open_conns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 {
+		fail("database.conn_max_lifetime and database.conn_max_idle_time cannot be negative")
+	}
+	if c.Database.ConnectAttempts < 1 {
+		fail("database.connect_attempts must be at least 1")
+	}
+	if c.Database.ConnectBackoff <= 0 || c.Database.ConnectBackoff > constants.DBConnectMaxBackoff {
+		fail("database.connect_backoff must be between 0 and %s", constants.DBConnectMaxBackoff)
+	}
+	if c.Database.ReplicaURL != "" {
+		if u, err := url.Parse(c.Database.ReplicaURL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			fail("database.replica_url must be a postgres:// URL")
+		}
+	}
+	if c.Database.StickyWindow < 0 {
+		fail("database.sticky_window cannot be negative")
+	}
+
+	if len(c.CORS.AllowOrigins) == 0 {
+		fail("cors.allow_origins needs at least one origin")
+	}
+	for _, origin := range c.CORS.AllowOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				fail(`cors.allow_credentials cannot be combined with cors.allow_origins "*"; list the trusted origins instead`)
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			fail("cors.allow_origins entry %q must be * or a scheme://host[:port] origin", origin)
+		}
+	}
+	if len(c.CORS.AllowMethods) == 0 {
+		fail("cors.allow_methods needs at least one method")
+	}
+	if c.CORS.MaxAge < 0 {
+		fail("cors.max_age cannot be negative")
+	}
+
+	if c.Cache.DefaultTTL <= 0 || c.Cache.CleanupInterval <= 0 {
+		fail("cache.default_ttl and cache.cleanup_interval must be positive")
+	}
+
+	if c.Pagination.MaxLimit < 1 || c.Pagination.MaxLimit > constants.PaginationHardLimit {
+		fail("pagination.max_limit must be between 1 and %d, got %d", constants.PaginationHardLimit, c.Pagination.MaxLimit)
+	}
+	if c.Pagination.DefaultLimit < 1 || c.Pagination.DefaultLimit > c.Pagination.MaxLimit {
+		fail("pagination.default_limit must be between 1 and pagination.max_limit (%d), got %d", c.Pagination.MaxLimit, c.Pagination.DefaultLimit)
+	}
+
+	switch c.Logging.Level {
+	case constants.LogLevelDebug, constants.LogLevelInfo, constants.LogLevelWarn, constants.LogLevelError:
+	default:
+		fail("logging.level must be debug, info, warn or error, got %q", c.Logging.Level)
+	}
+	switch c.Logging.Format {
+	case constants.LogFormatText, constants.LogFormatJSON:
+	default:
+		fail("logging.format must be text or json, got %q", c.Logging.Format)
+	}
+	if c.Logging.SlowQueryThreshold < 0 {
+		fail("logging.slow_query_threshold cannot be negative")
+	}
+
+	if c.Auth.Header == "" {
+		fail("auth.header is required")
+	}
+	if c.Auth.Enabled && len(c.Auth.APIKeys) == 0 {
+		fail("auth.api_keys needs at least one name:key pair when auth is enabled")
+	}
+	if _, err := c.Auth.Keys(); err != nil {
+		fail("%s", err)
+	}
+
+	if c.Metrics.Enabled && (!strings.HasPrefix(c.Metrics.Path, "/") || strings.HasPrefix(c.Metrics.Path, "/api/")) {
+		fail("metrics.path must start with / and lie outside /api/, got %q", c.Metrics.Path)
+	}
+
+	switch c.Tracing.Exporter {
+	case constants.TracingExporterOTLP:
+		if c.Tracing.Endpoint == "" {
+			fail("tracing.endpoint is required for the otlp exporter")
+		}
+	case constants.TracingExporterStdout:
+	default:
+		fail("tracing.exporter must be otlp or stdout, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.ServiceName == "" {
+		fail("tracing.service_name is required")
+	}
+	if c.Tracing.SamplePercent < 0 || c.Tracing.SamplePercent > 100 {
+		fail("tracing.sample_percent must be between 0 and 100, got %d", c.Tracing.SamplePercent)
+	}
+
+	// Only the relay uses the sinks; events are recorded in the outbox either way
+	if c.Outbox.RelayEnabled {
+		if len(c.Outbox.Sinks) == 0 {
+			fail("outbox.sinks needs at least one sink when the relay is enabled")
+		}
+		seen := map[string]bool{}
+		for _, sink := range c.Outbox.Sinks {
+			if seen[sink] {
+				fail("outbox.sinks lists %q twice", sink)
+			}
+			seen[sink] = true
+
+			switch sink {
+			case constants.OutboxSinkStdout, constants.OutboxSinkWebhookSubscriptions:
+			case constants.OutboxSinkWebhook:
+				if u, err := url.Parse(c.Outbox.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					fail("outbox.webhook_url must be an http:// or https:// URL for the webhook sink")
+				}
+			case constants.OutboxSinkNATS:
+				if u, err := url.Parse(c.Outbox.NATSURL); err != nil || u.Host == "" {
+					fail("outbox.nats_url must be a URL such as nats://localhost:4222 for the nats sink")
+				}
+				if c.Outbox.NATSSubject == "" {
+					fail("outbox.nats_subject is required for the nats sink")
+				}
+			case constants.OutboxSinkKafka:
+				if len(c.Outbox.KafkaBrokers) == 0 {
+					fail("outbox.kafka_brokers needs at least one broker for the kafka sink")
+				}
+				if c.Outbox.KafkaTopic == "" {
+					fail("outbox.kafka_topic is required for the kafka sink")
+				}
+			default:
+				fail("outbox.sinks entries must be stdout, webhook, nats, kafka or subscriptions, got %q", sink)
+			}
+		}
+
+		if c.Outbox.PollInterval <= 0 || c.Outbox.DeliveryTimeout <= 0 || c.Outbox.RetryBackoff <= 0 {
+			fail("outbox.poll_interval, outbox.delivery_timeout and outbox.retry_backoff must be positive")
+		}
+		if c.Outbox.BatchSize < 1 || c.Outbox.BatchSize > constants.OutboxMaxBatchSize {
+			fail("outbox.batch_size must be between 1 and %d, got %d", constants.OutboxMaxBatchSize, c.Outbox.BatchSize)
+		}
+		if c.Outbox.MaxAttempts < 1 {
+			fail("outbox.max_attempts must be at least 1")
+		}
+		if c.Outbox.MaxBackoff < c.Outbox.RetryBackoff {
+			fail("outbox.max_backoff (%s) cannot be less than outbox.retry_backoff (%s)", c.Outbox.MaxBackoff, c.Outbox.RetryBackoff)
+		}
+		// A lease that ends mid-delivery lets another relay send the message again
+		if worst := c.Outbox.DeliveryTimeout * time.Duration(len(c.Outbox.Sinks)); c.Outbox.Lease <= worst {
+			fail("outbox.lease (%s) must exceed outbox.delivery_timeout times the number of sinks (%s)", c.Outbox.Lease, worst)
+		}
+		if c.Outbox.Retention < 0 {
+			fail("outbox.retention cannot be negative")
+		}
+	}
+
+	if c.Webhooks.DispatcherEnabled {
+		if c.Webhooks.PollInterval <= 0 || c.Webhooks.Timeout <= 0 || c.Webhooks.RetryBackoff <= 0 {
+			fail("webhooks.poll_interval, webhooks.timeout and webhooks.retry_backoff must be positive")
+		}
+		if c.Webhooks.BatchSize < 1 || c.Webhooks.BatchSize > constants.OutboxMaxBatchSize {
+			fail("webhooks.batch_size must be between 1 and %d, got %d", constants.OutboxMaxBatchSize, c.Webhooks.BatchSize)
+		}
+		if c.Webhooks.MaxAttempts < 1 {
+			fail("webhooks.max_attempts must be at least 1")
+		}
+		if c.Webhooks.MaxBackoff < c.Webhooks.RetryBackoff {
+			fail("webhooks.max_backoff (%s) cannot be less than webhooks.retry_backoff (%s)", c.Webhooks.MaxBackoff, c.Webhooks.RetryBackoff)
+		}
+		// A request still in flight when its lease runs out would be sent twice
+		if c.Webhooks.Lease <= c.Webhooks.Timeout {
+			fail("webhooks.lease (%s) must exceed webhooks.timeout (%s)", c.Webhooks.Lease, c.Webhooks.Timeout)
+		}
+		if c.Webhooks.Retention < 0 {
+			fail("webhooks.retention cannot be negative")
+		}
+	}
+	if c.Webhooks.DisableAfter < 1 {
+		fail("webhooks.disable_after must be at least 1")
+	}
+	if c.Audit.Retention < 0 {
+		fail("audit.retention cannot be negative")
+	}
+	if c.Trash.Retention < 0 {
+		fail("trash.retention cannot be negative")
+	}
+	for _, policy := range []struct{ name, value string }{
+		{"delete.location_theatres", c.Delete.LocationTheatres},
+		{"delete.theatre_type_theatres", c.Delete.TheatreTypeTheatres},
+		{"delete.show_type_shows", c.Delete.ShowTypeShows},
+		{"delete.theatre_shows", c.Delete.TheatreShows},
+	} {
+		switch policy.value {
+		case constants.DeletePolicyRestrict, constants.DeletePolicyCascade, constants.DeletePolicyReassign:
+		default:
+			fail("%s must be restrict, cascade or reassign, got %q", policy.name, policy.value)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// Keys maps each API key to the name of its holder
+func (a AuthConfig) Keys() (map[string]string, error) {
+	keys := make(map[string]string, len(a.APIKeys))
+	for _, pair := range a.APIKeys {
+		name, key, ok := strings.Cut(pair, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, fmt.Errorf("auth.api_keys entries must look like name:key")
+		}
+		if len(key) < constants.MinAPIKeyLength {
+			return nil, fmt.Errorf("auth.api_keys key for %q must be at least %d characters", name, constants.MinAPIKeyLength)
+		}
+		if _, exists := keys[key]; exists {
+			return nil, fmt.Errorf("auth.api_keys key for %q is already assigned", name)
+		}
+		keys[key] = name
+	}
+	return keys, nil
+}
+
+// CORSAllowHeaders returns cors.allow_headers plus the API key and request ID headers, which browsers
+// may only send cross-origin when the preflight allows them
+func (c *Config) CORSAllowHeaders() []string {
+	headers := append([]string(nil), c.CORS.AllowHeaders...)
+	for _, required := range []string{c.Auth.Header, constants.HeaderRequestID} {
+		if !containsFold(headers, required) {
+			headers = append(headers, required)
+		}
+	}
+	return headers
+}
+
+// containsFold reports whether items holds value, ignoring case as HTTP header names do
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy safe to print, with passwords and API keys masked
+func (c Config) Redacted() Config {
+	if u, err := url.Parse(c.Database.URL); err == nil {
+		c.Database.URL = u.Redacted()
+	}
+	if u, err := url.Parse(c.Database.ReplicaURL); err == nil && c.Database.ReplicaURL != "" {
+		c.Database.ReplicaURL = u.Redacted()
+	}
+	if u, err := url.Parse(c.Outbox.WebhookURL); err == nil && c.Outbox.WebhookURL != "" {
+		c.Outbox.WebhookURL = u.Redacted()
+	}
+	if u, err := url.Parse(c.Outbox.NATSURL); err == nil && c.Outbox.NATSURL != "" {
+		c.Outbox.NATSURL = u.Redacted()
+	}
+
+	keys := make([]string, len(c.Auth.APIKeys))
+	for i, pair := range c.Auth.APIKeys {
+		name, _, _ := strings.Cut(pair, ":")
+		keys[i] = name + ":" + constants.RedactedValue
+	}
+	c.Auth.APIKeys = keys
+
+	return c
+}
+
+// Print writes the redacted configuration as YAML
+func (c Config) Print(out io.Writer) error {
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options are the command-line settings that steer loading rather than configure the application
+type Options struct {
+	File        string   // YAML file the configuration was read from, if any
+	PrintConfig bool     // print the effective configuration and exit
+	Args        []string // arguments left after the flags, such as a subcommand
+}
+
+// setting is one leaf field of the configuration
+type setting struct {
+	name  string // dotted YAML path, also the flag name
+	env   string
+	usage string
+	value reflect.Value
+}
+
+// Load builds the configuration from defaults, then the YAML file, then environment variables,
+// then flags, each overriding the last, and validates the result
+func Load(args []string) (*Config, Options, error) {
+	cfg := Default()
+	settings := collectSettings(reflect.ValueOf(cfg).Elem(), "")
+
+	var options Options
+	flags := flag.NewFlagSet("theatre-management-system", flag.ContinueOnError)
+	flags.StringVar(&options.File, "config", os.Getenv("CONFIG_FILE"), "YAML configuration file (env CONFIG_FILE)")
+	flags.BoolVar(&options.PrintConfig, "print-config", false, "print the effective configuration, with secrets redacted, and exit")
+
+	// Flag values are applied last, so only record them while parsing
+	overrides := make(map[string]string)
+	for _, s := range settings {
+		name := s.name
+		record := func(value string) error {
+			overrides[name] = value
+			return nil
+		}
+		if s.value.Kind() == reflect.Bool {
+			flags.BoolFunc(name, fmt.Sprintf("%s (env %s)", s.usage, s.env), record)
+		} else {
+			flags.Func(name, fmt.Sprintf("%s (env %s)", s.usage, s.env), record)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, options, err
+	}
+	options.Args = flags.Args()
+
+	if options.File != "" {
+		if err := loadFile(cfg, options.File); err != nil {
+			return nil, options, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := setValue(s.value, value); err != nil {
+				return nil, options, fmt.Errorf("environment variable %s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := overrides[s.name]; ok {
+			if err := setValue(s.value, value); err != nil {
+				return nil, options, fmt.Errorf("flag -%s: %w", s.name, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, options, err
+	}
+	return cfg, options, nil
+}
+
+// loadFile overlays a YAML file onto the configuration, rejecting unknown keys
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// collectSettings walks the configuration struct and returns its leaf fields
+func collectSettings(v reflect.Value, prefix string) []setting {
+	var settings []setting
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := prefix + field.Tag.Get("yaml")
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			settings = append(settings, collectSettings(v.Field(i), name+".")...)
+			continue
+		}
+
+		settings = append(settings, setting{
+			name:  name,
+			env:   field.Tag.Get("env"),
+			usage: field.Tag.Get("usage"),
+			value: v.Field(i),
+		})
+	}
+	return settings
+}
+
+// setValue parses text into a configuration field
+func setValue(v reflect.Value, text string) error {
+	switch v.Interface().(type) {
+	case time.Duration:
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", text)
+		}
+		v.SetInt(int64(duration))
+	case string:
+		v.SetString(text)
+	case int:
+		number, err := strconv.Atoi(text)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", text)
+		}
+		v.SetInt(int64(number))
+	case bool:
+		enabled, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", text)
+		}
+		v.SetBool(enabled)
+	case []string:
+		var items []string
+		for _, item := range strings.Split(text, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditController handles HTTP requests for the audit log
+type AuditController struct {
+	auditService interfaces.AuditService
+}
+
+// NewAuditController creates a new audit controller
+func NewAuditController(auditService interfaces.AuditService) *AuditController {
+	return &AuditController{
+		auditService: auditService,
+	}
+}
+
+// ListEntries handles GET /audit, filtered with ?entity=&id=&action=&actor=&from=&to=
+func (ctrl *AuditController) ListEntries(c *gin.Context) {
+	params := GetPaginationParams(c)
+	query := dto.AuditQuery{
+		Entity: c.Query("entity"),
+		ID:     c.Query("id"),
+		Action: c.Query("action"),
+		Actor:  c.Query("actor"),
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+	}
+
+	entries, err := ctrl.auditService.ListEntries(c.Request.Context(), query, params.Limit, params.Offset)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), constants.ErrorAuditInvalidQuery) {
+			BadRequestResponse(c, constants.ErrorAuditInvalidQuery, err)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, entries)
+}
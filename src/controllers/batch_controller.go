@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BatchController handles HTTP requests for batched operations
+type BatchController struct {
+	batchService interfaces.BatchService
+}
+
+// NewBatchController creates a new batch controller
+func NewBatchController(batchService interfaces.BatchService) *BatchController {
+	return &BatchController{
+		batchService: batchService,
+	}
+}
+
+// ExecuteBatch returns the handler for POST /{resource}/batch
+func (ctrl *BatchController) ExecuteBatch(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		atomic, err := queryBool(c, "atomic", true)
+		if err != nil {
+			BadRequestResponse(c, constants.ErrorInvalidInput, err)
+			return
+		}
+
+		var operations []dto.BatchOperation
+		if err := c.ShouldBindJSON(&operations); err != nil {
+			BadRequestResponse(c, constants.ErrorInvalidInput, err)
+			return
+		}
+
+		result, err := ctrl.batchService.ExecuteBatch(c.Request.Context(), resource, operations, atomic)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), constants.ErrorBatchInvalid) {
+				BadRequestResponse(c, constants.ErrorBatchInvalid, err)
+				return
+			}
+			InternalServerErrorResponse(c, err)
+			return
+		}
+
+		// Multi-Status tells clients to inspect each result
+		if result.Failed > 0 {
+			SuccessResponse(c, http.StatusMultiStatus, constants.MessageBatchFailed, result)
+			return
+		}
+
+		SuccessResponse(c, http.StatusOK, constants.MessageBatchCompleted, result)
+	}
+}
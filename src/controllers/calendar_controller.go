@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CalendarController handles HTTP requests for iCalendar feeds
+type CalendarController struct {
+	calendarService interfaces.CalendarService
+}
+
+// NewCalendarController creates a new calendar controller
+func NewCalendarController(calendarService interfaces.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// GetTheatreCalendar handles GET /theatres/:id/calendar.ics
+func (ctrl *CalendarController) GetTheatreCalendar(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	var showTypeID *uuid.UUID
+	if typeParam := c.Query("show_type_id"); typeParam != "" {
+		typeID, err := uuid.Parse(typeParam)
+		if err != nil {
+			BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+			return
+		}
+		showTypeID = &typeID
+	}
+
+	calendar, err := ctrl.calendarService.GetTheatreCalendar(c.Request.Context(), id, showTypeID)
+	if err != nil {
+		if err.Error() == constants.ErrorTheatreNotFound {
+			NotFoundResponse(c, constants.ErrorTheatreNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	CalendarResponse(c, "theatre-"+id.String()+".ics", calendar)
+}
+
+// GetShowCalendar handles GET /shows/:id/calendar.ics
+func (ctrl *CalendarController) GetShowCalendar(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	calendar, err := ctrl.calendarService.GetShowCalendar(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorShowNotFound {
+			NotFoundResponse(c, constants.ErrorShowNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	CalendarResponse(c, "show-"+id.String()+".ics", calendar)
+}
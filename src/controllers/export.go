@@ -0,0 +1,381 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// exportColumn describes one flattened column of an export row
+type exportColumn struct {
+	header string
+	index  []int // field index path from the row struct, through nested relationship structs
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// ListPage fetches up to limit rows of a list, skipping offset, in the list's order
+type ListPage func(limit, offset int) (interface{}, error)
+
+// ListResponse sends a list its service loads whole as the standard JSON envelope, or as CSV/XLSX when
+// ?format= asks for it
+func ListResponse(c *gin.Context, name string, items interface{}) {
+	format, ok := listFormat(c)
+	if !ok {
+		return
+	}
+	if format == constants.ExportFormatJSON {
+		SuccessResponse(c, http.StatusOK, constants.StatusOK, items)
+		return
+	}
+
+	// The whole list is the first and only batch
+	exportResponse(c, name, format, reflect.ValueOf(items), func() (reflect.Value, error) {
+		return reflect.Value{}, nil
+	})
+}
+
+// PagedListResponse sends one page of a paginated list as the standard JSON envelope, capped at the
+// configured maximum page size. For ?format=csv|xlsx it exports every row from offset on, or limit rows
+// when given, fetching constants.ExportBatchSize rows at a time and writing each batch as it arrives. An
+// error fetching the first page or batch is returned for the caller to respond with, since nothing has
+// been sent yet; a later one aborts the connection mid-CSV. Exports run under server.export_timeout rather
+// than the request timeout
+func PagedListResponse(c *gin.Context, name string, page ListPage) error {
+	format, ok := listFormat(c)
+	if !ok {
+		return nil
+	}
+	if format == constants.ExportFormatJSON {
+		params := GetPaginationParams(c)
+		items, err := page(params.Limit, params.Offset)
+		if err != nil {
+			return err
+		}
+		SuccessResponse(c, http.StatusOK, constants.StatusOK, items)
+		return nil
+	}
+
+	offset, remaining := exportRange(c)
+	finished := false
+	next := func() (reflect.Value, error) {
+		size := constants.ExportBatchSize
+		if remaining >= 0 {
+			size = min(size, remaining)
+		}
+		if finished || size == 0 {
+			return reflect.Value{}, nil
+		}
+
+		items, err := page(size, offset)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rows := reflect.ValueOf(items)
+		offset += rows.Len()
+		if remaining >= 0 {
+			remaining -= rows.Len()
+		}
+		finished = rows.Len() < size
+		return rows, nil
+	}
+
+	first, err := next()
+	if err != nil {
+		return err
+	}
+	exportResponse(c, name, format, first, next)
+	return nil
+}
+
+// isExport reports whether ?format= asks for a CSV or XLSX export
+func isExport(c *gin.Context) bool {
+	format := strings.ToLower(c.Query("format"))
+	return format == constants.ExportFormatCSV || format == constants.ExportFormatXLSX
+}
+
+// listFormat returns the representation ?format= asks for, responding with 400 when it is unknown
+func listFormat(c *gin.Context) (string, bool) {
+	switch format := strings.ToLower(c.Query("format")); format {
+	case "", constants.ExportFormatJSON:
+		return constants.ExportFormatJSON, true
+	case constants.ExportFormatCSV, constants.ExportFormatXLSX:
+		return format, true
+	}
+	BadRequestResponse(c, constants.ErrorUnsupportedFormat, fmt.Errorf("format must be one of json, csv, xlsx"))
+	return "", false
+}
+
+// exportRange reads where an export starts and how many rows it may hold, -1 for all of them. Unlike JSON
+// pages, exports aren't capped by the configured maximum page size
+func exportRange(c *gin.Context) (offset, limit int) {
+	offset, err := strconv.Atoi(c.Query("offset"))
+	if err != nil || offset < 0 {
+		offset = constants.DefaultOffset
+	}
+	limit, err = strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = -1
+	}
+	return offset, limit
+}
+
+// exportResponse writes first and every batch next returns after it, until an empty one, as a spreadsheet
+func exportResponse(c *gin.Context, name, format string, first reflect.Value, next func() (reflect.Value, error)) {
+	columns := exportColumns(rowType(first.Type()), "", nil)
+	if format == constants.ExportFormatCSV {
+		csvResponse(c, name, columns, first, next)
+	} else {
+		xlsxResponse(c, name, columns, first, next)
+	}
+}
+
+// csvResponse streams batches to the client, flushing each one as soon as it is encoded
+func csvResponse(c *gin.Context, name string, columns []exportColumn, rows reflect.Value, next func() (reflect.Value, error)) {
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+	c.Header("Content-Type", constants.CSVContentType)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	record := make([]string, len(columns))
+
+	for i, column := range columns {
+		record[i] = column.header
+	}
+	if err := w.Write(record); err != nil {
+		c.Error(err)
+		return
+	}
+
+	for rows.IsValid() && rows.Len() > 0 {
+		for i := 0; i < rows.Len(); i++ {
+			row := reflect.Indirect(rows.Index(i))
+			for j, column := range columns {
+				record[j] = csvCell(exportValue(row, column.index))
+			}
+			if err := w.Write(record); err != nil {
+				c.Error(err)
+				return
+			}
+
+			// Push completed rows to the client rather than holding a large batch
+			if (i+1)%constants.ExportFlushInterval == 0 {
+				w.Flush()
+				c.Writer.Flush()
+			}
+		}
+		w.Flush()
+		c.Writer.Flush()
+
+		// The status and earlier rows are already sent, so only dropping the connection tells the client
+		// the file is incomplete
+		var err error
+		if rows, err = next(); err != nil {
+			c.Error(err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	if err := w.Error(); err != nil {
+		c.Error(err)
+	}
+}
+
+// xlsxResponse writes batches through excelize's stream writer, which spills rows to a temporary file
+// rather than memory, and sends the workbook once complete: an XLSX file is a ZIP archive, and excelize
+// can only write the archive whole
+func xlsxResponse(c *gin.Context, name string, columns []exportColumn, rows reflect.Value, next func() (reflect.Value, error)) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	record := make([]interface{}, len(columns))
+	line := 2
+	for rows.IsValid() && rows.Len() > 0 {
+		for i := 0; i < rows.Len(); i++ {
+			row := reflect.Indirect(rows.Index(i))
+			for j, column := range columns {
+				record[j] = xlsxCellValue(exportValue(row, column.index))
+			}
+
+			cell, err := excelize.CoordinatesToCellName(1, line)
+			if err != nil {
+				InternalServerErrorResponse(c, err)
+				return
+			}
+			if err := stream.SetRow(cell, record); err != nil {
+				InternalServerErrorResponse(c, err)
+				return
+			}
+			line++
+		}
+
+		if rows, err = next(); err != nil {
+			InternalServerErrorResponse(c, err)
+			return
+		}
+	}
+
+	if err := stream.Flush(); err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.xlsx"`)
+	c.Header("Content-Type", constants.XLSXContentType)
+	c.Status(http.StatusOK)
+	if err := file.Write(c.Writer); err != nil {
+		c.Error(err)
+	}
+}
+
+// rowType returns the struct type of a list's elements
+func rowType(listType reflect.Type) reflect.Type {
+	t := listType.Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// exportColumns flattens a DTO into columns named by JSON tag, e.g. theatre.location.city.
+// Nested relationship slices are skipped since they don't fit in a single row.
+func exportColumns(t reflect.Type, prefix string, index []int) []exportColumn {
+	var columns []exportColumn
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map:
+			continue
+		case fieldType.Kind() == reflect.Struct && fieldType != timeType:
+			columns = append(columns, exportColumns(fieldType, prefix+name+".", fieldIndex)...)
+		default:
+			columns = append(columns, exportColumn{header: prefix + name, index: fieldIndex})
+		}
+	}
+
+	return columns
+}
+
+// exportValue follows a field index path, returning an invalid value when a pointer on the way is nil
+func exportValue(row reflect.Value, index []int) reflect.Value {
+	value := row
+	for _, i := range index {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}
+			}
+			value = value.Elem()
+		}
+		value = value.Field(i)
+	}
+
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+
+	return value
+}
+
+// formatExportValue renders a field value as CSV text
+func formatExportValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(time.RFC3339)
+	}
+
+	if value.Type().Implements(stringerType) {
+		return value.Interface().(fmt.Stringer).String()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+// csvCell renders a field value as CSV text, quoting text that spreadsheets would otherwise run as a formula
+func csvCell(value reflect.Value) string {
+	text := formatExportValue(value)
+	if value.IsValid() && value.Kind() == reflect.String && text != "" && strings.ContainsRune(constants.CSVFormulaPrefixes, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// xlsxCellValue keeps numbers and booleans typed so spreadsheets can sort and sum them
+func xlsxCellValue(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	default:
+		return formatExportValue(value)
+	}
+}
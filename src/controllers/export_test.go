@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type exportTestLocation struct {
+	City     string   `json:"city"`
+	Latitude *float64 `json:"latitude"`
+}
+
+type exportTestTheatre struct {
+	Name     string             `json:"name"`
+	Location exportTestLocation `json:"location"`
+}
+
+type exportTestRow struct {
+	ID        uuid.UUID          `json:"id"`
+	Title     string             `json:"title,omitempty"`
+	StartDate *time.Time         `json:"start_date"`
+	Theatre   *exportTestTheatre `json:"theatre"`
+	Shows     []string           `json:"shows"`
+	Secret    string             `json:"-"`
+	Untagged  int
+	internal  int
+}
+
+// exportTestContext builds a gin context for a GET of target, recording the response
+func exportTestContext(target string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c, recorder
+}
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "plain text", value: "Hamilton", want: "Hamilton"},
+		{name: "empty", value: "", want: ""},
+		{name: "formula", value: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{name: "plus", value: "+1 555 0100", want: "'+1 555 0100"},
+		{name: "minus", value: "-2+3", want: "'-2+3"},
+		{name: "at", value: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{name: "tab", value: "\t=1+1", want: "'\t=1+1"},
+		{name: "carriage return", value: "\r=1+1", want: "'\r=1+1"},
+		{name: "formula character later on", value: "Tom & Jerry = fun", want: "Tom & Jerry = fun"},
+		{name: "negative number stays a number", value: -5, want: "-5"},
+		{name: "negative float", value: -1.5, want: "-1.5"},
+		{name: "bool", value: true, want: "true"},
+		{name: "missing", value: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvCell(reflect.ValueOf(tt.value)); got != tt.want {
+				t.Errorf("csvCell(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportColumns(t *testing.T) {
+	columns := exportColumns(rowType(reflect.TypeOf([]*exportTestRow{})), "", nil)
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	want := []string{"id", "title", "start_date", "theatre.name", "theatre.location.city", "theatre.location.latitude", "Untagged"}
+	if strings.Join(headers, ",") != strings.Join(want, ",") {
+		t.Errorf("headers = %v, want %v", headers, want)
+	}
+
+	latitude := 40.76
+	rows := []exportTestRow{
+		{Title: "Hamilton", Theatre: &exportTestTheatre{Name: "Majestic", Location: exportTestLocation{City: "New York", Latitude: &latitude}}},
+		{Title: "Touring"},
+	}
+	for i, wantCells := range [][]string{
+		{"00000000-0000-0000-0000-000000000000", "Hamilton", "", "Majestic", "New York", "40.76", "0"},
+		{"00000000-0000-0000-0000-000000000000", "Touring", "", "", "", "", "0"},
+	} {
+		for j, column := range columns {
+			if got := formatExportValue(exportValue(reflect.ValueOf(rows[i]), column.index)); got != wantCells[j] {
+				t.Errorf("row %d %s = %q, want %q", i, column.header, got, wantCells[j])
+			}
+		}
+	}
+}
+
+func TestListResponseCSV(t *testing.T) {
+	c, recorder := exportTestContext("/shows?format=CSV")
+	ListResponse(c, "shows", []exportTestRow{{Title: "=cmd|' /C calc'!A0"}})
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if got := recorder.Header().Get("Content-Disposition"); got != `attachment; filename="shows.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	want := "id,title,start_date,theatre.name,theatre.location.city,theatre.location.latitude,Untagged\n" +
+		"00000000-0000-0000-0000-000000000000,'=cmd|' /C calc'!A0,,,,,0\n"
+	if recorder.Body.String() != want {
+		t.Errorf("body =\n%s\nwant\n%s", recorder.Body.String(), want)
+	}
+}
+
+func TestPagedListResponseCSVAbortsWhenALaterBatchFails(t *testing.T) {
+	c, recorder := exportTestContext("/shows?format=csv")
+	errUnavailable := errors.New("unavailable")
+	calls := 0
+	page := func(limit, offset int) (interface{}, error) {
+		calls++
+		if calls > 1 {
+			return nil, errUnavailable
+		}
+		return make([]exportTestRow, limit), nil
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler", recovered)
+		}
+		if recorder.Code != http.StatusOK || recorder.Body.Len() == 0 {
+			t.Errorf("expected the first batch to be sent before the abort")
+		}
+		if len(c.Errors) != 1 || !errors.Is(c.Errors[0].Err, errUnavailable) {
+			t.Errorf("errors = %v, want the failed batch's", c.Errors)
+		}
+	}()
+	PagedListResponse(c, "shows", page)
+	t.Fatal("PagedListResponse returned after a later batch failed")
+}
+
+func TestPagedListResponseReturnsFirstBatchError(t *testing.T) {
+	c, recorder := exportTestContext("/shows?format=csv")
+	errUnavailable := errors.New("unavailable")
+
+	err := PagedListResponse(c, "shows", func(int, int) (interface{}, error) { return nil, errUnavailable })
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("PagedListResponse returned %v, want %v", err, errUnavailable)
+	}
+	if recorder.Body.Len() != 0 {
+		t.Errorf("wrote %q before the first batch arrived", recorder.Body.String())
+	}
+}
+
+func TestRequestTimeoutGivesExportsTheirOwnDeadline(t *testing.T) {
+	tests := []struct {
+		target string
+		want   time.Duration
+	}{
+		{target: "/shows", want: time.Second},
+		{target: "/shows?format=json", want: time.Second},
+		{target: "/shows?format=csv", want: time.Hour},
+		{target: "/shows?format=XLSX", want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(RequestTimeout(time.Second, time.Hour, time.Minute))
+
+			var remaining time.Duration
+			router.GET("/shows", func(c *gin.Context) {
+				deadline, _ := c.Request.Context().Deadline()
+				remaining = time.Until(deadline)
+			})
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if remaining > tt.want || remaining < tt.want-time.Second/2 {
+				t.Errorf("deadline in %s, want %s", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsExport(t *testing.T) {
+	for target, want := range map[string]bool{
+		"/shows":             false,
+		"/shows?format=json": false,
+		"/shows?format=" + constants.ExportFormatCSV:  true,
+		"/shows?format=" + constants.ExportFormatXLSX: true,
+		"/shows?format=ics":                           false,
+	} {
+		c, _ := exportTestContext(target)
+		if got := isExport(c); got != want {
+			t.Errorf("isExport(%s) = %v, want %v", target, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"sync/atomic"
 	"theatre-management-system/src/business"
 	"theatre-management-system/src/constants"
 	"time"
@@ -14,6 +15,8 @@ import (
 type HealthController struct {
 	db           *gorm.DB
 	cacheService *business.CacheService
+	startedAt    time.Time
+	draining     atomic.Bool
 }
 
 // NewHealthController creates a new health controller
@@ -21,9 +24,15 @@ func NewHealthController(db *gorm.DB, cacheService *business.CacheService) *Heal
 	return &HealthController{
 		db:           db,
 		cacheService: cacheService,
+		startedAt:    time.Now(),
 	}
 }
 
+// StartDraining makes the readiness check fail so load balancers stop routing new requests here
+func (ctrl *HealthController) StartDraining() {
+	ctrl.draining.Store(true)
+}
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status      string            `json:"status"`
@@ -56,7 +65,7 @@ func (ctrl *HealthController) HealthCheck(c *gin.Context) {
 		response.Status = "unhealthy"
 		response.Services["database"] = "error: " + err.Error()
 	} else {
-		err = sqlDB.Ping()
+		err = sqlDB.PingContext(c.Request.Context())
 		if err != nil {
 			response.Status = "unhealthy"
 			response.Services["database"] = "error: " + err.Error()
@@ -89,13 +98,19 @@ func (ctrl *HealthController) ReadinessCheck(c *gin.Context) {
 	ready := true
 	services := make(map[string]string)
 
+	// A server shutting down finishes its in-flight requests but should not be sent new ones
+	if ctrl.draining.Load() {
+		ready = false
+		services["server"] = "draining"
+	}
+
 	// Check database readiness
 	sqlDB, err := ctrl.db.DB()
 	if err != nil {
 		ready = false
 		services["database"] = "not ready: " + err.Error()
 	} else {
-		err = sqlDB.Ping()
+		err = sqlDB.PingContext(c.Request.Context())
 		if err != nil {
 			ready = false
 			services["database"] = "not ready: " + err.Error()
@@ -124,7 +139,7 @@ func (ctrl *HealthController) LivenessCheck(c *gin.Context) {
 	response := map[string]interface{}{
 		"alive":     true,
 		"timestamp": time.Now(),
-		"uptime":    time.Since(time.Now().Add(-time.Hour)), // Placeholder uptime
+		"uptime":    time.Since(ctrl.startedAt).String(),
 	}
 
 	SuccessResponse(c, http.StatusOK, "Liveness check completed", response)
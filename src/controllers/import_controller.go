@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ImportController handles HTTP requests for bulk imports
+type ImportController struct {
+	importService interfaces.ImportService
+}
+
+// NewImportController creates a new import controller
+func NewImportController(importService interfaces.ImportService) *ImportController {
+	return &ImportController{
+		importService: importService,
+	}
+}
+
+// ImportFile handles POST /imports/:entity
+func (ctrl *ImportController) ImportFile(c *gin.Context) {
+	dryRun, err := queryBool(c, "dry_run", false)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidInput, err)
+		return
+	}
+
+	async, err := queryBool(c, "async", false)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidInput, err)
+		return
+	}
+
+	data, filename, err := readImportFile(c)
+	if err != nil {
+		switch err.Error() {
+		case constants.ErrorImportFileTooLarge:
+			ErrorResponse(c, http.StatusRequestEntityTooLarge, constants.ErrorImportFileTooLarge, nil)
+		case constants.ErrorImportFileRequired:
+			BadRequestResponse(c, constants.ErrorImportFileRequired, nil)
+		default:
+			BadRequestResponse(c, constants.ErrorInvalidInput, err)
+		}
+		return
+	}
+
+	options := dto.ImportOptions{
+		Entity: c.Param("entity"),
+		Format: c.Query("format"),
+		Mode:   c.Query("mode"),
+		DryRun: dryRun,
+		Async:  async,
+	}
+	if options.Format == "" {
+		options.Format = detectImportFormat(filename, c.ContentType())
+	}
+
+	job, err := ctrl.importService.StartImport(c.Request.Context(), data, options)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), constants.ErrorImportInvalid) {
+			BadRequestResponse(c, constants.ErrorImportInvalid, err)
+			return
+		}
+		if err.Error() == constants.ErrorTooManyImports {
+			ErrorResponse(c, http.StatusTooManyRequests, constants.ErrorTooManyImports, nil)
+			return
+		}
+		if err.Error() == constants.ErrorShuttingDown {
+			ErrorResponse(c, http.StatusServiceUnavailable, constants.ErrorShuttingDown, nil)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	if job.Status == constants.ImportJobPending || job.Status == constants.ImportJobRunning {
+		c.Header("Location", "/api/v1/imports/jobs/"+job.ID.String())
+		SuccessResponse(c, http.StatusAccepted, constants.MessageImportAccepted, job)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageImportCompleted, job)
+}
+
+// GetImportJob handles GET /imports/jobs/:id
+func (ctrl *ImportController) GetImportJob(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	job, err := ctrl.importService.GetImportJob(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorImportJobNotFound {
+			NotFoundResponse(c, constants.ErrorImportJobNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, job)
+}
+
+// readImportFile reads the upload from a multipart "file" field or, failing that, the raw request body
+func readImportFile(c *gin.Context) ([]byte, string, error) {
+	var (
+		reader   io.Reader = c.Request.Body
+		filename string
+	)
+
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, "", errors.New(constants.ErrorImportFileTooLarge)
+			}
+			return nil, "", errors.New(constants.ErrorImportFileRequired)
+		}
+		if header.Size > constants.ImportMaxFileSize {
+			return nil, "", errors.New(constants.ErrorImportFileTooLarge)
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+
+		reader = file
+		filename = header.Filename
+	}
+
+	// Read one byte past the limit to tell a full-size file from an oversized one
+	data, err := io.ReadAll(io.LimitReader(reader, constants.ImportMaxFileSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > constants.ImportMaxFileSize {
+		return nil, "", errors.New(constants.ErrorImportFileTooLarge)
+	}
+	if len(data) == 0 {
+		return nil, "", errors.New(constants.ErrorImportFileRequired)
+	}
+
+	return data, filename, nil
+}
+
+// detectImportFormat infers the file format from the upload's extension or content type
+func detectImportFormat(filename, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return constants.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return constants.ImportFormatNDJSON
+	}
+
+	switch contentType {
+	case "application/x-ndjson", "application/jsonl":
+		return constants.ImportFormatNDJSON
+	default:
+		return constants.ImportFormatCSV
+	}
+}
+
+// queryBool parses an optional boolean query parameter
+func queryBool(c *gin.Context, name string, fallback bool) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -32,7 +32,7 @@ func (ctrl *LocationController) CreateLocation(c *gin.Context) {
 		return
 	}
 
-	location, err := ctrl.locationService.CreateLocation(&locationDTO)
+	location, err := ctrl.locationService.CreateLocation(c.Request.Context(), &locationDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorValidationFailed {
 			ValidationErrorResponse(c, err)
@@ -54,7 +54,7 @@ func (ctrl *LocationController) GetLocationByID(c *gin.Context) {
 		return
 	}
 
-	location, err := ctrl.locationService.GetLocationByID(id)
+	location, err := ctrl.locationService.GetLocationByID(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == constants.ErrorLocationNotFound {
 			NotFoundResponse(c, constants.ErrorLocationNotFound)
@@ -69,15 +69,12 @@ func (ctrl *LocationController) GetLocationByID(c *gin.Context) {
 
 // GetAllLocations handles GET /locations
 func (ctrl *LocationController) GetAllLocations(c *gin.Context) {
-	params := GetPaginationParams(c)
-
-	locations, err := ctrl.locationService.GetAllLocations(params.Limit, params.Offset)
+	err := PagedListResponse(c, "locations", func(limit, offset int) (interface{}, error) {
+		return ctrl.locationService.GetAllLocations(c.Request.Context(), limit, offset)
+	})
 	if err != nil {
 		InternalServerErrorResponse(c, err)
-		return
 	}
-
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, locations)
 }
 
 // UpdateLocation handles PATCH /locations/:id
@@ -95,7 +92,7 @@ func (ctrl *LocationController) UpdateLocation(c *gin.Context) {
 		return
 	}
 
-	location, err := ctrl.locationService.UpdateLocation(id, &locationDTO)
+	location, err := ctrl.locationService.UpdateLocation(c.Request.Context(), id, &locationDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorLocationNotFound {
 			NotFoundResponse(c, constants.ErrorLocationNotFound)
@@ -112,7 +109,7 @@ func (ctrl *LocationController) UpdateLocation(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, constants.MessageLocationUpdated, location)
 }
 
-// DeleteLocation handles DELETE /locations/:id
+// DeleteLocation handles DELETE /locations/:id?reassign_to=
 func (ctrl *LocationController) DeleteLocation(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -121,13 +118,15 @@ func (ctrl *LocationController) DeleteLocation(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.locationService.DeleteLocation(id)
+	reassignTo, err := queryUUID(c, "reassign_to")
 	if err != nil {
-		if err.Error() == constants.ErrorLocationNotFound {
-			NotFoundResponse(c, constants.ErrorLocationNotFound)
-			return
-		}
-		InternalServerErrorResponse(c, err)
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.locationService.DeleteLocation(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		DeleteErrorResponse(c, constants.ErrorLocationNotFound, err)
 		return
 	}
 
@@ -166,24 +165,24 @@ func (ctrl *LocationController) GetLocationsByCoordinates(c *gin.Context) {
 		}
 	}
 
-	locations, err := ctrl.locationService.GetLocationsByCoordinates(latitude, longitude, radius)
+	locations, err := ctrl.locationService.GetLocationsByCoordinates(c.Request.Context(), latitude, longitude, radius)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, locations)
+	ListResponse(c, "locations", locations)
 }
 
 // GetActiveLocations handles GET /locations/active
 func (ctrl *LocationController) GetActiveLocations(c *gin.Context) {
-	locations, err := ctrl.locationService.GetActiveLocations()
+	locations, err := ctrl.locationService.GetActiveLocations(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, locations)
+	ListResponse(c, "locations", locations)
 }
 
 // SearchLocations handles GET /locations/search
@@ -194,11 +193,11 @@ func (ctrl *LocationController) SearchLocations(c *gin.Context) {
 		return
 	}
 
-	locations, err := ctrl.locationService.SearchLocations(query)
+	locations, err := ctrl.locationService.SearchLocations(c.Request.Context(), query)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, locations)
+	ListResponse(c, "locations", locations)
 }
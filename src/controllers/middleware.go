@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/logging"
+	"theatre-management-system/src/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestTimeout gives every request a deadline; queries still running when it passes, or when the client disconnects, are cancelled.
+// A CSV or XLSX export gets exportTimeout instead, and a write deadline writeMargin after it in place of the server's
+func RequestTimeout(timeout, exportTimeout, writeMargin time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deadline := timeout
+		if isExport(c) {
+			deadline = exportTimeout
+			// Fails only for writers without deadlines, such as test recorders
+			http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(exportTimeout + writeMargin))
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), deadline)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// MaxBodySize caps the request body; set on a route group, it replaces the limit set on the engine
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, ok := c.Get(constants.ContextKeyRawBody)
+		if !ok {
+			body = c.Request.Body
+			c.Set(constants.ContextKeyRawBody, body)
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, body.(io.ReadCloser), limit)
+		c.Next()
+	}
+}
+
+// Pagination makes the configured page sizes available to GetPaginationParams
+func Pagination(defaultLimit, maxLimit int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(constants.ContextKeyPaginationLimit, defaultLimit)
+		c.Set(constants.ContextKeyPaginationMax, maxLimit)
+		c.Next()
+	}
+}
+
+// APIKeyAuth requires a known API key on every request that changes data, recording its holder as the actor
+func APIKeyAuth(header string, keys map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		// Compare against every key in constant time so response timing doesn't leak key prefixes
+		presented := []byte(c.GetHeader(header))
+		actor := ""
+		for key, name := range keys {
+			if subtle.ConstantTimeCompare(presented, []byte(key)) == 1 {
+				actor = name
+			}
+		}
+		if actor == "" {
+			ErrorResponse(c, http.StatusUnauthorized, constants.ErrorUnauthorized, errors.New("missing or unknown "+header+" header"))
+			c.Abort()
+			return
+		}
+
+		c.Set(constants.ContextKeyActor, actor)
+		c.Request = c.Request.WithContext(logging.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// ReadYourWrites keeps a client's reads on the primary for window after it writes, so replica lag never hides its own changes
+func ReadYourWrites(window time.Duration, withPrimary func(context.Context) context.Context) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+
+		sticky := false
+		if value, err := c.Cookie(constants.StickyPrimaryCookie); err == nil {
+			if until, err := strconv.ParseInt(value, 10, 64); err == nil && now.Unix() < until {
+				sticky = true
+			}
+		}
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			// Set before the handler runs because headers are sent with the first byte of the body
+			sticky = true
+			seconds := int((window + time.Second - 1) / time.Second)
+			c.SetSameSite(http.SameSiteLaxMode)
+			c.SetCookie(constants.StickyPrimaryCookie, strconv.FormatInt(now.Add(window).Unix(), 10), seconds, "/", "", false, true)
+		}
+
+		if sticky {
+			c.Request = c.Request.WithContext(withPrimary(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+// RequestID accepts the client's X-Request-ID or generates one, echoing it on the response and attaching it to every log record;
+// the client's IP is attached to the request context alongside it for the audit log
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(constants.HeaderRequestID)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(constants.ContextKeyRequestID, requestID)
+		c.Header(constants.HeaderRequestID, requestID)
+		ctx := logging.WithRequestID(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(logging.WithClientIP(ctx, c.ClientIP()))
+		c.Next()
+	}
+}
+
+// validRequestID accepts short IDs of letters, digits and -_.: so client input can't forge log lines
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > constants.MaxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestLogger writes one record per request; server errors log as errors and client errors as warnings
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if actor := c.GetString(constants.ContextKeyActor); actor != "" {
+			attrs = append(attrs, slog.String("actor", actor))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// Recovery turns a panicking handler into a 500 and logs the panic with its stack
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		// An export that failed after its response started aborts the connection so the client sees it incomplete
+		if recovered == http.ErrAbortHandler {
+			logger.WarnContext(c.Request.Context(), "response aborted", slog.String("error", c.Errors.String()))
+			panic(recovered)
+		}
+
+		logger.ErrorContext(c.Request.Context(), "panic recovered",
+			slog.Any("panic", recovered),
+			slog.String("stack", string(debug.Stack())),
+		)
+		ErrorResponse(c, http.StatusInternalServerError, constants.ErrorInternalServerError, nil)
+		c.Abort()
+	})
+}
+
+// RequestMetrics records each request's count and latency under its route template, never the raw path
+func RequestMetrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = constants.MetricsUnmatchedRoute
+		}
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// Tracing continues the caller's W3C trace, or starts one, with a server span named by the route template
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer(constants.TracerName)
+
+	return func(c *gin.Context) {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = constants.MetricsUnmatchedRoute
+		}
+
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.ClientAddress(c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		// Return the trace context so clients can quote it alongside the request ID
+		propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OutboxController handles HTTP requests for inspecting and replaying outbox messages
+type OutboxController struct {
+	outboxService interfaces.OutboxService
+}
+
+// NewOutboxController creates a new outbox controller
+func NewOutboxController(outboxService interfaces.OutboxService) *OutboxController {
+	return &OutboxController{
+		outboxService: outboxService,
+	}
+}
+
+// ListMessages handles GET /admin/outbox, optionally filtered with ?status=pending|delivered|failed
+func (ctrl *OutboxController) ListMessages(c *gin.Context) {
+	params := GetPaginationParams(c)
+
+	messages, err := ctrl.outboxService.ListMessages(c.Request.Context(), c.Query("status"), params.Limit, params.Offset)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), constants.ErrorOutboxInvalidStatus) {
+			BadRequestResponse(c, constants.ErrorOutboxInvalidStatus, err)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, messages)
+}
+
+// GetMessage handles GET /admin/outbox/:id
+func (ctrl *OutboxController) GetMessage(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	message, err := ctrl.outboxService.GetMessage(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorOutboxNotFound {
+			NotFoundResponse(c, constants.ErrorOutboxNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, message)
+}
+
+// ReplayMessage handles POST /admin/outbox/:id/replay
+func (ctrl *OutboxController) ReplayMessage(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	message, err := ctrl.outboxService.ReplayMessage(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorOutboxNotFound {
+			NotFoundResponse(c, constants.ErrorOutboxNotFound)
+			return
+		}
+		if err.Error() == constants.ErrorOutboxDelivered {
+			ErrorResponse(c, http.StatusConflict, constants.ErrorOutboxDelivered, nil)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageOutboxReplayed, message)
+}
+
+// ReplayFailed handles POST /admin/outbox/replay, replaying every failed message
+func (ctrl *OutboxController) ReplayFailed(c *gin.Context) {
+	result, err := ctrl.outboxService.ReplayFailed(c.Request.Context())
+	if err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageOutboxAllReplayed, result)
+}
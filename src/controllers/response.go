@@ -1,18 +1,26 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // APIResponse represents a standard API response structure
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success   bool        `json:"success"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 // SuccessResponse sends a successful response
@@ -24,11 +32,24 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
-// ErrorResponse sends an error response
+// ErrorResponse sends an error response carrying the request ID; a body over the size limit is always reported as 413
 func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
+	ErrorResponseWithData(c, statusCode, message, err, nil)
+}
+
+// ErrorResponseWithData sends an error response along with details the client needs to resolve it
+func ErrorResponseWithData(c *gin.Context, statusCode int, message string, err error, data interface{}) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		statusCode = http.StatusRequestEntityTooLarge
+		message = constants.ErrorRequestTooLarge
+	}
+
 	response := APIResponse{
-		Success: false,
-		Message: message,
+		Success:   false,
+		Message:   message,
+		Data:      data,
+		RequestID: c.GetString(constants.ContextKeyRequestID),
 	}
 
 	if err != nil {
@@ -53,9 +74,53 @@ func BadRequestResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusBadRequest, message, err)
 }
 
-// InternalServerErrorResponse sends an internal server error response
+// DeleteErrorResponse maps a failed delete to its status; a delete refused under the restrict policy lists what still belongs to the record
+func DeleteErrorResponse(c *gin.Context, notFound string, err error) {
+	var conflict *dto.DeleteConflict
+	switch {
+	case err.Error() == notFound:
+		NotFoundResponse(c, notFound)
+	case errors.As(err, &conflict):
+		ErrorResponseWithData(c, http.StatusConflict, constants.ErrorHasDependents, err, conflict)
+	case strings.HasPrefix(err.Error(), constants.ErrorInvalidReassignTarget):
+		BadRequestResponse(c, constants.ErrorInvalidReassignTarget, err)
+	case strings.HasPrefix(err.Error(), constants.ErrorValidationFailed):
+		// A record being moved no longer passes validation
+		ValidationErrorResponse(c, err)
+	default:
+		InternalServerErrorResponse(c, err)
+	}
+}
+
+// InternalServerErrorResponse sends an internal server error response, or a timeout when the request's deadline cut it short
 func InternalServerErrorResponse(c *gin.Context, err error) {
-	ErrorResponse(c, http.StatusInternalServerError, constants.ErrorInternalServerError, err)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		ErrorResponse(c, http.StatusGatewayTimeout, constants.ErrorRequestTimeout, err)
+	case errors.Is(err, context.Canceled):
+		// The client has gone, so there is nobody to send a body to
+		c.AbortWithStatus(constants.StatusClientClosedRequest)
+	default:
+		ErrorResponse(c, http.StatusInternalServerError, constants.ErrorInternalServerError, err)
+	}
+}
+
+// CalendarResponse sends an iCalendar document inline so calendar apps can subscribe to it
+func CalendarResponse(c *gin.Context, filename string, calendar []byte) {
+	c.Header("Content-Disposition", `inline; filename="`+filename+`"`)
+	c.Data(http.StatusOK, constants.CalendarContentType, calendar)
+}
+
+// WantsJSONLD reports whether the client prefers schema.org JSON-LD over the standard JSON envelope
+func WantsJSONLD(c *gin.Context) bool {
+	c.Header("Vary", "Accept")
+	return c.NegotiateFormat(gin.MIMEJSON, constants.MIMEJSONLD) == constants.MIMEJSONLD
+}
+
+// JSONLDResponse sends a bare JSON-LD document without the API envelope
+func JSONLDResponse(c *gin.Context, document interface{}) {
+	c.Header("Content-Type", constants.MIMEJSONLD+"; charset=utf-8")
+	c.JSON(http.StatusOK, document)
 }
 
 // PaginationParams represents pagination parameters
@@ -68,20 +133,29 @@ type PaginationParams struct {
 func GetPaginationParams(c *gin.Context) PaginationParams {
 	var params PaginationParams
 
+	// Page sizes come from configuration when the Pagination middleware is installed
+	defaultLimit, maxLimit := constants.DefaultLimit, constants.MaxLimit
+	if limit := c.GetInt(constants.ContextKeyPaginationLimit); limit > 0 {
+		defaultLimit = limit
+	}
+	if limit := c.GetInt(constants.ContextKeyPaginationMax); limit > 0 {
+		maxLimit = limit
+	}
+
 	// Set defaults
-	params.Limit = constants.DefaultLimit
+	params.Limit = defaultLimit
 	params.Offset = constants.DefaultOffset
 
 	// Bind query parameters
 	if err := c.ShouldBindQuery(&params); err != nil {
 		// Use defaults if binding fails
-		params.Limit = constants.DefaultLimit
+		params.Limit = defaultLimit
 		params.Offset = constants.DefaultOffset
 	}
 
 	// Apply limits
-	if params.Limit <= 0 || params.Limit > constants.MaxLimit {
-		params.Limit = constants.DefaultLimit
+	if params.Limit <= 0 || params.Limit > maxLimit {
+		params.Limit = defaultLimit
 	}
 
 	if params.Offset < 0 {
@@ -90,3 +164,28 @@ func GetPaginationParams(c *gin.Context) PaginationParams {
 
 	return params
 }
+
+// ParseRevision parses a revision number from a path or query parameter; revisions are numbered from 1
+func ParseRevision(value string) (int, error) {
+	revision, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if revision < 1 {
+		return 0, fmt.Errorf("revision %d is below 1", revision)
+	}
+	return revision, nil
+}
+
+// queryUUID parses an optional UUID query parameter
+func queryUUID(c *gin.Context, name string) (*uuid.UUID, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
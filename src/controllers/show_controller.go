@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strings"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
 	"theatre-management-system/src/interfaces"
@@ -31,7 +32,7 @@ func (ctrl *ShowController) CreateShow(c *gin.Context) {
 		return
 	}
 
-	show, err := ctrl.showService.CreateShow(&showDTO)
+	show, err := ctrl.showService.CreateShow(c.Request.Context(), &showDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorValidationFailed {
 			ValidationErrorResponse(c, err)
@@ -48,7 +49,7 @@ func (ctrl *ShowController) CreateShow(c *gin.Context) {
 	SuccessResponse(c, http.StatusCreated, constants.MessageShowCreated, show)
 }
 
-// GetShowByID handles GET /shows/:id
+// GetShowByID handles GET /shows/:id, returning schema.org JSON-LD when requested via Accept
 func (ctrl *ShowController) GetShowByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -57,7 +58,22 @@ func (ctrl *ShowController) GetShowByID(c *gin.Context) {
 		return
 	}
 
-	show, err := ctrl.showService.GetShowByID(id)
+	if WantsJSONLD(c) {
+		document, err := ctrl.showService.GetShowStructuredData(c.Request.Context(), id)
+		if err != nil {
+			if err.Error() == constants.ErrorShowNotFound {
+				NotFoundResponse(c, constants.ErrorShowNotFound)
+				return
+			}
+			InternalServerErrorResponse(c, err)
+			return
+		}
+
+		JSONLDResponse(c, document)
+		return
+	}
+
+	show, err := ctrl.showService.GetShowByID(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == constants.ErrorShowNotFound {
 			NotFoundResponse(c, constants.ErrorShowNotFound)
@@ -72,15 +88,12 @@ func (ctrl *ShowController) GetShowByID(c *gin.Context) {
 
 // GetAllShows handles GET /shows
 func (ctrl *ShowController) GetAllShows(c *gin.Context) {
-	params := GetPaginationParams(c)
-
-	shows, err := ctrl.showService.GetAllShows(params.Limit, params.Offset)
+	err := PagedListResponse(c, "shows", func(limit, offset int) (interface{}, error) {
+		return ctrl.showService.GetAllShows(c.Request.Context(), limit, offset)
+	})
 	if err != nil {
 		InternalServerErrorResponse(c, err)
-		return
 	}
-
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
 }
 
 // UpdateShow handles PATCH /shows/:id
@@ -98,7 +111,7 @@ func (ctrl *ShowController) UpdateShow(c *gin.Context) {
 		return
 	}
 
-	show, err := ctrl.showService.UpdateShow(id, &showDTO)
+	show, err := ctrl.showService.UpdateShow(c.Request.Context(), id, &showDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorShowNotFound {
 			NotFoundResponse(c, constants.ErrorShowNotFound)
@@ -128,13 +141,15 @@ func (ctrl *ShowController) DeleteShow(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.showService.DeleteShow(id)
+	reassignTo, err := queryUUID(c, "reassign_to")
 	if err != nil {
-		if err.Error() == constants.ErrorShowNotFound {
-			NotFoundResponse(c, constants.ErrorShowNotFound)
-			return
-		}
-		InternalServerErrorResponse(c, err)
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.showService.DeleteShow(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		DeleteErrorResponse(c, constants.ErrorShowNotFound, err)
 		return
 	}
 
@@ -150,13 +165,13 @@ func (ctrl *ShowController) GetShowsByTheatreID(c *gin.Context) {
 		return
 	}
 
-	shows, err := ctrl.showService.GetShowsByTheatreID(theatreID)
+	shows, err := ctrl.showService.GetShowsByTheatreID(c.Request.Context(), theatreID)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // GetShowsByShowTypeID handles GET /shows/type/:typeId
@@ -168,57 +183,57 @@ func (ctrl *ShowController) GetShowsByShowTypeID(c *gin.Context) {
 		return
 	}
 
-	shows, err := ctrl.showService.GetShowsByShowTypeID(typeID)
+	shows, err := ctrl.showService.GetShowsByShowTypeID(c.Request.Context(), typeID)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // GetFeaturedShows handles GET /shows/featured
 func (ctrl *ShowController) GetFeaturedShows(c *gin.Context) {
-	shows, err := ctrl.showService.GetFeaturedShows()
+	shows, err := ctrl.showService.GetFeaturedShows(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // GetActiveShows handles GET /shows/active
 func (ctrl *ShowController) GetActiveShows(c *gin.Context) {
-	shows, err := ctrl.showService.GetActiveShows()
+	shows, err := ctrl.showService.GetActiveShows(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // GetCurrentShows handles GET /shows/current
 func (ctrl *ShowController) GetCurrentShows(c *gin.Context) {
-	shows, err := ctrl.showService.GetCurrentShows()
+	shows, err := ctrl.showService.GetCurrentShows(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // GetUpcomingShows handles GET /shows/upcoming
 func (ctrl *ShowController) GetUpcomingShows(c *gin.Context) {
-	shows, err := ctrl.showService.GetUpcomingShows()
+	shows, err := ctrl.showService.GetUpcomingShows(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
 }
 
 // SearchShows handles GET /shows/search
@@ -229,11 +244,127 @@ func (ctrl *ShowController) SearchShows(c *gin.Context) {
 		return
 	}
 
-	shows, err := ctrl.showService.SearchShows(query)
+	shows, err := ctrl.showService.SearchShows(c.Request.Context(), query)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, shows)
+	ListResponse(c, "shows", shows)
+}
+
+// GetShowRevisions handles GET /shows/:id/revisions
+func (ctrl *ShowController) GetShowRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = PagedListResponse(c, "revisions", func(limit, offset int) (interface{}, error) {
+		return ctrl.showService.GetShowRevisions(c.Request.Context(), id, limit, offset)
+	})
+	if err != nil {
+		if err.Error() == constants.ErrorShowNotFound {
+			NotFoundResponse(c, constants.ErrorShowNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+	}
+}
+
+// GetShowRevision handles GET /shows/:id/revisions/:revision
+func (ctrl *ShowController) GetShowRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	revision, err := ParseRevision(c.Param("revision"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	details, err := ctrl.showService.GetShowRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		if err.Error() == constants.ErrorShowNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, details)
+}
+
+// DiffShowRevisions handles GET /shows/:id/revisions/diff?from=&to=
+func (ctrl *ShowController) DiffShowRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	from, err := ParseRevision(c.Query("from"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+	to, err := ParseRevision(c.Query("to"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	diff, err := ctrl.showService.DiffShowRevisions(c.Request.Context(), id, from, to)
+	if err != nil {
+		if err.Error() == constants.ErrorShowNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, diff)
+}
+
+// RestoreShowRevision handles POST /shows/:id/revisions/:revision/restore
+func (ctrl *ShowController) RestoreShowRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	revision, err := ParseRevision(c.Param("revision"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	show, err := ctrl.showService.RestoreShowRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		if err.Error() == constants.ErrorShowNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		if strings.HasPrefix(err.Error(), constants.ErrorValidationFailed) {
+			ValidationErrorResponse(c, err)
+			return
+		}
+		// The revision points at something that has since been deleted
+		if err.Error() == constants.ErrorTheatreNotFound || err.Error() == constants.ErrorShowTypeNotFound {
+			ErrorResponse(c, http.StatusConflict, err.Error(), err)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageRevisionRestored, show)
 }
@@ -31,7 +31,7 @@ func (ctrl *ShowTypeController) CreateShowType(c *gin.Context) {
 		return
 	}
 
-	showType, err := ctrl.showTypeService.CreateShowType(&showTypeDTO)
+	showType, err := ctrl.showTypeService.CreateShowType(c.Request.Context(), &showTypeDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorValidationFailed {
 			ValidationErrorResponse(c, err)
@@ -57,7 +57,7 @@ func (ctrl *ShowTypeController) GetShowTypeByID(c *gin.Context) {
 		return
 	}
 
-	showType, err := ctrl.showTypeService.GetShowTypeByID(id)
+	showType, err := ctrl.showTypeService.GetShowTypeByID(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == constants.ErrorShowTypeNotFound {
 			NotFoundResponse(c, constants.ErrorShowTypeNotFound)
@@ -72,15 +72,12 @@ func (ctrl *ShowTypeController) GetShowTypeByID(c *gin.Context) {
 
 // GetAllShowTypes handles GET /show-types
 func (ctrl *ShowTypeController) GetAllShowTypes(c *gin.Context) {
-	params := GetPaginationParams(c)
-
-	showTypes, err := ctrl.showTypeService.GetAllShowTypes(params.Limit, params.Offset)
+	err := PagedListResponse(c, "show-types", func(limit, offset int) (interface{}, error) {
+		return ctrl.showTypeService.GetAllShowTypes(c.Request.Context(), limit, offset)
+	})
 	if err != nil {
 		InternalServerErrorResponse(c, err)
-		return
 	}
-
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, showTypes)
 }
 
 // UpdateShowType handles PATCH /show-types/:id
@@ -98,7 +95,7 @@ func (ctrl *ShowTypeController) UpdateShowType(c *gin.Context) {
 		return
 	}
 
-	showType, err := ctrl.showTypeService.UpdateShowType(id, &showTypeDTO)
+	showType, err := ctrl.showTypeService.UpdateShowType(c.Request.Context(), id, &showTypeDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorShowTypeNotFound {
 			NotFoundResponse(c, constants.ErrorShowTypeNotFound)
@@ -119,7 +116,7 @@ func (ctrl *ShowTypeController) UpdateShowType(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, constants.MessageShowTypeUpdated, showType)
 }
 
-// DeleteShowType handles DELETE /show-types/:id
+// DeleteShowType handles DELETE /show-types/:id?reassign_to=
 func (ctrl *ShowTypeController) DeleteShowType(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -128,13 +125,15 @@ func (ctrl *ShowTypeController) DeleteShowType(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.showTypeService.DeleteShowType(id)
+	reassignTo, err := queryUUID(c, "reassign_to")
 	if err != nil {
-		if err.Error() == constants.ErrorShowTypeNotFound {
-			NotFoundResponse(c, constants.ErrorShowTypeNotFound)
-			return
-		}
-		InternalServerErrorResponse(c, err)
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.showTypeService.DeleteShowType(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		DeleteErrorResponse(c, constants.ErrorShowTypeNotFound, err)
 		return
 	}
 
@@ -149,7 +148,7 @@ func (ctrl *ShowTypeController) GetShowTypeByName(c *gin.Context) {
 		return
 	}
 
-	showType, err := ctrl.showTypeService.GetShowTypeByName(name)
+	showType, err := ctrl.showTypeService.GetShowTypeByName(c.Request.Context(), name)
 	if err != nil {
 		if err.Error() == constants.ErrorShowTypeNotFound {
 			NotFoundResponse(c, constants.ErrorShowTypeNotFound)
@@ -164,11 +163,11 @@ func (ctrl *ShowTypeController) GetShowTypeByName(c *gin.Context) {
 
 // GetActiveShowTypes handles GET /show-types/active
 func (ctrl *ShowTypeController) GetActiveShowTypes(c *gin.Context) {
-	showTypes, err := ctrl.showTypeService.GetActiveShowTypes()
+	showTypes, err := ctrl.showTypeService.GetActiveShowTypes(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, showTypes)
+	ListResponse(c, "show-types", showTypes)
 }
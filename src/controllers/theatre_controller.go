@@ -3,6 +3,7 @@ package controllers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"theatre-management-system/src/constants"
 	"theatre-management-system/src/dto"
 	"theatre-management-system/src/interfaces"
@@ -32,7 +33,7 @@ func (ctrl *TheatreController) CreateTheatre(c *gin.Context) {
 		return
 	}
 
-	theatre, err := ctrl.theatreService.CreateTheatre(&theatreDTO)
+	theatre, err := ctrl.theatreService.CreateTheatre(c.Request.Context(), &theatreDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorValidationFailed {
 			ValidationErrorResponse(c, err)
@@ -49,7 +50,7 @@ func (ctrl *TheatreController) CreateTheatre(c *gin.Context) {
 	SuccessResponse(c, http.StatusCreated, constants.MessageTheatreCreated, theatre)
 }
 
-// GetTheatreByID handles GET /theatres/:id
+// GetTheatreByID handles GET /theatres/:id, returning schema.org JSON-LD when requested via Accept
 func (ctrl *TheatreController) GetTheatreByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -58,7 +59,22 @@ func (ctrl *TheatreController) GetTheatreByID(c *gin.Context) {
 		return
 	}
 
-	theatre, err := ctrl.theatreService.GetTheatreByID(id)
+	if WantsJSONLD(c) {
+		document, err := ctrl.theatreService.GetTheatreStructuredData(c.Request.Context(), id)
+		if err != nil {
+			if err.Error() == constants.ErrorTheatreNotFound {
+				NotFoundResponse(c, constants.ErrorTheatreNotFound)
+				return
+			}
+			InternalServerErrorResponse(c, err)
+			return
+		}
+
+		JSONLDResponse(c, document)
+		return
+	}
+
+	theatre, err := ctrl.theatreService.GetTheatreByID(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == constants.ErrorTheatreNotFound {
 			NotFoundResponse(c, constants.ErrorTheatreNotFound)
@@ -73,15 +89,12 @@ func (ctrl *TheatreController) GetTheatreByID(c *gin.Context) {
 
 // GetAllTheatres handles GET /theatres
 func (ctrl *TheatreController) GetAllTheatres(c *gin.Context) {
-	params := GetPaginationParams(c)
-
-	theatres, err := ctrl.theatreService.GetAllTheatres(params.Limit, params.Offset)
+	err := PagedListResponse(c, "theatres", func(limit, offset int) (interface{}, error) {
+		return ctrl.theatreService.GetAllTheatres(c.Request.Context(), limit, offset)
+	})
 	if err != nil {
 		InternalServerErrorResponse(c, err)
-		return
 	}
-
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
 }
 
 // UpdateTheatre handles PATCH /theatres/:id
@@ -99,7 +112,7 @@ func (ctrl *TheatreController) UpdateTheatre(c *gin.Context) {
 		return
 	}
 
-	theatre, err := ctrl.theatreService.UpdateTheatre(id, &theatreDTO)
+	theatre, err := ctrl.theatreService.UpdateTheatre(c.Request.Context(), id, &theatreDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorTheatreNotFound {
 			NotFoundResponse(c, constants.ErrorTheatreNotFound)
@@ -120,7 +133,7 @@ func (ctrl *TheatreController) UpdateTheatre(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, constants.MessageTheatreUpdated, theatre)
 }
 
-// DeleteTheatre handles DELETE /theatres/:id
+// DeleteTheatre handles DELETE /theatres/:id?reassign_to=
 func (ctrl *TheatreController) DeleteTheatre(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -129,13 +142,15 @@ func (ctrl *TheatreController) DeleteTheatre(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.theatreService.DeleteTheatre(id)
+	reassignTo, err := queryUUID(c, "reassign_to")
 	if err != nil {
-		if err.Error() == constants.ErrorTheatreNotFound {
-			NotFoundResponse(c, constants.ErrorTheatreNotFound)
-			return
-		}
-		InternalServerErrorResponse(c, err)
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.theatreService.DeleteTheatre(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		DeleteErrorResponse(c, constants.ErrorTheatreNotFound, err)
 		return
 	}
 
@@ -151,13 +166,13 @@ func (ctrl *TheatreController) GetTheatresByLocationID(c *gin.Context) {
 		return
 	}
 
-	theatres, err := ctrl.theatreService.GetTheatresByLocationID(locationID)
+	theatres, err := ctrl.theatreService.GetTheatresByLocationID(c.Request.Context(), locationID)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	ListResponse(c, "theatres", theatres)
 }
 
 // GetTheatresByTheatreTypeID handles GET /theatres/type/:typeId
@@ -169,35 +184,35 @@ func (ctrl *TheatreController) GetTheatresByTheatreTypeID(c *gin.Context) {
 		return
 	}
 
-	theatres, err := ctrl.theatreService.GetTheatresByTheatreTypeID(typeID)
+	theatres, err := ctrl.theatreService.GetTheatresByTheatreTypeID(c.Request.Context(), typeID)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	ListResponse(c, "theatres", theatres)
 }
 
 // GetFeaturedTheatres handles GET /theatres/featured
 func (ctrl *TheatreController) GetFeaturedTheatres(c *gin.Context) {
-	theatres, err := ctrl.theatreService.GetFeaturedTheatres()
+	theatres, err := ctrl.theatreService.GetFeaturedTheatres(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	ListResponse(c, "theatres", theatres)
 }
 
 // GetActiveTheatres handles GET /theatres/active
 func (ctrl *TheatreController) GetActiveTheatres(c *gin.Context) {
-	theatres, err := ctrl.theatreService.GetActiveTheatres()
+	theatres, err := ctrl.theatreService.GetActiveTheatres(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	ListResponse(c, "theatres", theatres)
 }
 
 // SearchTheatres handles GET /theatres/search
@@ -208,13 +223,13 @@ func (ctrl *TheatreController) SearchTheatres(c *gin.Context) {
 		return
 	}
 
-	theatres, err := ctrl.theatreService.SearchTheatres(query)
+	theatres, err := ctrl.theatreService.SearchTheatres(c.Request.Context(), query)
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	ListResponse(c, "theatres", theatres)
 }
 
 // GetNearbyTheatres handles GET /theatres/nearby
@@ -249,11 +264,127 @@ func (ctrl *TheatreController) GetNearbyTheatres(c *gin.Context) {
 		}
 	}
 
-	theatres, err := ctrl.theatreService.GetNearbyTheatres(latitude, longitude, radius)
+	theatres, err := ctrl.theatreService.GetNearbyTheatres(c.Request.Context(), latitude, longitude, radius)
+	if err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	ListResponse(c, "theatres", theatres)
+}
+
+// GetTheatreRevisions handles GET /theatres/:id/revisions
+func (ctrl *TheatreController) GetTheatreRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = PagedListResponse(c, "revisions", func(limit, offset int) (interface{}, error) {
+		return ctrl.theatreService.GetTheatreRevisions(c.Request.Context(), id, limit, offset)
+	})
+	if err != nil {
+		if err.Error() == constants.ErrorTheatreNotFound {
+			NotFoundResponse(c, constants.ErrorTheatreNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+	}
+}
+
+// GetTheatreRevision handles GET /theatres/:id/revisions/:revision
+func (ctrl *TheatreController) GetTheatreRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	revision, err := ParseRevision(c.Param("revision"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	details, err := ctrl.theatreService.GetTheatreRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		if err.Error() == constants.ErrorTheatreNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, details)
+}
+
+// DiffTheatreRevisions handles GET /theatres/:id/revisions/diff?from=&to=
+func (ctrl *TheatreController) DiffTheatreRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	from, err := ParseRevision(c.Query("from"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+	to, err := ParseRevision(c.Query("to"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	diff, err := ctrl.theatreService.DiffTheatreRevisions(c.Request.Context(), id, from, to)
 	if err != nil {
+		if err.Error() == constants.ErrorTheatreNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, diff)
+}
+
+// RestoreTheatreRevision handles POST /theatres/:id/revisions/:revision/restore
+func (ctrl *TheatreController) RestoreTheatreRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	revision, err := ParseRevision(c.Param("revision"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidRevision, err)
+		return
+	}
+
+	theatre, err := ctrl.theatreService.RestoreTheatreRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		if err.Error() == constants.ErrorTheatreNotFound || err.Error() == constants.ErrorRevisionNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		if strings.HasPrefix(err.Error(), constants.ErrorValidationFailed) {
+			ValidationErrorResponse(c, err)
+			return
+		}
+		// The revision points at something that has since been deleted
+		if err.Error() == constants.ErrorLocationNotFound || err.Error() == constants.ErrorTheatreTypeNotFound {
+			ErrorResponse(c, http.StatusConflict, err.Error(), err)
+			return
+		}
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatres)
+	SuccessResponse(c, http.StatusOK, constants.MessageRevisionRestored, theatre)
 }
@@ -31,7 +31,7 @@ func (ctrl *TheatreTypeController) CreateTheatreType(c *gin.Context) {
 		return
 	}
 
-	theatreType, err := ctrl.theatreTypeService.CreateTheatreType(&theatreTypeDTO)
+	theatreType, err := ctrl.theatreTypeService.CreateTheatreType(c.Request.Context(), &theatreTypeDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorValidationFailed {
 			ValidationErrorResponse(c, err)
@@ -57,7 +57,7 @@ func (ctrl *TheatreTypeController) GetTheatreTypeByID(c *gin.Context) {
 		return
 	}
 
-	theatreType, err := ctrl.theatreTypeService.GetTheatreTypeByID(id)
+	theatreType, err := ctrl.theatreTypeService.GetTheatreTypeByID(c.Request.Context(), id)
 	if err != nil {
 		if err.Error() == constants.ErrorTheatreTypeNotFound {
 			NotFoundResponse(c, constants.ErrorTheatreTypeNotFound)
@@ -72,15 +72,12 @@ func (ctrl *TheatreTypeController) GetTheatreTypeByID(c *gin.Context) {
 
 // GetAllTheatreTypes handles GET /theatre-types
 func (ctrl *TheatreTypeController) GetAllTheatreTypes(c *gin.Context) {
-	params := GetPaginationParams(c)
-
-	theatreTypes, err := ctrl.theatreTypeService.GetAllTheatreTypes(params.Limit, params.Offset)
+	err := PagedListResponse(c, "theatre-types", func(limit, offset int) (interface{}, error) {
+		return ctrl.theatreTypeService.GetAllTheatreTypes(c.Request.Context(), limit, offset)
+	})
 	if err != nil {
 		InternalServerErrorResponse(c, err)
-		return
 	}
-
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatreTypes)
 }
 
 // UpdateTheatreType handles PATCH /theatre-types/:id
@@ -98,7 +95,7 @@ func (ctrl *TheatreTypeController) UpdateTheatreType(c *gin.Context) {
 		return
 	}
 
-	theatreType, err := ctrl.theatreTypeService.UpdateTheatreType(id, &theatreTypeDTO)
+	theatreType, err := ctrl.theatreTypeService.UpdateTheatreType(c.Request.Context(), id, &theatreTypeDTO)
 	if err != nil {
 		if err.Error() == constants.ErrorTheatreTypeNotFound {
 			NotFoundResponse(c, constants.ErrorTheatreTypeNotFound)
@@ -119,7 +116,7 @@ func (ctrl *TheatreTypeController) UpdateTheatreType(c *gin.Context) {
 	SuccessResponse(c, http.StatusOK, constants.MessageTheatreTypeUpdated, theatreType)
 }
 
-// DeleteTheatreType handles DELETE /theatre-types/:id
+// DeleteTheatreType handles DELETE /theatre-types/:id?reassign_to=
 func (ctrl *TheatreTypeController) DeleteTheatreType(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -128,13 +125,15 @@ func (ctrl *TheatreTypeController) DeleteTheatreType(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.theatreTypeService.DeleteTheatreType(id)
+	reassignTo, err := queryUUID(c, "reassign_to")
 	if err != nil {
-		if err.Error() == constants.ErrorTheatreTypeNotFound {
-			NotFoundResponse(c, constants.ErrorTheatreTypeNotFound)
-			return
-		}
-		InternalServerErrorResponse(c, err)
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.theatreTypeService.DeleteTheatreType(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		DeleteErrorResponse(c, constants.ErrorTheatreTypeNotFound, err)
 		return
 	}
 
@@ -149,7 +148,7 @@ func (ctrl *TheatreTypeController) GetTheatreTypeByName(c *gin.Context) {
 		return
 	}
 
-	theatreType, err := ctrl.theatreTypeService.GetTheatreTypeByName(name)
+	theatreType, err := ctrl.theatreTypeService.GetTheatreTypeByName(c.Request.Context(), name)
 	if err != nil {
 		if err.Error() == constants.ErrorTheatreTypeNotFound {
 			NotFoundResponse(c, constants.ErrorTheatreTypeNotFound)
@@ -164,11 +163,11 @@ func (ctrl *TheatreTypeController) GetTheatreTypeByName(c *gin.Context) {
 
 // GetActiveTheatreTypes handles GET /theatre-types/active
 func (ctrl *TheatreTypeController) GetActiveTheatreTypes(c *gin.Context) {
-	theatreTypes, err := ctrl.theatreTypeService.GetActiveTheatreTypes()
+	theatreTypes, err := ctrl.theatreTypeService.GetActiveTheatreTypes(c.Request.Context())
 	if err != nil {
 		InternalServerErrorResponse(c, err)
 		return
 	}
 
-	SuccessResponse(c, http.StatusOK, constants.StatusOK, theatreTypes)
+	ListResponse(c, "theatre-types", theatreTypes)
 }
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TrashController handles HTTP requests for soft-deleted records
+type TrashController struct {
+	locationService    interfaces.LocationService
+	theatreTypeService interfaces.TheatreTypeService
+	showTypeService    interfaces.ShowTypeService
+	theatreService     interfaces.TheatreService
+	showService        interfaces.ShowService
+}
+
+// NewTrashController creates a new trash controller
+func NewTrashController(locationService interfaces.LocationService, theatreTypeService interfaces.TheatreTypeService, showTypeService interfaces.ShowTypeService, theatreService interfaces.TheatreService, showService interfaces.ShowService) *TrashController {
+	return &TrashController{
+		locationService:    locationService,
+		theatreTypeService: theatreTypeService,
+		showTypeService:    showTypeService,
+		theatreService:     theatreService,
+		showService:        showService,
+	}
+}
+
+// ListDeleted handles GET /trash/:resource
+func (ctrl *TrashController) ListDeleted(c *gin.Context) {
+	resource := c.Param("resource")
+	ctx := c.Request.Context()
+
+	var page ListPage
+	switch resource {
+	case constants.BatchResourceLocations:
+		page = func(limit, offset int) (interface{}, error) {
+			return ctrl.locationService.GetDeletedLocations(ctx, limit, offset)
+		}
+	case constants.BatchResourceTheatreTypes:
+		page = func(limit, offset int) (interface{}, error) {
+			return ctrl.theatreTypeService.GetDeletedTheatreTypes(ctx, limit, offset)
+		}
+	case constants.BatchResourceShowTypes:
+		page = func(limit, offset int) (interface{}, error) {
+			return ctrl.showTypeService.GetDeletedShowTypes(ctx, limit, offset)
+		}
+	case constants.BatchResourceTheatres:
+		page = func(limit, offset int) (interface{}, error) {
+			return ctrl.theatreService.GetDeletedTheatres(ctx, limit, offset)
+		}
+	case constants.BatchResourceShows:
+		page = func(limit, offset int) (interface{}, error) {
+			return ctrl.showService.GetDeletedShows(ctx, limit, offset)
+		}
+	default:
+		NotFoundResponse(c, constants.ErrorTrashUnknownResource)
+		return
+	}
+
+	if err := PagedListResponse(c, resource, page); err != nil {
+		InternalServerErrorResponse(c, err)
+	}
+}
+
+// Restore handles POST /trash/:resource/:id/restore
+func (ctrl *TrashController) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	ctx := c.Request.Context()
+
+	var restored interface{}
+	switch c.Param("resource") {
+	case constants.BatchResourceLocations:
+		restored, err = ctrl.locationService.RestoreLocation(ctx, id)
+	case constants.BatchResourceTheatreTypes:
+		restored, err = ctrl.theatreTypeService.RestoreTheatreType(ctx, id)
+	case constants.BatchResourceShowTypes:
+		restored, err = ctrl.showTypeService.RestoreShowType(ctx, id)
+	case constants.BatchResourceTheatres:
+		restored, err = ctrl.theatreService.RestoreTheatre(ctx, id)
+	case constants.BatchResourceShows:
+		restored, err = ctrl.showService.RestoreShow(ctx, id)
+	default:
+		NotFoundResponse(c, constants.ErrorTrashUnknownResource)
+		return
+	}
+	if err != nil {
+		trashErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageTrashRestored, restored)
+}
+
+// Purge handles DELETE /trash/:resource/:id
+func (ctrl *TrashController) Purge(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	var purge func(ctx context.Context, id uuid.UUID) error
+	switch c.Param("resource") {
+	case constants.BatchResourceLocations:
+		purge = ctrl.locationService.PurgeLocation
+	case constants.BatchResourceTheatreTypes:
+		purge = ctrl.theatreTypeService.PurgeTheatreType
+	case constants.BatchResourceShowTypes:
+		purge = ctrl.showTypeService.PurgeShowType
+	case constants.BatchResourceTheatres:
+		purge = ctrl.theatreService.PurgeTheatre
+	case constants.BatchResourceShows:
+		purge = ctrl.showService.PurgeShow
+	default:
+		NotFoundResponse(c, constants.ErrorTrashUnknownResource)
+		return
+	}
+
+	if err := purge(c.Request.Context(), id); err != nil {
+		trashErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageTrashPurged, nil)
+}
+
+// trashErrorResponse maps restore and purge failures to their status codes
+func trashErrorResponse(c *gin.Context, err error) {
+	switch {
+	case err.Error() == constants.ErrorNotInTrash:
+		NotFoundResponse(c, constants.ErrorNotInTrash)
+	case strings.HasPrefix(err.Error(), constants.ErrorDuplicateEntry):
+		// A live record has taken the name since this one was deleted
+		ErrorResponse(c, http.StatusConflict, constants.ErrorDuplicateEntry, err)
+	case err.Error() == constants.ErrorLocationNotFound, err.Error() == constants.ErrorTheatreTypeNotFound,
+		err.Error() == constants.ErrorTheatreNotFound, err.Error() == constants.ErrorShowTypeNotFound:
+		// A record this one belongs to is itself deleted, so restore that first
+		ErrorResponse(c, http.StatusConflict, err.Error(), err)
+	case strings.HasPrefix(err.Error(), constants.ErrorStillReferenced):
+		ErrorResponse(c, http.StatusConflict, constants.ErrorStillReferenced, err)
+	default:
+		InternalServerErrorResponse(c, err)
+	}
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookController handles HTTP requests for webhook subscriptions and their deliveries
+type WebhookController struct {
+	webhookService interfaces.WebhookService
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(webhookService interfaces.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook handles POST /webhooks
+func (ctrl *WebhookController) CreateWebhook(c *gin.Context) {
+	var webhookDTO dto.WebhookBase
+
+	if err := c.ShouldBindJSON(&webhookDTO); err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidInput, err)
+		return
+	}
+
+	webhook, err := ctrl.webhookService.CreateWebhook(c.Request.Context(), &webhookDTO)
+	if err != nil {
+		if webhookInputError(c, err) {
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusCreated, constants.MessageWebhookCreated, webhook)
+}
+
+// GetWebhookByID handles GET /webhooks/:id
+func (ctrl *WebhookController) GetWebhookByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	webhook, err := ctrl.webhookService.GetWebhookByID(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound {
+			NotFoundResponse(c, constants.ErrorWebhookNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, webhook)
+}
+
+// GetAllWebhooks handles GET /webhooks
+func (ctrl *WebhookController) GetAllWebhooks(c *gin.Context) {
+	params := GetPaginationParams(c)
+
+	webhooks, err := ctrl.webhookService.GetAllWebhooks(c.Request.Context(), params.Limit, params.Offset)
+	if err != nil {
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, webhooks)
+}
+
+// UpdateWebhook handles PATCH /webhooks/:id
+func (ctrl *WebhookController) UpdateWebhook(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	var webhookDTO dto.WebhookBase
+	if err := c.ShouldBindJSON(&webhookDTO); err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidInput, err)
+		return
+	}
+
+	webhook, err := ctrl.webhookService.UpdateWebhook(c.Request.Context(), id, &webhookDTO)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound {
+			NotFoundResponse(c, constants.ErrorWebhookNotFound)
+			return
+		}
+		if webhookInputError(c, err) {
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageWebhookUpdated, webhook)
+}
+
+// DeleteWebhook handles DELETE /webhooks/:id
+func (ctrl *WebhookController) DeleteWebhook(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	err = ctrl.webhookService.DeleteWebhook(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound {
+			NotFoundResponse(c, constants.ErrorWebhookNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.MessageWebhookDeleted, nil)
+}
+
+// SendTestEvent handles POST /webhooks/:id/test, answering 200 with the logged delivery whether or not the endpoint accepted it
+func (ctrl *WebhookController) SendTestEvent(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	delivery, err := ctrl.webhookService.SendTestEvent(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound {
+			NotFoundResponse(c, constants.ErrorWebhookNotFound)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	message := constants.MessageWebhookTestSent
+	if delivery.Status != constants.WebhookDeliverySucceeded {
+		message = constants.MessageWebhookTestFailed
+	}
+	SuccessResponse(c, http.StatusOK, message, delivery)
+}
+
+// GetDeliveries handles GET /webhooks/:id/deliveries, optionally filtered with ?status=pending|succeeded|failed
+func (ctrl *WebhookController) GetDeliveries(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	params := GetPaginationParams(c)
+
+	deliveries, err := ctrl.webhookService.GetDeliveries(c.Request.Context(), id, c.Query("status"), params.Limit, params.Offset)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound {
+			NotFoundResponse(c, constants.ErrorWebhookNotFound)
+			return
+		}
+		if strings.HasPrefix(err.Error(), constants.ErrorWebhookInvalidDeliveryStatus) {
+			BadRequestResponse(c, constants.ErrorWebhookInvalidDeliveryStatus, err)
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, deliveries)
+}
+
+// GetDelivery handles GET /webhooks/:id/deliveries/:deliveryId
+func (ctrl *WebhookController) GetDelivery(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		BadRequestResponse(c, constants.ErrorInvalidUUID, err)
+		return
+	}
+
+	delivery, err := ctrl.webhookService.GetDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		if err.Error() == constants.ErrorWebhookNotFound || err.Error() == constants.ErrorWebhookDeliveryNotFound {
+			NotFoundResponse(c, err.Error())
+			return
+		}
+		InternalServerErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, http.StatusOK, constants.StatusOK, delivery)
+}
+
+// webhookInputError answers for errors caused by a bad webhook definition, reporting whether it did
+func webhookInputError(c *gin.Context, err error) bool {
+	switch {
+	case strings.HasPrefix(err.Error(), constants.ErrorValidationFailed):
+		ValidationErrorResponse(c, err)
+	case strings.HasPrefix(err.Error(), constants.ErrorWebhookInvalid):
+		BadRequestResponse(c, constants.ErrorWebhookInvalid, err)
+	case err.Error() == constants.ErrorTheatreNotFound || err.Error() == constants.ErrorShowTypeNotFound:
+		BadRequestResponse(c, err.Error(), err)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditQuery filters the audit log; every field is optional
+type AuditQuery struct {
+	Entity string // location, theatre_type, show_type, theatre or show
+	ID     string // entity ID
+	Action string // create, update, delete, restore or purge
+	Actor  string
+	From   string // RFC 3339, inclusive
+	To     string // RFC 3339, exclusive
+}
+
+// AuditEntry is one recorded change
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	Changes    json.RawMessage `json:"changes"` // field name to {"old": ..., "new": ...}
+	CreatedAt  time.Time       `json:"created_at"`
+}
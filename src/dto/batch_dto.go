@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// BatchOperation is a single create, update or delete within a batch request
+type BatchOperation struct {
+	Op         string          `json:"op"`                    // create, update or delete
+	ID         *uuid.UUID      `json:"id,omitempty"`          // required for update and delete
+	Data       json.RawMessage `json:"data,omitempty"`        // the resource body for create and update
+	ReassignTo *uuid.UUID      `json:"reassign_to,omitempty"` // where a delete moves what belongs to the record, under the reassign policy
+}
+
+// BatchItemResult reports the outcome of one batch operation with its HTTP status
+type BatchItemResult struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	ID     *uuid.UUID  `json:"id,omitempty"`
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// BatchResult summarizes a batch with per-operation results
+type BatchResult struct {
+	Resource  string            `json:"resource"`
+	Atomic    bool              `json:"atomic"`
+	Committed bool              `json:"committed"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"strings"
+	"theatre-management-system/src/constants"
+)
+
+// Violation is one way a request or response breaks the API contract
+type Violation struct {
+	In      string `json:"in"`             // path, query, body or response
+	Name    string `json:"name,omitempty"` // the parameter, or the field within the body such as event_types[0]
+	Message string `json:"message"`
+}
+
+// ContractViolations rejects a request that doesn't match the OpenAPI document, listing every problem
+type ContractViolations struct {
+	Violations []Violation `json:"violations"`
+}
+
+// Error lists the problems
+func (e *ContractViolations) Error() string {
+	problems := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		where := violation.In
+		if violation.Name != "" {
+			where += " " + violation.Name
+		}
+		problems[i] = where + ": " + violation.Message
+	}
+	return constants.ErrorContractViolation + ": " + strings.Join(problems, "; ")
+}
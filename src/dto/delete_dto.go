@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"fmt"
+	"theatre-management-system/src/constants"
+
+	"github.com/google/uuid"
+)
+
+// Dependent is a record that belongs to one being deleted
+type Dependent struct {
+	Type string    `json:"type"` // theatre or show
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// DeleteConflict refuses a delete while records still belong to the one being deleted, listing them
+type DeleteConflict struct {
+	Dependents []Dependent `json:"dependents"`
+}
+
+// Error reports how many records stand in the way
+func (e *DeleteConflict) Error() string {
+	noun := e.Dependents[0].Type + "s"
+	if len(e.Dependents) == 1 {
+		noun = e.Dependents[0].Type
+	}
+	return fmt.Sprintf("%s: %d %s must be deleted or moved first", constants.ErrorHasDependents, len(e.Dependents), noun)
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportOptions controls how an uploaded file is imported
+type ImportOptions struct {
+	Entity string `json:"entity"`  // locations, theatres or shows
+	Format string `json:"format"`  // csv or ndjson
+	Mode   string `json:"mode"`    // atomic (all-or-nothing) or partial (per-row)
+	DryRun bool   `json:"dry_run"` // validate everything, write nothing
+	Async  bool   `json:"async"`   // force a background job regardless of size
+}
+
+// ImportRowResult reports the outcome of a single imported row
+type ImportRowResult struct {
+	Row    int        `json:"row"` // line number in the uploaded file
+	Status string     `json:"status"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Errors []string   `json:"errors,omitempty"`
+}
+
+// ImportReport summarizes an import with per-row results
+type ImportReport struct {
+	Entity    string            `json:"entity"`
+	Format    string            `json:"format"`
+	Mode      string            `json:"mode"`
+	DryRun    bool              `json:"dry_run"`
+	Committed bool              `json:"committed"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// ImportJob tracks an import, which may run in the background
+type ImportJob struct {
+	ID          uuid.UUID     `json:"id"`
+	Status      string        `json:"status"`
+	Processed   int           `json:"processed"`
+	Total       int           `json:"total"`
+	Error       string        `json:"error,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Report      *ImportReport `json:"report,omitempty"`
+}
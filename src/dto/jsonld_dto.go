@@ -0,0 +1,82 @@
+package dto
+
+// TheaterEventLD is a schema.org TheaterEvent document for a show
+type TheaterEventLD struct {
+	Context             string                   `json:"@context,omitempty"`
+	Type                string                   `json:"@type"`
+	Name                string                   `json:"name"`
+	Description         string                   `json:"description,omitempty"`
+	Image               string                   `json:"image,omitempty"`
+	StartDate           string                   `json:"startDate,omitempty"`
+	EndDate             string                   `json:"endDate,omitempty"`
+	Duration            string                   `json:"duration,omitempty"`
+	Genre               string                   `json:"genre,omitempty"`
+	EventStatus         string                   `json:"eventStatus"`
+	EventAttendanceMode string                   `json:"eventAttendanceMode"`
+	Director            *PersonLD                `json:"director,omitempty"`
+	Performer           *PerformingGroupLD       `json:"performer,omitempty"`
+	Location            *PerformingArtsTheaterLD `json:"location,omitempty"`
+	Offers              *OfferLD                 `json:"offers,omitempty"`
+	SubjectOf           *VideoObjectLD           `json:"subjectOf,omitempty"`
+}
+
+// PerformingArtsTheaterLD is a schema.org PerformingArtsTheater document for a theatre
+type PerformingArtsTheaterLD struct {
+	Context         string            `json:"@context,omitempty"`
+	Type            string            `json:"@type"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	Image           string            `json:"image,omitempty"`
+	URL             string            `json:"url,omitempty"`
+	Telephone       string            `json:"telephone,omitempty"`
+	Email           string            `json:"email,omitempty"`
+	MaximumAttendee int               `json:"maximumAttendeeCapacity,omitempty"`
+	Address         *PostalAddressLD  `json:"address,omitempty"`
+	Geo             *GeoCoordinatesLD `json:"geo,omitempty"`
+	Event           []TheaterEventLD  `json:"event,omitempty"`
+}
+
+// PostalAddressLD is a schema.org PostalAddress
+type PostalAddressLD struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+// GeoCoordinatesLD is a schema.org GeoCoordinates
+type GeoCoordinatesLD struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// OfferLD is a schema.org Offer
+type OfferLD struct {
+	Type          string `json:"@type"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+	ValidFrom     string `json:"validFrom,omitempty"`
+}
+
+// PersonLD is a schema.org Person
+type PersonLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// PerformingGroupLD is a schema.org PerformingGroup
+type PerformingGroupLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// VideoObjectLD is a schema.org VideoObject
+type VideoObjectLD struct {
+	Type       string `json:"@type"`
+	Name       string `json:"name"`
+	ContentURL string `json:"contentUrl"`
+}
@@ -17,6 +17,7 @@ type LocationBase struct {
 	PostalCode  string   `json:"postal_code" validate:"max=20"`
 	Address     string   `json:"address" validate:"max=500"`
 	Description string   `json:"description" validate:"max=1000"`
+	Timezone    string   `json:"timezone" validate:"omitempty,timezone,max=64"` // Derived from coordinates when empty
 	IsActive    *bool    `json:"is_active,omitempty"`
 }
 
@@ -32,6 +33,7 @@ type LocationDetails struct {
 	PostalCode  string    `json:"postal_code"`
 	Address     string    `json:"address"`
 	Description string    `json:"description"`
+	Timezone    string    `json:"timezone"`
 	IsActive    bool      `json:"is_active"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -42,12 +44,14 @@ type LocationDetails struct {
 
 // LocationSummary contains summary location information for lists
 type LocationSummary struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	City      string    `json:"city"`
-	State     string    `json:"state"`
-	Country   string    `json:"country"`
-	Latitude  *float64  `json:"latitude"`
-	Longitude *float64  `json:"longitude"`
-	IsActive  bool      `json:"is_active"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	City      string     `json:"city"`
+	State     string     `json:"state"`
+	Country   string     `json:"country"`
+	Latitude  *float64   `json:"latitude"`
+	Longitude *float64   `json:"longitude"`
+	Timezone  string     `json:"timezone"`
+	IsActive  bool       `json:"is_active"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // set only in the trash
 }
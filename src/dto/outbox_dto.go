@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is an outbox row as shown to operators
+type OutboxMessage struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	EventName     string          `json:"event_name"`
+	Payload       json.RawMessage `json:"payload"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Status        string          `json:"status"` // pending, delivered or failed
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// OutboxEnvelope is what every sink receives for one domain event
+type OutboxEnvelope struct {
+	ID            uuid.UUID       `json:"id"` // same on every redelivery, so consumers can deduplicate
+	Event         string          `json:"event"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Sequence      int64           `json:"sequence"` // increases with each event of an aggregate
+	OccurredAt    time.Time       `json:"occurred_at"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// OutboxReplayResult reports how many messages a bulk replay queued
+type OutboxReplayResult struct {
+	Replayed int64 `json:"replayed"`
+}
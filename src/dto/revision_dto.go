@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RevisionSummary describes one revision of a show or theatre
+type RevisionSummary struct {
+	Revision     int       `json:"revision"`
+	Action       string    `json:"action"`                  // baseline, create, update or restore
+	RestoredFrom *int      `json:"restored_from,omitempty"` // the revision a restore copied
+	Actor        string    `json:"actor"`
+	RequestID    string    `json:"request_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RevisionDetails is a revision with the entity's editable fields as they stood after it
+type RevisionDetails struct {
+	RevisionSummary
+	Snapshot json.RawMessage `json:"snapshot"` // shaped like the create/update request body
+}
+
+// RevisionDiff lists the fields that differ between two revisions of the same entity
+type RevisionDiff struct {
+	From    int                    `json:"from"`
+	To      int                    `json:"to"`
+	Changes map[string]FieldChange `json:"changes"`
+}
+
+// FieldChange is one field's value in the older and newer of two versions
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
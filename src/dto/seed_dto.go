@@ -0,0 +1,53 @@
+package dto
+
+// SeedFixture is a fixture set: records to upsert by natural key, plus optional synthetic data
+type SeedFixture struct {
+	Description  string             `json:"description,omitempty"`
+	TheatreTypes []TheatreTypeBase  `json:"theatre_types,omitempty"`
+	ShowTypes    []ShowTypeBase     `json:"show_types,omitempty"`
+	Locations    []LocationBase     `json:"locations,omitempty"`
+	Theatres     []SeedTheatre      `json:"theatres,omitempty"`
+	Shows        []SeedShow         `json:"shows,omitempty"`
+	Generate     *SeedGenerateCount `json:"generate,omitempty"`
+}
+
+// SeedTheatre is a theatre fixture that references its type and location by natural key
+type SeedTheatre struct {
+	TheatreBase
+	TheatreType  string `json:"theatre_type"`
+	LocationName string `json:"location_name"`
+	LocationCity string `json:"location_city"`
+}
+
+// SeedShow is a show fixture that references its type and theatre by natural key
+type SeedShow struct {
+	ShowBase
+	ShowType    string `json:"show_type"`
+	TheatreName string `json:"theatre_name"`
+	TheatreCity string `json:"theatre_city"`
+}
+
+// SeedGenerateCount asks for synthetic records on top of a fixture's own
+type SeedGenerateCount struct {
+	Locations  int    `json:"locations"` // 0 spreads theatres over the fixture's locations
+	Theatres   int    `json:"theatres"`
+	Shows      int    `json:"shows"`
+	RandomSeed int64  `json:"random_seed"`           // the same seed produces the same records, so reruns update instead of duplicating
+	NamePrefix string `json:"name_prefix,omitempty"` // starts generated location and theatre names, keeping them apart from other sets' records
+}
+
+// SeedEntityCounts reports how many records of one kind were created, updated or already matched the fixture
+type SeedEntityCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+// SeedReport summarizes a seed run
+type SeedReport struct {
+	TheatreTypes SeedEntityCounts `json:"theatre_types"`
+	ShowTypes    SeedEntityCounts `json:"show_types"`
+	Locations    SeedEntityCounts `json:"locations"`
+	Theatres     SeedEntityCounts `json:"theatres"`
+	Shows        SeedEntityCounts `json:"shows"`
+}
@@ -34,6 +34,9 @@ type ShowDetails struct {
 	Duration    int        `json:"duration"`
 	StartDate   *time.Time `json:"start_date"`
 	EndDate     *time.Time `json:"end_date"`
+	Timezone    string     `json:"timezone"`
+	StartLocal  string     `json:"start_local"`
+	EndLocal    string     `json:"end_local"`
 	Price       float64    `json:"price"`
 	ImageURL    string     `json:"image_url"`
 	TrailerURL  string     `json:"trailer_url"`
@@ -58,6 +61,9 @@ type ShowSummary struct {
 	Duration    int             `json:"duration"`
 	StartDate   *time.Time      `json:"start_date"`
 	EndDate     *time.Time      `json:"end_date"`
+	Timezone    string          `json:"timezone"`
+	StartLocal  string          `json:"start_local"`
+	EndLocal    string          `json:"end_local"`
 	Price       float64         `json:"price"`
 	ImageURL    string          `json:"image_url"`
 	IsFeatured  bool            `json:"is_featured"`
@@ -66,4 +72,5 @@ type ShowSummary struct {
 	ShowTypeID  uuid.UUID       `json:"show_type_id"`
 	Theatre     TheatreSummary  `json:"theatre"`
 	ShowType    ShowTypeSummary `json:"show_type"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"` // set only in the trash
 }
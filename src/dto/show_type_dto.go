@@ -28,8 +28,9 @@ type ShowTypeDetails struct {
 
 // ShowTypeSummary contains summary show type information for lists
 type ShowTypeSummary struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IsActive    bool      `json:"is_active"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // set only in the trash
 }
@@ -59,4 +59,5 @@ type TheatreSummary struct {
 	TheatreTypeID uuid.UUID          `json:"theatre_type_id"`
 	Location      LocationSummary    `json:"location"`
 	TheatreType   TheatreTypeSummary `json:"theatre_type"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty"` // set only in the trash
 }
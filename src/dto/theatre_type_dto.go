@@ -28,8 +28,9 @@ type TheatreTypeDetails struct {
 
 // TheatreTypeSummary contains summary theatre type information for lists
 type TheatreTypeSummary struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IsActive    bool      `json:"is_active"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // set only in the trash
 }
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookBase contains webhook subscription information for creation/updates
+type WebhookBase struct {
+	URL         string     `json:"url" validate:"required,url,max=500"`
+	Secret      string     `json:"secret,omitempty" validate:"omitempty,min=16,max=255"` // generated on create, kept on update, when empty
+	Description string     `json:"description" validate:"max=1000"`
+	EventTypes  []string   `json:"event_types" validate:"required,min=1,dive,required,max=100"` // event names, "<entity>.*" or "*"
+	TheatreID   *uuid.UUID `json:"theatre_id,omitempty"`
+	ShowTypeID  *uuid.UUID `json:"show_type_id,omitempty"`
+	IsActive    *bool      `json:"is_active,omitempty"` // re-enabling a disabled webhook clears its failures
+}
+
+// WebhookDetails contains detailed webhook subscription information
+type WebhookDetails struct {
+	ID                  uuid.UUID  `json:"id"`
+	URL                 string     `json:"url"`
+	Secret              string     `json:"secret,omitempty"` // only returned when the webhook is created
+	Description         string     `json:"description"`
+	EventTypes          []string   `json:"event_types"`
+	TheatreID           *uuid.UUID `json:"theatre_id,omitempty"`
+	ShowTypeID          *uuid.UUID `json:"show_type_id,omitempty"`
+	IsActive            bool       `json:"is_active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookDeliverySummary contains summary delivery information for lists
+type WebhookDeliverySummary struct {
+	ID                 uuid.UUID  `json:"id"`
+	WebhookID          uuid.UUID  `json:"webhook_id"`
+	EventName          string     `json:"event_name"`
+	Status             string     `json:"status"` // pending, succeeded or failed
+	Attempts           int        `json:"attempts"`
+	NextAttemptAt      *time.Time `json:"next_attempt_at,omitempty"` // only while pending
+	LastResponseStatus *int       `json:"last_response_status,omitempty"`
+	LastError          string     `json:"last_error,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+}
+
+// WebhookDeliveryDetails contains a delivery with its payload and every attempt made
+type WebhookDeliveryDetails struct {
+	ID                 uuid.UUID                `json:"id"`
+	WebhookID          uuid.UUID                `json:"webhook_id"`
+	EventName          string                   `json:"event_name"`
+	Status             string                   `json:"status"`
+	Attempts           int                      `json:"attempts"`
+	NextAttemptAt      *time.Time               `json:"next_attempt_at,omitempty"`
+	LastResponseStatus *int                     `json:"last_response_status,omitempty"`
+	LastError          string                   `json:"last_error,omitempty"`
+	CreatedAt          time.Time                `json:"created_at"`
+	CompletedAt        *time.Time               `json:"completed_at,omitempty"`
+	Payload            json.RawMessage          `json:"payload"` // the exact body that was signed and sent
+	Log                []WebhookDeliveryAttempt `json:"log"`
+}
+
+// WebhookDeliveryAttempt describes one HTTP request made for a delivery
+type WebhookDeliveryAttempt struct {
+	Attempt        int       `json:"attempt"`
+	ResponseStatus *int      `json:"response_status,omitempty"`
+	ResponseBody   string    `json:"response_body,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	DurationMs     int       `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -1,72 +1,219 @@
 package interfaces
 
 import (
+	"context"
 	"theatre-management-system/src/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // LocationRepository defines the interface for location data access
 type LocationRepository interface {
-	Create(location *models.Location) error
-	GetByID(id uuid.UUID) (*models.Location, error)
-	GetAll(limit, offset int) ([]*models.Location, error)
-	Update(location *models.Location) error
-	Delete(id uuid.UUID) error
-	GetByCoordinates(latitude, longitude, radius float64) ([]*models.Location, error)
-	GetActiveLocations() ([]*models.Location, error)
-	Search(query string) ([]*models.Location, error)
+	Create(ctx context.Context, location *models.Location) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Location, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.Location, error)
+	Update(ctx context.Context, location *models.Location) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDeleted(ctx context.Context, limit, offset int) ([]*models.Location, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Location, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently deletes a soft-deleted row, unless other rows still refer to it, and reports whether it did
+	Purge(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+	LockForShare(ctx context.Context, id uuid.UUID) error
+	LockForUpdate(ctx context.Context, id uuid.UUID) error
+	GetByCoordinates(ctx context.Context, latitude, longitude, radius float64) ([]*models.Location, error)
+	GetByNameAndCity(ctx context.Context, name, city string) (*models.Location, error)
+	GetActiveLocations(ctx context.Context) ([]*models.Location, error)
+	Search(ctx context.Context, query string) ([]*models.Location, error)
 }
 
 // TheatreTypeRepository defines the interface for theatre type data access
 type TheatreTypeRepository interface {
-	Create(theatreType *models.TheatreType) error
-	GetByID(id uuid.UUID) (*models.TheatreType, error)
-	GetAll(limit, offset int) ([]*models.TheatreType, error)
-	Update(theatreType *models.TheatreType) error
-	Delete(id uuid.UUID) error
-	GetByName(name string) (*models.TheatreType, error)
-	GetActiveTypes() ([]*models.TheatreType, error)
+	Create(ctx context.Context, theatreType *models.TheatreType) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.TheatreType, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.TheatreType, error)
+	Update(ctx context.Context, theatreType *models.TheatreType) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDeleted(ctx context.Context, limit, offset int) ([]*models.TheatreType, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.TheatreType, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently deletes a soft-deleted row, unless other rows still refer to it, and reports whether it did
+	Purge(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+	LockForShare(ctx context.Context, id uuid.UUID) error
+	LockForUpdate(ctx context.Context, id uuid.UUID) error
+	GetByName(ctx context.Context, name string) (*models.TheatreType, error)
+	GetActiveTypes(ctx context.Context) ([]*models.TheatreType, error)
 }
 
 // ShowTypeRepository defines the interface for show type data access
 type ShowTypeRepository interface {
-	Create(showType *models.ShowType) error
-	GetByID(id uuid.UUID) (*models.ShowType, error)
-	GetAll(limit, offset int) ([]*models.ShowType, error)
-	Update(showType *models.ShowType) error
-	Delete(id uuid.UUID) error
-	GetByName(name string) (*models.ShowType, error)
-	GetActiveTypes() ([]*models.ShowType, error)
+	Create(ctx context.Context, showType *models.ShowType) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ShowType, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.ShowType, error)
+	Update(ctx context.Context, showType *models.ShowType) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDeleted(ctx context.Context, limit, offset int) ([]*models.ShowType, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.ShowType, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently deletes a soft-deleted row, unless other rows still refer to it, and reports whether it did
+	Purge(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+	LockForShare(ctx context.Context, id uuid.UUID) error
+	LockForUpdate(ctx context.Context, id uuid.UUID) error
+	GetByName(ctx context.Context, name string) (*models.ShowType, error)
+	GetActiveTypes(ctx context.Context) ([]*models.ShowType, error)
 }
 
 // TheatreRepository defines the interface for theatre data access
 type TheatreRepository interface {
-	Create(theatre *models.Theatre) error
-	GetByID(id uuid.UUID) (*models.Theatre, error)
-	GetAll(limit, offset int) ([]*models.Theatre, error)
-	Update(theatre *models.Theatre) error
-	Delete(id uuid.UUID) error
-	GetByLocationID(locationID uuid.UUID) ([]*models.Theatre, error)
-	GetByTheatreTypeID(theatreTypeID uuid.UUID) ([]*models.Theatre, error)
-	GetFeaturedTheatres() ([]*models.Theatre, error)
-	GetActiveTheatres() ([]*models.Theatre, error)
-	Search(query string) ([]*models.Theatre, error)
-	GetNearbyTheatres(latitude, longitude, radius float64) ([]*models.Theatre, error)
+	Create(ctx context.Context, theatre *models.Theatre) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Theatre, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.Theatre, error)
+	Update(ctx context.Context, theatre *models.Theatre) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDeleted(ctx context.Context, limit, offset int) ([]*models.Theatre, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Theatre, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently deletes a soft-deleted row, unless other rows still refer to it, and reports whether it did
+	Purge(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+	LockForShare(ctx context.Context, id uuid.UUID) error
+	LockForUpdate(ctx context.Context, id uuid.UUID) error
+	GetByLocationID(ctx context.Context, locationID uuid.UUID) ([]*models.Theatre, error)
+	GetByNameAndCity(ctx context.Context, name, city string) (*models.Theatre, error)
+	GetByTheatreTypeID(ctx context.Context, theatreTypeID uuid.UUID) ([]*models.Theatre, error)
+	GetFeaturedTheatres(ctx context.Context) ([]*models.Theatre, error)
+	GetActiveTheatres(ctx context.Context) ([]*models.Theatre, error)
+	Search(ctx context.Context, query string) ([]*models.Theatre, error)
+	GetNearbyTheatres(ctx context.Context, latitude, longitude, radius float64) ([]*models.Theatre, error)
 }
 
 // ShowRepository defines the interface for show data access
 type ShowRepository interface {
-	Create(show *models.Show) error
-	GetByID(id uuid.UUID) (*models.Show, error)
-	GetAll(limit, offset int) ([]*models.Show, error)
-	Update(show *models.Show) error
-	Delete(id uuid.UUID) error
-	GetByTheatreID(theatreID uuid.UUID) ([]*models.Show, error)
-	GetByShowTypeID(showTypeID uuid.UUID) ([]*models.Show, error)
-	GetFeaturedShows() ([]*models.Show, error)
-	GetActiveShows() ([]*models.Show, error)
-	GetCurrentShows() ([]*models.Show, error)
-	GetUpcomingShows() ([]*models.Show, error)
-	Search(query string) ([]*models.Show, error)
+	Create(ctx context.Context, show *models.Show) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Show, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.Show, error)
+	Update(ctx context.Context, show *models.Show) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDeleted(ctx context.Context, limit, offset int) ([]*models.Show, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Show, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge permanently deletes a soft-deleted row, unless other rows still refer to it, and reports whether it did
+	Purge(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error)
+	GetByTheatreID(ctx context.Context, theatreID uuid.UUID) ([]*models.Show, error)
+	GetByTitleAndTheatreID(ctx context.Context, title string, theatreID uuid.UUID) (*models.Show, error)
+	GetByShowTypeID(ctx context.Context, showTypeID uuid.UUID) ([]*models.Show, error)
+	GetFeaturedShows(ctx context.Context) ([]*models.Show, error)
+	GetActiveShows(ctx context.Context) ([]*models.Show, error)
+	GetCurrentShows(ctx context.Context) ([]*models.Show, error)
+	GetUpcomingShows(ctx context.Context) ([]*models.Show, error)
+	Search(ctx context.Context, query string) ([]*models.Show, error)
+}
+
+// OutboxRepository defines the interface for outbox message storage and delivery bookkeeping
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...*models.OutboxMessage) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.OutboxMessage, error)
+	List(ctx context.Context, status string, limit, offset int) ([]*models.OutboxMessage, error)
+
+	// ClaimDue leases up to limit due messages, at most the oldest undelivered one per aggregate,
+	// hiding them from other relays until the lease expires and counting the attempt
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxMessage, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	MarkRetry(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string) error
+
+	// Replay makes an undelivered message due now with a fresh set of attempts
+	Replay(ctx context.Context, id uuid.UUID) error
+	ReplayFailed(ctx context.Context) (int64, error)
+	DeleteDeliveredBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// WebhookRepository defines the interface for webhook subscriptions and their delivery logs
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*models.WebhookSubscription, error)
+	GetActive(ctx context.Context) ([]*models.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// RecordSuccess clears a subscription's run of failures
+	RecordSuccess(ctx context.Context, id uuid.UUID) error
+	// RecordFailure counts a failed delivery, disabling the subscription once disableAfter failures in a row
+	// have been seen, and reports whether this failure disabled it
+	RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int, reason string) (bool, error)
+
+	// CreateDeliveries queues deliveries, skipping any already queued for the same subscription and outbox message
+	CreateDeliveries(ctx context.Context, deliveries ...*models.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*models.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, subscriptionID, id uuid.UUID) (*models.WebhookDelivery, error)
+
+	// ClaimDueDeliveries leases up to limit due deliveries of active subscriptions, with their subscription loaded,
+	// hiding them from other dispatchers until the lease expires and counting the attempt
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	// SaveAttempt logs an attempt and stores the delivery's resulting status
+	SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error
+	DeleteDeliveriesCompletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// AuditRepository defines the interface for audit log storage
+type AuditRepository interface {
+	Append(ctx context.Context, entries ...*models.AuditEntry) error
+	List(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]*models.AuditEntry, error)
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// ShowRevisionRepository defines the interface for show revision history
+type ShowRevisionRepository interface {
+	Append(ctx context.Context, revision *models.ShowRevision) error
+	// GetLatest returns the show's highest-numbered revision, or gorm.ErrRecordNotFound if it has none yet
+	GetLatest(ctx context.Context, showID uuid.UUID) (*models.ShowRevision, error)
+	GetByRevision(ctx context.Context, showID uuid.UUID, revision int) (*models.ShowRevision, error)
+	GetByShowID(ctx context.Context, showID uuid.UUID, limit, offset int) ([]*models.ShowRevision, error)
+}
+
+// TheatreRevisionRepository defines the interface for theatre revision history
+type TheatreRevisionRepository interface {
+	Append(ctx context.Context, revision *models.TheatreRevision) error
+	// GetLatest returns the theatre's highest-numbered revision, or gorm.ErrRecordNotFound if it has none yet
+	GetLatest(ctx context.Context, theatreID uuid.UUID) (*models.TheatreRevision, error)
+	GetByRevision(ctx context.Context, theatreID uuid.UUID, revision int) (*models.TheatreRevision, error)
+	GetByTheatreID(ctx context.Context, theatreID uuid.UUID, limit, offset int) ([]*models.TheatreRevision, error)
+}
+
+// ImportJobRepository defines the interface for import job storage
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error)
+	Update(ctx context.Context, job *models.ImportJob) error
+	// DeleteUpdatedBefore removes jobs that haven't changed since cutoff, returning how many there were
+	DeleteUpdatedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// UnitOfWork provides repositories that share one database handle and runs work atomically across them
+type UnitOfWork interface {
+	Locations() LocationRepository
+	TheatreTypes() TheatreTypeRepository
+	ShowTypes() ShowTypeRepository
+	Theatres() TheatreRepository
+	Shows() ShowRepository
+	Outbox() OutboxRepository
+	Webhooks() WebhookRepository
+	Audit() AuditRepository
+	ShowRevisions() ShowRevisionRepository
+	TheatreRevisions() TheatreRevisionRepository
+	ImportJobs() ImportJobRepository
+
+	// Do runs fn in a transaction with repositories scoped to it, rolling back if fn returns an error.
+	// Calling Do on a transaction-scoped unit of work nests, rolling back only the inner work.
+	Do(ctx context.Context, fn func(tx UnitOfWork) error) error
+
+	// AfterCommit runs fn once the outermost transaction commits, or straight away outside a transaction;
+	// it never runs if that transaction, or the savepoint fn was queued in, rolls back
+	AfterCommit(fn func())
 }
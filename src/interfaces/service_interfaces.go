@@ -1,72 +1,210 @@
 package interfaces
 
 import (
+	"context"
 	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
 
 	"github.com/google/uuid"
 )
 
 // LocationService defines the interface for location business logic
 type LocationService interface {
-	CreateLocation(location *dto.LocationBase) (*dto.LocationDetails, error)
-	GetLocationByID(id uuid.UUID) (*dto.LocationDetails, error)
-	GetAllLocations(limit, offset int) ([]*dto.LocationSummary, error)
-	UpdateLocation(id uuid.UUID, location *dto.LocationBase) (*dto.LocationDetails, error)
-	DeleteLocation(id uuid.UUID) error
-	GetLocationsByCoordinates(latitude, longitude, radius float64) ([]*dto.LocationSummary, error)
-	GetActiveLocations() ([]*dto.LocationSummary, error)
-	SearchLocations(query string) ([]*dto.LocationSummary, error)
+	CreateLocation(ctx context.Context, location *dto.LocationBase) (*dto.LocationDetails, error)
+	GetLocationByID(ctx context.Context, id uuid.UUID) (*dto.LocationDetails, error)
+	GetAllLocations(ctx context.Context, limit, offset int) ([]*dto.LocationSummary, error)
+	UpdateLocation(ctx context.Context, id uuid.UUID, location *dto.LocationBase) (*dto.LocationDetails, error)
+	DeleteLocation(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
+	GetDeletedLocations(ctx context.Context, limit, offset int) ([]*dto.LocationSummary, error)
+	RestoreLocation(ctx context.Context, id uuid.UUID) (*dto.LocationDetails, error)
+	PurgeLocation(ctx context.Context, id uuid.UUID) error
+	GetLocationsByCoordinates(ctx context.Context, latitude, longitude, radius float64) ([]*dto.LocationSummary, error)
+	GetLocationByNameAndCity(ctx context.Context, name, city string) (*dto.LocationDetails, error)
+	GetActiveLocations(ctx context.Context) ([]*dto.LocationSummary, error)
+	SearchLocations(ctx context.Context, query string) ([]*dto.LocationSummary, error)
 }
 
 // TheatreTypeService defines the interface for theatre type business logic
 type TheatreTypeService interface {
-	CreateTheatreType(theatreType *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error)
-	GetTheatreTypeByID(id uuid.UUID) (*dto.TheatreTypeDetails, error)
-	GetAllTheatreTypes(limit, offset int) ([]*dto.TheatreTypeSummary, error)
-	UpdateTheatreType(id uuid.UUID, theatreType *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error)
-	DeleteTheatreType(id uuid.UUID) error
-	GetTheatreTypeByName(name string) (*dto.TheatreTypeDetails, error)
-	GetActiveTheatreTypes() ([]*dto.TheatreTypeSummary, error)
+	CreateTheatreType(ctx context.Context, theatreType *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error)
+	GetTheatreTypeByID(ctx context.Context, id uuid.UUID) (*dto.TheatreTypeDetails, error)
+	GetAllTheatreTypes(ctx context.Context, limit, offset int) ([]*dto.TheatreTypeSummary, error)
+	UpdateTheatreType(ctx context.Context, id uuid.UUID, theatreType *dto.TheatreTypeBase) (*dto.TheatreTypeDetails, error)
+	DeleteTheatreType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
+	GetDeletedTheatreTypes(ctx context.Context, limit, offset int) ([]*dto.TheatreTypeSummary, error)
+	RestoreTheatreType(ctx context.Context, id uuid.UUID) (*dto.TheatreTypeDetails, error)
+	PurgeTheatreType(ctx context.Context, id uuid.UUID) error
+	GetTheatreTypeByName(ctx context.Context, name string) (*dto.TheatreTypeDetails, error)
+	GetActiveTheatreTypes(ctx context.Context) ([]*dto.TheatreTypeSummary, error)
 }
 
 // ShowTypeService defines the interface for show type business logic
 type ShowTypeService interface {
-	CreateShowType(showType *dto.ShowTypeBase) (*dto.ShowTypeDetails, error)
-	GetShowTypeByID(id uuid.UUID) (*dto.ShowTypeDetails, error)
-	GetAllShowTypes(limit, offset int) ([]*dto.ShowTypeSummary, error)
-	UpdateShowType(id uuid.UUID, showType *dto.ShowTypeBase) (*dto.ShowTypeDetails, error)
-	DeleteShowType(id uuid.UUID) error
-	GetShowTypeByName(name string) (*dto.ShowTypeDetails, error)
-	GetActiveShowTypes() ([]*dto.ShowTypeSummary, error)
+	CreateShowType(ctx context.Context, showType *dto.ShowTypeBase) (*dto.ShowTypeDetails, error)
+	GetShowTypeByID(ctx context.Context, id uuid.UUID) (*dto.ShowTypeDetails, error)
+	GetAllShowTypes(ctx context.Context, limit, offset int) ([]*dto.ShowTypeSummary, error)
+	UpdateShowType(ctx context.Context, id uuid.UUID, showType *dto.ShowTypeBase) (*dto.ShowTypeDetails, error)
+	DeleteShowType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
+	GetDeletedShowTypes(ctx context.Context, limit, offset int) ([]*dto.ShowTypeSummary, error)
+	RestoreShowType(ctx context.Context, id uuid.UUID) (*dto.ShowTypeDetails, error)
+	PurgeShowType(ctx context.Context, id uuid.UUID) error
+	GetShowTypeByName(ctx context.Context, name string) (*dto.ShowTypeDetails, error)
+	GetActiveShowTypes(ctx context.Context) ([]*dto.ShowTypeSummary, error)
 }
 
 // TheatreService defines the interface for theatre business logic
 type TheatreService interface {
-	CreateTheatre(theatre *dto.TheatreBase) (*dto.TheatreDetails, error)
-	GetTheatreByID(id uuid.UUID) (*dto.TheatreDetails, error)
-	GetAllTheatres(limit, offset int) ([]*dto.TheatreSummary, error)
-	UpdateTheatre(id uuid.UUID, theatre *dto.TheatreBase) (*dto.TheatreDetails, error)
-	DeleteTheatre(id uuid.UUID) error
-	GetTheatresByLocationID(locationID uuid.UUID) ([]*dto.TheatreSummary, error)
-	GetTheatresByTheatreTypeID(theatreTypeID uuid.UUID) ([]*dto.TheatreSummary, error)
-	GetFeaturedTheatres() ([]*dto.TheatreSummary, error)
-	GetActiveTheatres() ([]*dto.TheatreSummary, error)
-	SearchTheatres(query string) ([]*dto.TheatreSummary, error)
-	GetNearbyTheatres(latitude, longitude, radius float64) ([]*dto.TheatreSummary, error)
+	CreateTheatre(ctx context.Context, theatre *dto.TheatreBase) (*dto.TheatreDetails, error)
+	GetTheatreByID(ctx context.Context, id uuid.UUID) (*dto.TheatreDetails, error)
+	GetTheatreStructuredData(ctx context.Context, id uuid.UUID) (*dto.PerformingArtsTheaterLD, error)
+	GetAllTheatres(ctx context.Context, limit, offset int) ([]*dto.TheatreSummary, error)
+	UpdateTheatre(ctx context.Context, id uuid.UUID, theatre *dto.TheatreBase) (*dto.TheatreDetails, error)
+	DeleteTheatre(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
+	GetDeletedTheatres(ctx context.Context, limit, offset int) ([]*dto.TheatreSummary, error)
+	RestoreTheatre(ctx context.Context, id uuid.UUID) (*dto.TheatreDetails, error)
+	PurgeTheatre(ctx context.Context, id uuid.UUID) error
+	GetTheatresByLocationID(ctx context.Context, locationID uuid.UUID) ([]*dto.TheatreSummary, error)
+	GetTheatreByNameAndCity(ctx context.Context, name, city string) (*dto.TheatreDetails, error)
+	GetTheatresByTheatreTypeID(ctx context.Context, theatreTypeID uuid.UUID) ([]*dto.TheatreSummary, error)
+	GetFeaturedTheatres(ctx context.Context) ([]*dto.TheatreSummary, error)
+	GetActiveTheatres(ctx context.Context) ([]*dto.TheatreSummary, error)
+	SearchTheatres(ctx context.Context, query string) ([]*dto.TheatreSummary, error)
+	GetNearbyTheatres(ctx context.Context, latitude, longitude, radius float64) ([]*dto.TheatreSummary, error)
+	GetTheatreRevisions(ctx context.Context, id uuid.UUID, limit, offset int) ([]*dto.RevisionSummary, error)
+	GetTheatreRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.RevisionDetails, error)
+	DiffTheatreRevisions(ctx context.Context, id uuid.UUID, from, to int) (*dto.RevisionDiff, error)
+	RestoreTheatreRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.TheatreDetails, error)
 }
 
 // ShowService defines the interface for show business logic
 type ShowService interface {
-	CreateShow(show *dto.ShowBase) (*dto.ShowDetails, error)
-	GetShowByID(id uuid.UUID) (*dto.ShowDetails, error)
-	GetAllShows(limit, offset int) ([]*dto.ShowSummary, error)
-	UpdateShow(id uuid.UUID, show *dto.ShowBase) (*dto.ShowDetails, error)
-	DeleteShow(id uuid.UUID) error
-	GetShowsByTheatreID(theatreID uuid.UUID) ([]*dto.ShowSummary, error)
-	GetShowsByShowTypeID(showTypeID uuid.UUID) ([]*dto.ShowSummary, error)
-	GetFeaturedShows() ([]*dto.ShowSummary, error)
-	GetActiveShows() ([]*dto.ShowSummary, error)
-	GetCurrentShows() ([]*dto.ShowSummary, error)
-	GetUpcomingShows() ([]*dto.ShowSummary, error)
-	SearchShows(query string) ([]*dto.ShowSummary, error)
+	CreateShow(ctx context.Context, show *dto.ShowBase) (*dto.ShowDetails, error)
+	GetShowByID(ctx context.Context, id uuid.UUID) (*dto.ShowDetails, error)
+	GetShowStructuredData(ctx context.Context, id uuid.UUID) (*dto.TheaterEventLD, error)
+	GetAllShows(ctx context.Context, limit, offset int) ([]*dto.ShowSummary, error)
+	UpdateShow(ctx context.Context, id uuid.UUID, show *dto.ShowBase) (*dto.ShowDetails, error)
+	DeleteShow(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
+	GetDeletedShows(ctx context.Context, limit, offset int) ([]*dto.ShowSummary, error)
+	RestoreShow(ctx context.Context, id uuid.UUID) (*dto.ShowDetails, error)
+	PurgeShow(ctx context.Context, id uuid.UUID) error
+	GetShowsByTheatreID(ctx context.Context, theatreID uuid.UUID) ([]*dto.ShowSummary, error)
+	GetShowsByShowTypeID(ctx context.Context, showTypeID uuid.UUID) ([]*dto.ShowSummary, error)
+	GetFeaturedShows(ctx context.Context) ([]*dto.ShowSummary, error)
+	GetActiveShows(ctx context.Context) ([]*dto.ShowSummary, error)
+	GetCurrentShows(ctx context.Context) ([]*dto.ShowSummary, error)
+	GetUpcomingShows(ctx context.Context) ([]*dto.ShowSummary, error)
+	SearchShows(ctx context.Context, query string) ([]*dto.ShowSummary, error)
+	GetShowRevisions(ctx context.Context, id uuid.UUID, limit, offset int) ([]*dto.RevisionSummary, error)
+	GetShowRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.RevisionDetails, error)
+	DiffShowRevisions(ctx context.Context, id uuid.UUID, from, to int) (*dto.RevisionDiff, error)
+	RestoreShowRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.ShowDetails, error)
+}
+
+// CalendarService defines the interface for iCalendar feed generation
+type CalendarService interface {
+	GetTheatreCalendar(ctx context.Context, theatreID uuid.UUID, showTypeID *uuid.UUID) ([]byte, error)
+	GetShowCalendar(ctx context.Context, showID uuid.UUID) ([]byte, error)
+}
+
+// Worker is a component with background work that must finish or stop before the process exits
+type Worker interface {
+	Shutdown(ctx context.Context) error
+}
+
+// ImportService defines the interface for bulk file imports
+type ImportService interface {
+	StartImport(ctx context.Context, data []byte, options dto.ImportOptions) (*dto.ImportJob, error)
+	GetImportJob(ctx context.Context, id uuid.UUID) (*dto.ImportJob, error)
+	Worker
+}
+
+// BatchService defines the interface for batched create/update/delete operations
+type BatchService interface {
+	ExecuteBatch(ctx context.Context, resource string, operations []dto.BatchOperation, atomic bool) (*dto.BatchResult, error)
+}
+
+// SeedService defines the interface for loading fixture data
+type SeedService interface {
+	Seed(ctx context.Context, fixture *dto.SeedFixture) (*dto.SeedReport, error)
+}
+
+// BusinessMetrics counts domain activity for monitoring
+type BusinessMetrics interface {
+	EntitiesCreated(entity string, count int)
+	SearchExecuted(entity string)
+}
+
+// CacheMetrics counts cache lookups and evictions for monitoring
+type CacheMetrics interface {
+	CacheHit()
+	CacheMiss()
+	CacheEviction()
+}
+
+// Event is a domain event, published once the change it describes has committed
+type Event interface {
+	EventName() string
+	EntityID() uuid.UUID
+}
+
+// EventHandler reacts to a published domain event
+type EventHandler func(ctx context.Context, event Event)
+
+// EventPublisher hands domain events to their subscribers
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+// EventBus delivers domain events to synchronous and asynchronous subscribers
+type EventBus interface {
+	EventPublisher
+
+	// Subscribe runs handler in the publisher's goroutine before Publish returns; "*" subscribes to every event
+	Subscribe(eventName string, handler EventHandler)
+
+	// SubscribeAsync runs handler in its own goroutine, so slow subscribers never hold up the request
+	SubscribeAsync(eventName string, handler EventHandler)
+
+	Worker
+}
+
+// OutboxService defines the interface for inspecting and replaying outbox messages
+type OutboxService interface {
+	ListMessages(ctx context.Context, status string, limit, offset int) ([]*dto.OutboxMessage, error)
+	GetMessage(ctx context.Context, id uuid.UUID) (*dto.OutboxMessage, error)
+	ReplayMessage(ctx context.Context, id uuid.UUID) (*dto.OutboxMessage, error)
+	ReplayFailed(ctx context.Context) (*dto.OutboxReplayResult, error)
+}
+
+// OutboxSink delivers outbox messages to one destination; a nil error means the destination has accepted the message
+type OutboxSink interface {
+	Name() string
+	Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error
+	Close() error
+}
+
+// WebhookService defines the interface for webhook subscriptions, their delivery logs and test events
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook *dto.WebhookBase) (*dto.WebhookDetails, error)
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*dto.WebhookDetails, error)
+	GetAllWebhooks(ctx context.Context, limit, offset int) ([]*dto.WebhookDetails, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, webhook *dto.WebhookBase) (*dto.WebhookDetails, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	SendTestEvent(ctx context.Context, id uuid.UUID) (*dto.WebhookDeliveryDetails, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, limit, offset int) ([]*dto.WebhookDeliverySummary, error)
+	GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*dto.WebhookDeliveryDetails, error)
+}
+
+// WebhookSender makes one signed HTTP attempt at a delivery, describing the outcome as an attempt to log
+type WebhookSender interface {
+	Send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) *models.WebhookDeliveryAttempt
+
+	// CheckURL fails unless url's host resolves only to public addresses, the only ones Send connects to
+	CheckURL(ctx context.Context, url string) error
+}
+
+// AuditService defines the interface for querying the audit log; as a worker it purges entries past their retention
+type AuditService interface {
+	ListEntries(ctx context.Context, query dto.AuditQuery, limit, offset int) ([]*dto.AuditEntry, error)
+	Worker
 }
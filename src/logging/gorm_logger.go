@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// gormLogger writes GORM's query logs through slog, so they carry the request ID of the query's context
+type gormLogger struct {
+	logger        *slog.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger logs failed queries, and queries slower than the configured threshold; every query is only logged at debug
+func NewGormLogger(l *slog.Logger, cfg config.LoggingConfig) logger.Interface {
+	level := logger.Warn
+	switch cfg.Level {
+	case constants.LogLevelDebug:
+		level = logger.Info
+	case constants.LogLevelError:
+		level = logger.Error
+	}
+
+	return &gormLogger{
+		logger:        l,
+		level:         level,
+		slowThreshold: cfg.SlowQueryThreshold,
+	}
+}
+
+// LogMode returns a copy logging at the given level
+func (g *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *g
+	copied.level = level
+	return &copied
+}
+
+// Info logs a GORM informational message
+func (g *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Info {
+		g.logger.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn logs a GORM warning
+func (g *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Warn {
+		g.logger.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error logs a GORM error
+func (g *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= logger.Error {
+		g.logger.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace logs a finished query with its duration and affected rows
+func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	record := func(level slog.Level, msg string, extra ...slog.Attr) {
+		sql, rows := fc()
+		attrs := append([]slog.Attr{
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+			slog.String("source", utils.FileWithLineNum()),
+		}, extra...)
+		g.logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+
+	switch {
+	// A missing record is an expected outcome that services turn into a 404
+	case err != nil && g.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		record(slog.LevelError, "query failed", slog.String("error", err.Error()))
+	case g.slowThreshold > 0 && elapsed > g.slowThreshold && g.level >= logger.Warn:
+		record(slog.LevelWarn, "slow query", slog.String("threshold", g.slowThreshold.String()))
+	case g.level >= logger.Info:
+		record(slog.LevelDebug, "query")
+	}
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDKey stores the request ID on a context
+type requestIDKey struct{}
+
+// WithRequestID attaches a request ID to ctx; every record logged with the returned context carries it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID attached to ctx, or an empty string
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// actorKey stores the authenticated caller on a context
+type actorKey struct{}
+
+// WithActor attaches the name of whoever is making the request to ctx
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor attached to ctx, or an empty string
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// clientIPKey stores the caller's address on a context
+type clientIPKey struct{}
+
+// WithClientIP attaches the caller's IP address to ctx
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the IP address attached to ctx, or an empty string
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// New creates a logger writing text or JSON records at the configured level
+func New(cfg config.LoggingConfig, out io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{Level: Level(cfg.Level)}
+
+	var handler slog.Handler
+	if cfg.Format == constants.LogFormatJSON {
+		handler = slog.NewJSONHandler(out, options)
+	} else {
+		handler = slog.NewTextHandler(out, options)
+	}
+
+	return slog.New(contextHandler{handler})
+}
+
+// Level maps a configured level name onto slog's
+func Level(level string) slog.Level {
+	switch level {
+	case constants.LogLevelDebug:
+		return slog.LevelDebug
+	case constants.LogLevelWarn:
+		return slog.LevelWarn
+	case constants.LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// contextHandler adds the request ID and trace from the record's context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the request ID and trace, if any, before passing the record on
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(constants.LogKeyRequestID, requestID))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(
+			slog.String(constants.LogKeyTraceID, span.TraceID().String()),
+			slog.String(constants.LogKeySpanID, span.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the request ID behaviour on derived loggers
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the request ID behaviour on derived loggers
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
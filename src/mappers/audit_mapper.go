@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+)
+
+// AuditMapper handles mapping between AuditEntry models and DTOs
+type AuditMapper struct{}
+
+// NewAuditMapper creates a new AuditMapper
+func NewAuditMapper() *AuditMapper {
+	return &AuditMapper{}
+}
+
+// ToDTO converts an AuditEntry model to an AuditEntry DTO
+func (m *AuditMapper) ToDTO(entry *models.AuditEntry) *dto.AuditEntry {
+	return &dto.AuditEntry{
+		ID:         entry.ID,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Action:     entry.Action,
+		Actor:      entry.Actor,
+		RequestID:  entry.RequestID,
+		IPAddress:  entry.IPAddress,
+		Changes:    entry.Changes,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+// ToDTOs converts a slice of AuditEntry models to DTOs
+func (m *AuditMapper) ToDTOs(entries []*models.AuditEntry) []*dto.AuditEntry {
+	dtos := make([]*dto.AuditEntry, len(entries))
+	for i, entry := range entries {
+		dtos[i] = m.ToDTO(entry)
+	}
+	return dtos
+}
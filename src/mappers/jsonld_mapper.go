@@ -0,0 +1,189 @@
+package mappers
+
+import (
+	"fmt"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// countryCurrencies maps location countries to the ISO 4217 currency of their ticket prices
+var countryCurrencies = map[string]string{
+	"united states":  "USD",
+	"usa":            "USD",
+	"us":             "USD",
+	"united kingdom": "GBP",
+	"uk":             "GBP",
+	"gb":             "GBP",
+	"canada":         "CAD",
+	"ca":             "CAD",
+}
+
+// JSONLDMapper builds schema.org JSON-LD documents from the DTOs the other mappers produce
+type JSONLDMapper struct {
+	shows     *ShowMapper
+	showTypes *ShowTypeMapper
+	theatres  *TheatreMapper
+	locations *LocationMapper
+}
+
+// NewJSONLDMapper creates a new JSONLDMapper
+func NewJSONLDMapper() *JSONLDMapper {
+	return &JSONLDMapper{
+		shows:     NewShowMapper(),
+		showTypes: NewShowTypeMapper(),
+		theatres:  NewTheatreMapper(),
+		locations: NewLocationMapper(),
+	}
+}
+
+// ToTheaterEvent converts Show model, with its show type, theatre and location loaded, to a schema.org TheaterEvent document
+func (m *JSONLDMapper) ToTheaterEvent(show *models.Show) *dto.TheaterEventLD {
+	location := m.locations.ToDetailsDTO(&show.Theatre.Location)
+	event := m.toEvent(m.shows.ToDetailsDTO(show), m.showTypes.ToSummaryDTO(&show.ShowType), location)
+	event.Context = constants.SchemaOrgContext
+
+	// Map theatre if loaded
+	if show.Theatre.ID != uuid.Nil {
+		event.Location = m.toPlace(m.theatres.ToDetailsDTO(&show.Theatre), location)
+	}
+
+	return event
+}
+
+// ToPerformingArtsTheater converts Theatre model, with its location and shows' types loaded, to a schema.org
+// PerformingArtsTheater document
+func (m *JSONLDMapper) ToPerformingArtsTheater(theatre *models.Theatre) *dto.PerformingArtsTheaterLD {
+	location := m.locations.ToDetailsDTO(&theatre.Location)
+	place := m.toPlace(m.theatres.ToDetailsDTO(theatre), location)
+	place.Context = constants.SchemaOrgContext
+
+	// Map shows if loaded; the theatre is the enclosing document, so events don't repeat it
+	if len(theatre.Shows) > 0 {
+		place.Event = make([]dto.TheaterEventLD, 0, len(theatre.Shows))
+		for i := range theatre.Shows {
+			show := &theatre.Shows[i]
+			place.Event = append(place.Event, *m.toEvent(m.shows.ToDetailsDTO(show), m.showTypes.ToSummaryDTO(&show.ShowType), location))
+		}
+	}
+
+	return place
+}
+
+// toEvent builds a TheaterEvent without @context or location for embedding
+func (m *JSONLDMapper) toEvent(show *dto.ShowDetails, showType *dto.ShowTypeSummary, location *dto.LocationDetails) *dto.TheaterEventLD {
+	loc := loadTimezone(show.Timezone, location.Timezone)
+
+	event := &dto.TheaterEventLD{
+		Type:                "TheaterEvent",
+		Name:                show.Title,
+		Description:         show.Description,
+		Image:               show.ImageURL,
+		Genre:               showType.Name,
+		EventStatus:         constants.SchemaOrgEventScheduled,
+		EventAttendanceMode: constants.SchemaOrgOfflineAttendance,
+	}
+
+	if !show.IsActive {
+		event.EventStatus = constants.SchemaOrgEventCancelled
+	}
+
+	if show.StartDate != nil {
+		event.StartDate = show.StartDate.In(loc).Format(time.RFC3339)
+	}
+
+	if show.EndDate != nil {
+		event.EndDate = show.EndDate.In(loc).Format(time.RFC3339)
+	}
+
+	if show.Duration > 0 {
+		event.Duration = fmt.Sprintf("PT%dM", show.Duration)
+	}
+
+	if show.Director != "" {
+		event.Director = &dto.PersonLD{Type: "Person", Name: show.Director}
+	}
+
+	if show.Cast != "" {
+		event.Performer = &dto.PerformingGroupLD{Type: "PerformingGroup", Name: show.Cast}
+	}
+
+	if show.TrailerURL != "" {
+		event.SubjectOf = &dto.VideoObjectLD{Type: "VideoObject", Name: show.Title + " trailer", ContentURL: show.TrailerURL}
+	}
+
+	event.Offers = &dto.OfferLD{
+		Type:          "Offer",
+		Price:         fmt.Sprintf("%.2f", show.Price),
+		PriceCurrency: currencyFor(location.Country),
+		Availability:  constants.SchemaOrgInStock,
+	}
+	if !show.IsActive {
+		event.Offers.Availability = constants.SchemaOrgDiscontinued
+	}
+
+	return event
+}
+
+// toPlace builds a PerformingArtsTheater without @context for embedding
+func (m *JSONLDMapper) toPlace(theatre *dto.TheatreDetails, location *dto.LocationDetails) *dto.PerformingArtsTheaterLD {
+	place := &dto.PerformingArtsTheaterLD{
+		Type:            "PerformingArtsTheater",
+		Name:            theatre.Name,
+		Description:     theatre.Description,
+		Image:           theatre.ImageURL,
+		URL:             theatre.Website,
+		Telephone:       theatre.Phone,
+		Email:           theatre.Email,
+		MaximumAttendee: theatre.Capacity,
+	}
+
+	streetAddress := theatre.Address
+	if streetAddress == "" {
+		streetAddress = location.Address
+	}
+
+	place.Address = &dto.PostalAddressLD{
+		Type:            "PostalAddress",
+		StreetAddress:   streetAddress,
+		AddressLocality: location.City,
+		AddressRegion:   location.State,
+		PostalCode:      location.PostalCode,
+		AddressCountry:  location.Country,
+	}
+
+	if location.Latitude != nil && location.Longitude != nil {
+		place.Geo = &dto.GeoCoordinatesLD{
+			Type:      "GeoCoordinates",
+			Latitude:  *location.Latitude,
+			Longitude: *location.Longitude,
+		}
+	}
+
+	return place
+}
+
+// loadTimezone returns the first valid timezone of the candidates, defaulting to UTC
+func loadTimezone(candidates ...string) *time.Location {
+	for _, name := range candidates {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// currencyFor returns the ticket price currency for a location country
+func currencyFor(country string) string {
+	if currency, ok := countryCurrencies[strings.ToLower(strings.TrimSpace(country))]; ok {
+		return currency
+	}
+	return constants.DefaultPriceCurrency
+}
@@ -25,6 +25,7 @@ func (m *LocationMapper) ToModel(locationDTO *dto.LocationBase) *models.Location
 		PostalCode:  locationDTO.PostalCode,
 		Address:     locationDTO.Address,
 		Description: locationDTO.Description,
+		Timezone:    locationDTO.Timezone,
 		IsActive:    true, // default value
 	}
 
@@ -35,6 +36,26 @@ func (m *LocationMapper) ToModel(locationDTO *dto.LocationBase) *models.Location
 	return location
 }
 
+// ToBaseDTO converts Location model back to the LocationBase DTO that would create or update it as it is now
+func (m *LocationMapper) ToBaseDTO(location *models.Location) *dto.LocationBase {
+	// Copies, so the DTO keeps describing this version after the model changes
+	isActive := location.IsActive
+
+	return &dto.LocationBase{
+		Name:        location.Name,
+		City:        location.City,
+		State:       location.State,
+		Country:     location.Country,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		PostalCode:  location.PostalCode,
+		Address:     location.Address,
+		Description: location.Description,
+		Timezone:    location.Timezone,
+		IsActive:    &isActive,
+	}
+}
+
 // ToDetailsDTO converts Location model to LocationDetails DTO
 func (m *LocationMapper) ToDetailsDTO(location *models.Location) *dto.LocationDetails {
 	locationDTO := &dto.LocationDetails{
@@ -48,6 +69,7 @@ func (m *LocationMapper) ToDetailsDTO(location *models.Location) *dto.LocationDe
 		PostalCode:  location.PostalCode,
 		Address:     location.Address,
 		Description: location.Description,
+		Timezone:    location.Timezone,
 		IsActive:    location.IsActive,
 		CreatedAt:   location.CreatedAt,
 		UpdatedAt:   location.UpdatedAt,
@@ -67,7 +89,7 @@ func (m *LocationMapper) ToDetailsDTO(location *models.Location) *dto.LocationDe
 
 // ToSummaryDTO converts Location model to LocationSummary DTO
 func (m *LocationMapper) ToSummaryDTO(location *models.Location) *dto.LocationSummary {
-	return &dto.LocationSummary{
+	locationDTO := &dto.LocationSummary{
 		ID:        location.ID,
 		Name:      location.Name,
 		City:      location.City,
@@ -75,8 +97,15 @@ func (m *LocationMapper) ToSummaryDTO(location *models.Location) *dto.LocationSu
 		Country:   location.Country,
 		Latitude:  location.Latitude,
 		Longitude: location.Longitude,
+		Timezone:  location.Timezone,
 		IsActive:  location.IsActive,
 	}
+
+	if location.DeletedAt.Valid {
+		locationDTO.DeletedAt = &location.DeletedAt.Time
+	}
+
+	return locationDTO
 }
 
 // ToSummaryDTOs converts slice of Location models to slice of LocationSummary DTOs
@@ -99,6 +128,7 @@ func (m *LocationMapper) UpdateModel(location *models.Location, locationDTO *dto
 	location.PostalCode = locationDTO.PostalCode
 	location.Address = locationDTO.Address
 	location.Description = locationDTO.Description
+	location.Timezone = locationDTO.Timezone
 
 	if locationDTO.IsActive != nil {
 		location.IsActive = *locationDTO.IsActive
@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+)
+
+// OutboxMapper handles mapping between OutboxMessage models and DTOs
+type OutboxMapper struct{}
+
+// NewOutboxMapper creates a new OutboxMapper
+func NewOutboxMapper() *OutboxMapper {
+	return &OutboxMapper{}
+}
+
+// ToDTO converts an OutboxMessage model to its operator view
+func (m *OutboxMapper) ToDTO(message *models.OutboxMessage) *dto.OutboxMessage {
+	return &dto.OutboxMessage{
+		ID:            message.ID,
+		Sequence:      message.Sequence,
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		EventName:     message.EventName,
+		Payload:       message.Payload,
+		RequestID:     message.RequestID,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		DeliveredAt:   message.DeliveredAt,
+	}
+}
+
+// ToDTOs converts a slice of OutboxMessage models to operator views
+func (m *OutboxMapper) ToDTOs(messages []*models.OutboxMessage) []*dto.OutboxMessage {
+	dtos := make([]*dto.OutboxMessage, len(messages))
+	for i, message := range messages {
+		dtos[i] = m.ToDTO(message)
+	}
+	return dtos
+}
+
+// ToEnvelope converts an OutboxMessage model to the document delivered to sinks
+func (m *OutboxMapper) ToEnvelope(message *models.OutboxMessage) *dto.OutboxEnvelope {
+	return &dto.OutboxEnvelope{
+		ID:            message.ID,
+		Event:         message.EventName,
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		Sequence:      message.Sequence,
+		OccurredAt:    message.CreatedAt,
+		RequestID:     message.RequestID,
+		Data:          message.Payload,
+	}
+}
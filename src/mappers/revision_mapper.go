@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+)
+
+// RevisionMapper handles mapping between ShowRevision/TheatreRevision models and DTOs
+type RevisionMapper struct{}
+
+// NewRevisionMapper creates a new RevisionMapper
+func NewRevisionMapper() *RevisionMapper {
+	return &RevisionMapper{}
+}
+
+// ShowRevisionToDetailsDTO converts a ShowRevision model to a RevisionDetails DTO
+func (m *RevisionMapper) ShowRevisionToDetailsDTO(revision *models.ShowRevision) *dto.RevisionDetails {
+	return &dto.RevisionDetails{
+		RevisionSummary: m.ShowRevisionToSummaryDTO(revision),
+		Snapshot:        revision.Snapshot,
+	}
+}
+
+// ShowRevisionToSummaryDTO converts a ShowRevision model to a RevisionSummary DTO
+func (m *RevisionMapper) ShowRevisionToSummaryDTO(revision *models.ShowRevision) dto.RevisionSummary {
+	return dto.RevisionSummary{
+		Revision:     revision.Revision,
+		Action:       revision.Action,
+		RestoredFrom: revision.RestoredFrom,
+		Actor:        revision.Actor,
+		RequestID:    revision.RequestID,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+// ShowRevisionsToSummaryDTOs converts a slice of ShowRevision models to RevisionSummary DTOs
+func (m *RevisionMapper) ShowRevisionsToSummaryDTOs(revisions []*models.ShowRevision) []*dto.RevisionSummary {
+	dtos := make([]*dto.RevisionSummary, len(revisions))
+	for i, revision := range revisions {
+		summary := m.ShowRevisionToSummaryDTO(revision)
+		dtos[i] = &summary
+	}
+	return dtos
+}
+
+// TheatreRevisionToDetailsDTO converts a TheatreRevision model to a RevisionDetails DTO
+func (m *RevisionMapper) TheatreRevisionToDetailsDTO(revision *models.TheatreRevision) *dto.RevisionDetails {
+	return &dto.RevisionDetails{
+		RevisionSummary: m.TheatreRevisionToSummaryDTO(revision),
+		Snapshot:        revision.Snapshot,
+	}
+}
+
+// TheatreRevisionToSummaryDTO converts a TheatreRevision model to a RevisionSummary DTO
+func (m *RevisionMapper) TheatreRevisionToSummaryDTO(revision *models.TheatreRevision) dto.RevisionSummary {
+	return dto.RevisionSummary{
+		Revision:     revision.Revision,
+		Action:       revision.Action,
+		RestoredFrom: revision.RestoredFrom,
+		Actor:        revision.Actor,
+		RequestID:    revision.RequestID,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+// TheatreRevisionsToSummaryDTOs converts a slice of TheatreRevision models to RevisionSummary DTOs
+func (m *RevisionMapper) TheatreRevisionsToSummaryDTOs(revisions []*models.TheatreRevision) []*dto.RevisionSummary {
+	dtos := make([]*dto.RevisionSummary, len(revisions))
+	for i, revision := range revisions {
+		summary := m.TheatreRevisionToSummaryDTO(revision)
+		dtos[i] = &summary
+	}
+	return dtos
+}
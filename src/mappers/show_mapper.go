@@ -49,6 +49,35 @@ func (m *ShowMapper) ToModel(showDTO *dto.ShowBase) *models.Show {
 	return show
 }
 
+// ToBaseDTO converts Show model back to the ShowBase DTO that would create or update it as it is now
+func (m *ShowMapper) ToBaseDTO(show *models.Show) *dto.ShowBase {
+	// Copies, so the DTO keeps describing this version after the model changes
+	duration, price, isFeatured, isActive := show.Duration, show.Price, show.IsFeatured, show.IsActive
+
+	showDTO := &dto.ShowBase{
+		Title:       show.Title,
+		Description: show.Description,
+		Director:    show.Director,
+		Cast:        show.Cast,
+		StartDate:   show.StartDate,
+		EndDate:     show.EndDate,
+		Price:       &price,
+		ImageURL:    show.ImageURL,
+		TrailerURL:  show.TrailerURL,
+		IsFeatured:  &isFeatured,
+		IsActive:    &isActive,
+		TheatreID:   show.TheatreID,
+		ShowTypeID:  show.ShowTypeID,
+	}
+
+	// Zero means unset, which the DTO spells as null
+	if duration != 0 {
+		showDTO.Duration = &duration
+	}
+
+	return showDTO
+}
+
 // ToDetailsDTO converts Show model to ShowDetails DTO
 func (m *ShowMapper) ToDetailsDTO(show *models.Show) *dto.ShowDetails {
 	showDTO := &dto.ShowDetails{
@@ -60,6 +89,9 @@ func (m *ShowMapper) ToDetailsDTO(show *models.Show) *dto.ShowDetails {
 		Duration:    show.Duration,
 		StartDate:   show.StartDate,
 		EndDate:     show.EndDate,
+		Timezone:    show.Timezone,
+		StartLocal:  show.StartLocal,
+		EndLocal:    show.EndLocal,
 		Price:       show.Price,
 		ImageURL:    show.ImageURL,
 		TrailerURL:  show.TrailerURL,
@@ -96,6 +128,9 @@ func (m *ShowMapper) ToSummaryDTO(show *models.Show) *dto.ShowSummary {
 		Duration:    show.Duration,
 		StartDate:   show.StartDate,
 		EndDate:     show.EndDate,
+		Timezone:    show.Timezone,
+		StartLocal:  show.StartLocal,
+		EndLocal:    show.EndLocal,
 		Price:       show.Price,
 		ImageURL:    show.ImageURL,
 		IsFeatured:  show.IsFeatured,
@@ -116,6 +151,10 @@ func (m *ShowMapper) ToSummaryDTO(show *models.Show) *dto.ShowSummary {
 		showDTO.ShowType = *showTypeMapper.ToSummaryDTO(&show.ShowType)
 	}
 
+	if show.DeletedAt.Valid {
+		showDTO.DeletedAt = &show.DeletedAt.Time
+	}
+
 	return showDTO
 }
 
@@ -28,6 +28,18 @@ func (m *ShowTypeMapper) ToModel(showTypeDTO *dto.ShowTypeBase) *models.ShowType
 	return showType
 }
 
+// ToBaseDTO converts ShowType model back to the ShowTypeBase DTO that would create or update it as it is now
+func (m *ShowTypeMapper) ToBaseDTO(showType *models.ShowType) *dto.ShowTypeBase {
+	// Copies, so the DTO keeps describing this version after the model changes
+	isActive := showType.IsActive
+
+	return &dto.ShowTypeBase{
+		Name:        showType.Name,
+		Description: showType.Description,
+		IsActive:    &isActive,
+	}
+}
+
 // ToDetailsDTO converts ShowType model to ShowTypeDetails DTO
 func (m *ShowTypeMapper) ToDetailsDTO(showType *models.ShowType) *dto.ShowTypeDetails {
 	showTypeDTO := &dto.ShowTypeDetails{
@@ -53,12 +65,18 @@ func (m *ShowTypeMapper) ToDetailsDTO(showType *models.ShowType) *dto.ShowTypeDe
 
 // ToSummaryDTO converts ShowType model to ShowTypeSummary DTO
 func (m *ShowTypeMapper) ToSummaryDTO(showType *models.ShowType) *dto.ShowTypeSummary {
-	return &dto.ShowTypeSummary{
+	showTypeDTO := &dto.ShowTypeSummary{
 		ID:          showType.ID,
 		Name:        showType.Name,
 		Description: showType.Description,
 		IsActive:    showType.IsActive,
 	}
+
+	if showType.DeletedAt.Valid {
+		showTypeDTO.DeletedAt = &showType.DeletedAt.Time
+	}
+
+	return showTypeDTO
 }
 
 // ToSummaryDTOs converts slice of ShowType models to slice of ShowTypeSummary DTOs
@@ -44,6 +44,33 @@ func (m *TheatreMapper) ToModel(theatreDTO *dto.TheatreBase) *models.Theatre {
 	return theatre
 }
 
+// ToBaseDTO converts Theatre model back to the TheatreBase DTO that would create or update it as it is now
+func (m *TheatreMapper) ToBaseDTO(theatre *models.Theatre) *dto.TheatreBase {
+	// Copies, so the DTO keeps describing this version after the model changes
+	capacity, isFeatured, isActive := theatre.Capacity, theatre.IsFeatured, theatre.IsActive
+
+	theatreDTO := &dto.TheatreBase{
+		Name:          theatre.Name,
+		Description:   theatre.Description,
+		Address:       theatre.Address,
+		Phone:         theatre.Phone,
+		Email:         theatre.Email,
+		Website:       theatre.Website,
+		ImageURL:      theatre.ImageURL,
+		IsFeatured:    &isFeatured,
+		IsActive:      &isActive,
+		LocationID:    theatre.LocationID,
+		TheatreTypeID: theatre.TheatreTypeID,
+	}
+
+	// Zero means unset, which the DTO spells as null
+	if capacity != 0 {
+		theatreDTO.Capacity = &capacity
+	}
+
+	return theatreDTO
+}
+
 // ToDetailsDTO converts Theatre model to TheatreDetails DTO
 func (m *TheatreMapper) ToDetailsDTO(theatre *models.Theatre) *dto.TheatreDetails {
 	theatreDTO := &dto.TheatreDetails{
@@ -114,6 +141,10 @@ func (m *TheatreMapper) ToSummaryDTO(theatre *models.Theatre) *dto.TheatreSummar
 		theatreDTO.TheatreType = *theatreTypeMapper.ToSummaryDTO(&theatre.TheatreType)
 	}
 
+	if theatre.DeletedAt.Valid {
+		theatreDTO.DeletedAt = &theatre.DeletedAt.Time
+	}
+
 	return theatreDTO
 }
 
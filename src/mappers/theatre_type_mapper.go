@@ -28,6 +28,18 @@ func (m *TheatreTypeMapper) ToModel(theatreTypeDTO *dto.TheatreTypeBase) *models
 	return theatreType
 }
 
+// ToBaseDTO converts TheatreType model back to the TheatreTypeBase DTO that would create or update it as it is now
+func (m *TheatreTypeMapper) ToBaseDTO(theatreType *models.TheatreType) *dto.TheatreTypeBase {
+	// Copies, so the DTO keeps describing this version after the model changes
+	isActive := theatreType.IsActive
+
+	return &dto.TheatreTypeBase{
+		Name:        theatreType.Name,
+		Description: theatreType.Description,
+		IsActive:    &isActive,
+	}
+}
+
 // ToDetailsDTO converts TheatreType model to TheatreTypeDetails DTO
 func (m *TheatreTypeMapper) ToDetailsDTO(theatreType *models.TheatreType) *dto.TheatreTypeDetails {
 	theatreTypeDTO := &dto.TheatreTypeDetails{
@@ -53,12 +65,18 @@ func (m *TheatreTypeMapper) ToDetailsDTO(theatreType *models.TheatreType) *dto.T
 
 // ToSummaryDTO converts TheatreType model to TheatreTypeSummary DTO
 func (m *TheatreTypeMapper) ToSummaryDTO(theatreType *models.TheatreType) *dto.TheatreTypeSummary {
-	return &dto.TheatreTypeSummary{
+	theatreTypeDTO := &dto.TheatreTypeSummary{
 		ID:          theatreType.ID,
 		Name:        theatreType.Name,
 		Description: theatreType.Description,
 		IsActive:    theatreType.IsActive,
 	}
+
+	if theatreType.DeletedAt.Valid {
+		theatreTypeDTO.DeletedAt = &theatreType.DeletedAt.Time
+	}
+
+	return theatreTypeDTO
 }
 
 // ToSummaryDTOs converts slice of TheatreType models to slice of TheatreTypeSummary DTOs
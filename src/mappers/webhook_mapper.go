@@ -0,0 +1,137 @@
+package mappers
+
+import (
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/models"
+	"time"
+)
+
+// WebhookMapper handles mapping between webhook models and DTOs
+type WebhookMapper struct{}
+
+// NewWebhookMapper creates a new WebhookMapper
+func NewWebhookMapper() *WebhookMapper {
+	return &WebhookMapper{}
+}
+
+// ToModel converts a WebhookBase DTO to a WebhookSubscription model
+func (m *WebhookMapper) ToModel(base *dto.WebhookBase) *models.WebhookSubscription {
+	subscription := &models.WebhookSubscription{
+		URL:         base.URL,
+		Secret:      base.Secret,
+		Description: base.Description,
+		EventTypes:  base.EventTypes,
+		TheatreID:   base.TheatreID,
+		ShowTypeID:  base.ShowTypeID,
+		IsActive:    true,
+	}
+	if base.IsActive != nil {
+		subscription.IsActive = *base.IsActive
+	}
+	return subscription
+}
+
+// ToDetailsDTO converts a WebhookSubscription model to a WebhookDetails DTO, leaving out the secret
+func (m *WebhookMapper) ToDetailsDTO(subscription *models.WebhookSubscription) *dto.WebhookDetails {
+	return &dto.WebhookDetails{
+		ID:                  subscription.ID,
+		URL:                 subscription.URL,
+		Description:         subscription.Description,
+		EventTypes:          subscription.EventTypes,
+		TheatreID:           subscription.TheatreID,
+		ShowTypeID:          subscription.ShowTypeID,
+		IsActive:            subscription.IsActive,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		DisabledReason:      subscription.DisabledReason,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+// ToDetailsDTOs converts a slice of WebhookSubscription models to WebhookDetails DTOs
+func (m *WebhookMapper) ToDetailsDTOs(subscriptions []*models.WebhookSubscription) []*dto.WebhookDetails {
+	dtos := make([]*dto.WebhookDetails, len(subscriptions))
+	for i, subscription := range subscriptions {
+		dtos[i] = m.ToDetailsDTO(subscription)
+	}
+	return dtos
+}
+
+// UpdateModel updates a WebhookSubscription model from a WebhookBase DTO, keeping the secret when none is given
+func (m *WebhookMapper) UpdateModel(subscription *models.WebhookSubscription, base *dto.WebhookBase) {
+	subscription.URL = base.URL
+	if base.Secret != "" {
+		subscription.Secret = base.Secret
+	}
+	subscription.Description = base.Description
+	subscription.EventTypes = base.EventTypes
+	subscription.TheatreID = base.TheatreID
+	subscription.ShowTypeID = base.ShowTypeID
+	if base.IsActive != nil {
+		subscription.IsActive = *base.IsActive
+	}
+}
+
+// ToDeliverySummaryDTO converts a WebhookDelivery model to a WebhookDeliverySummary DTO
+func (m *WebhookMapper) ToDeliverySummaryDTO(delivery *models.WebhookDelivery) *dto.WebhookDeliverySummary {
+	return &dto.WebhookDeliverySummary{
+		ID:                 delivery.ID,
+		WebhookID:          delivery.SubscriptionID,
+		EventName:          delivery.EventName,
+		Status:             delivery.Status,
+		Attempts:           delivery.Attempts,
+		NextAttemptAt:      nextAttemptAt(delivery),
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		CreatedAt:          delivery.CreatedAt,
+		CompletedAt:        delivery.CompletedAt,
+	}
+}
+
+// ToDeliverySummaryDTOs converts a slice of WebhookDelivery models to WebhookDeliverySummary DTOs
+func (m *WebhookMapper) ToDeliverySummaryDTOs(deliveries []*models.WebhookDelivery) []*dto.WebhookDeliverySummary {
+	dtos := make([]*dto.WebhookDeliverySummary, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = m.ToDeliverySummaryDTO(delivery)
+	}
+	return dtos
+}
+
+// ToDeliveryDetailsDTO converts a WebhookDelivery model, with its attempts loaded, to a WebhookDeliveryDetails DTO
+func (m *WebhookMapper) ToDeliveryDetailsDTO(delivery *models.WebhookDelivery) *dto.WebhookDeliveryDetails {
+	details := &dto.WebhookDeliveryDetails{
+		ID:                 delivery.ID,
+		WebhookID:          delivery.SubscriptionID,
+		EventName:          delivery.EventName,
+		Status:             delivery.Status,
+		Attempts:           delivery.Attempts,
+		NextAttemptAt:      nextAttemptAt(delivery),
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		CreatedAt:          delivery.CreatedAt,
+		CompletedAt:        delivery.CompletedAt,
+		Payload:            delivery.Payload,
+		Log:                make([]dto.WebhookDeliveryAttempt, len(delivery.Log)),
+	}
+	for i, attempt := range delivery.Log {
+		details.Log[i] = dto.WebhookDeliveryAttempt{
+			Attempt:        attempt.Attempt,
+			ResponseStatus: attempt.ResponseStatus,
+			ResponseBody:   attempt.ResponseBody,
+			Error:          attempt.Error,
+			DurationMs:     attempt.DurationMs,
+			CreatedAt:      attempt.CreatedAt,
+		}
+	}
+	return details
+}
+
+// nextAttemptAt is when a pending delivery is tried next; completed deliveries have none
+func nextAttemptAt(delivery *models.WebhookDelivery) *time.Time {
+	if delivery.Status != constants.WebhookDeliveryPending {
+		return nil
+	}
+	return &delivery.NextAttemptAt
+}
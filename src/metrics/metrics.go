@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds every application metric, registered on a single registry
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	cacheHits       prometheus.Counter
+	cacheMisses     prometheus.Counter
+	cacheEvictions  prometheus.Counter
+	entitiesCreated *prometheus.CounterVec
+	searches        *prometheus.CounterVec
+}
+
+// NewRegistry creates a registry with the Go runtime and process collectors
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// New registers the application metrics on registry; nothing touches the global default registry,
+// so tests can pass a fresh prometheus.NewRegistry()
+func New(registry *prometheus.Registry) *Metrics {
+	factory := promauto.With(registry)
+
+	return &Metrics{
+		registry: registry,
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		cacheHits: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "cache_hits_total",
+			Help:      "Cache lookups that found an entry.",
+		}),
+		cacheMisses: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "cache_misses_total",
+			Help:      "Cache lookups that found nothing.",
+		}),
+		cacheEvictions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "cache_evictions_total",
+			Help:      "Cache entries removed by expiry or deletion.",
+		}),
+		entitiesCreated: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "entities_created_total",
+			Help:      "Committed creates by entity, including imports and batches.",
+		}, []string{"entity"}),
+		searches: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: constants.MetricsNamespace,
+			Name:      "searches_total",
+			Help:      "Text searches executed by entity.",
+		}, []string{"entity"}),
+	}
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDBPool exposes a connection pool's stats, labelled with the pool's name
+func (m *Metrics) RegisterDBPool(name string, db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveRequest records a finished HTTP request; route must be a template so label values stay bounded
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// CacheHit counts a cache lookup that found an entry
+func (m *Metrics) CacheHit() {
+	m.cacheHits.Inc()
+}
+
+// CacheMiss counts a cache lookup that found nothing
+func (m *Metrics) CacheMiss() {
+	m.cacheMisses.Inc()
+}
+
+// CacheEviction counts a cache entry removed by expiry or deletion
+func (m *Metrics) CacheEviction() {
+	m.cacheEvictions.Inc()
+}
+
+// EntitiesCreated counts committed creates of an entity
+func (m *Metrics) EntitiesCreated(entity string, count int) {
+	m.entitiesCreated.WithLabelValues(entity).Add(float64(count))
+}
+
+// SearchExecuted counts a text search of an entity
+func (m *Metrics) SearchExecuted(entity string) {
+	m.searches.WithLabelValues(entity).Inc()
+}
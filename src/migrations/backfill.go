@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"fmt"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/timezone"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// backfills are the Go steps of migrations, by version
+var backfills = map[int64]func(tx *gorm.DB) error{
+	8: backfillLocationTimezones,
+}
+
+// backfillLocationTimezones derives the timezone of every location still on the default one from its
+// coordinates, the way the API does on create and update, then re-localizes the shows at the locations it
+// changed and any old enough to have no local times at all
+func backfillLocationTimezones(tx *gorm.DB) error {
+	var locations []struct {
+		ID        uuid.UUID
+		Latitude  *float64
+		Longitude *float64
+	}
+	err := tx.Raw(`SELECT id, latitude, longitude FROM locations
+		WHERE timezone = ? AND latitude IS NOT NULL AND longitude IS NOT NULL`, constants.DefaultTimezone).
+		Scan(&locations).Error
+	if err != nil {
+		return err
+	}
+
+	var changed []uuid.UUID
+	for _, location := range locations {
+		name, err := timezone.FromCoordinates(*location.Latitude, *location.Longitude)
+		if err != nil {
+			return fmt.Errorf("resolve timezone of location %s: %w", location.ID, err)
+		}
+		if name == constants.DefaultTimezone {
+			continue
+		}
+		if err := tx.Exec("UPDATE locations SET timezone = ? WHERE id = ?", name, location.ID).Error; err != nil {
+			return err
+		}
+		changed = append(changed, location.ID)
+	}
+
+	// Soft-deleted shows are included, so a restored show comes back with the right local times
+	return tx.Exec(`
+		UPDATE shows SET
+			timezone = l.timezone,
+			start_local = COALESCE(to_char(shows.start_date AT TIME ZONE l.timezone, 'YYYY-MM-DD"T"HH24:MI:SS'), ''),
+			end_local = COALESCE(to_char(shows.end_date AT TIME ZONE l.timezone, 'YYYY-MM-DD"T"HH24:MI:SS'), '')
+		FROM theatres t
+		INNER JOIN locations l ON t.location_id = l.id
+		WHERE shows.theatre_id = t.id
+		AND (l.id IN ? OR shows.timezone IS NULL)`, changed).Error
+}
@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"theatre-management-system/src/models"
+
+	"gorm.io/gorm"
+)
+
+// Usage describes the migrate subcommands
+const Usage = `usage: migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [n]        roll back the last n migrations (default 1)
+  to <version>    migrate up or down to the given version (0 reverts everything)
+  status          list migrations and whether they are applied
+  verify          check that the GORM models match the migrated schema`
+
+// RunCLI executes a migrate subcommand, writing progress to out
+func RunCLI(ctx context.Context, db *gorm.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(Usage)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		ran, err := migrator.Up(ctx)
+		report(out, "applied", ran)
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		ran, err := migrator.Down(ctx, steps)
+		report(out, "reverted", ran)
+		return err
+
+	case "to":
+		if len(args) < 2 {
+			return errors.New("usage: migrate to <version>")
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		ran, err := migrator.To(ctx, version)
+		report(out, "migrated", ran)
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			switch {
+			case status.Modified:
+				state += " (modified since applied)"
+			case status.Missing:
+				state += " (not in this binary)"
+			}
+			fmt.Fprintf(out, "%04d_%-32s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+
+	case "verify":
+		mismatches, err := migrator.Verify(ctx, models.All()...)
+		if err != nil {
+			return err
+		}
+		for _, mismatch := range mismatches {
+			fmt.Fprintln(out, mismatch)
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("%d schema mismatches between models and migrations", len(mismatches))
+		}
+		fmt.Fprintln(out, "models match the migrated schema")
+		return nil
+	}
+
+	return errors.New(Usage)
+}
+
+// report prints the migrations a command ran
+func report(out io.Writer, verb string, migrations []Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintln(out, "nothing to do")
+		return
+	}
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %04d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,361 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"theatre-management-system/src/constants"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// fileNamePattern matches migration files such as 0001_initial_schema.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change with its SQL in both directions
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of the up SQL, recorded when applied to detect later edits
+
+	// Backfill runs after the up SQL, in the same transaction, for data only Go can derive
+	Backfill func(tx *gorm.DB) error
+}
+
+// Status describes a migration's state in the connected database
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Modified  bool       `json:"modified"` // the file changed after it was applied
+	Missing   bool       `json:"missing"`  // applied, but no longer shipped in the binary
+}
+
+// appliedMigration is a row of the migrations table
+type appliedMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// TableName returns the migrations bookkeeping table
+func (appliedMigration) TableName() string {
+	return constants.MigrationsTable
+}
+
+// Migrator applies and rolls back the embedded migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the migrations embedded in the binary
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns every known migration in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies all pending migrations, returning the ones it ran
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, m.latestVersion())
+}
+
+// Down rolls back the given number of most recently applied migrations, returning the ones it reverted
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration, err := m.revert(conn, applied[i])
+			if err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// To migrates up or down until the given version is the latest one applied; 0 reverts everything
+func (m *Migrator) To(ctx context.Context, version int64) ([]Migration, error) {
+	if version != 0 && m.find(version) == nil {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	var ran []Migration
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		// Roll back anything newer than the target, newest first
+		done := make(map[int64]bool, len(applied))
+		for i := len(applied) - 1; i >= 0; i-- {
+			if applied[i].Version <= version {
+				done[applied[i].Version] = true
+				continue
+			}
+			migration, err := m.revert(conn, applied[i])
+			if err != nil {
+				return err
+			}
+			ran = append(ran, migration)
+		}
+
+		// Then apply anything pending up to the target, oldest first
+		for _, migration := range m.migrations {
+			if migration.Version > version || done[migration.Version] {
+				continue
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			ran = append(ran, migration)
+		}
+		return nil
+	})
+
+	return ran, err
+}
+
+// Status reports every migration, applied or not, including applied ones the binary no longer ships
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		byVersion := make(map[int64]appliedMigration, len(applied))
+		for _, row := range applied {
+			byVersion[row.Version] = row
+		}
+
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := byVersion[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = row.Checksum != migration.Checksum
+				delete(byVersion, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, row := range byVersion {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{
+				Version:   row.Version,
+				Name:      row.Name,
+				Applied:   true,
+				AppliedAt: &appliedAt,
+				Missing:   true,
+			})
+		}
+
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock,
+// so concurrent deploys migrate one at a time
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", constants.MigrationLockID).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", constants.MigrationLockID)
+
+		if err := conn.Exec(createMigrationsTable).Error; err != nil {
+			return fmt.Errorf("create %s: %w", constants.MigrationsTable, err)
+		}
+
+		return fn(conn)
+	})
+}
+
+// applied returns the applied migrations in version order
+func (m *Migrator) applied(conn *gorm.DB) ([]appliedMigration, error) {
+	var rows []appliedMigration
+	err := conn.Order("version").Find(&rows).Error
+	return rows, err
+}
+
+// apply runs a migration's up SQL and records it, atomically
+func (m *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		if migration.Backfill != nil {
+			if err := migration.Backfill(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&appliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// revert runs an applied migration's down SQL and removes its record, atomically
+func (m *Migrator) revert(conn *gorm.DB, row appliedMigration) (Migration, error) {
+	migration := m.find(row.Version)
+	if migration == nil {
+		return Migration{}, fmt.Errorf("cannot revert migration %04d_%s: it is not in this binary", row.Version, row.Name)
+	}
+	if migration.Down == "" {
+		return Migration{}, fmt.Errorf("cannot revert migration %04d_%s: it has no down file", row.Version, row.Name)
+	}
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&appliedMigration{}, "version = ?", row.Version).Error
+	})
+	if err != nil {
+		return Migration{}, fmt.Errorf("revert migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return *migration, nil
+}
+
+// checkDrift refuses to migrate when an applied migration's file has since been edited
+func (m *Migrator) checkDrift(applied []appliedMigration) error {
+	for _, row := range applied {
+		migration := m.find(row.Version)
+		if migration != nil && migration.Checksum != row.Checksum {
+			return fmt.Errorf("migration %04d_%s was modified after it was applied; add a new migration instead", row.Version, row.Name)
+		}
+	}
+	return nil
+}
+
+// find returns the migration with the given version
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// latestVersion returns the highest known version
+func (m *Migrator) latestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// load reads and pairs the up/down files, ordered by version
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, "sql/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			migration.Up = string(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up file", migration.Version, migration.Name)
+		}
+		migration.Backfill = backfills[migration.Version]
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// createMigrationsTable creates the bookkeeping table on first use
+var createMigrationsTable = `CREATE TABLE IF NOT EXISTS ` + constants.MigrationsTable + ` (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
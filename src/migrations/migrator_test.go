@@ -0,0 +1,109 @@
+package migrations_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"theatre-management-system/src/migrations"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/testdb"
+
+	"gorm.io/gorm"
+)
+
+// testSchema keeps these tests' tables apart from other packages' running at the same time
+const testSchema = "migrations_test"
+
+// TestMigrationsMatchModels applies every migration and checks the schema against the models, then
+// rolls everything back and applies it again, checking the down files undo what the up files do
+func TestMigrationsMatchModels(t *testing.T) {
+	ctx := context.Background()
+	migrator := newMigrator(t, testdb.Open(t, testSchema))
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	assertMatchesModels(t, migrator)
+
+	reverted, err := migrator.To(ctx, 0)
+	if err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	if len(reverted) != len(migrator.Migrations()) {
+		t.Errorf("To(0) reverted %d migrations, want %d", len(reverted), len(migrator.Migrations()))
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Applied {
+			t.Errorf("migration %04d_%s is still applied after To(0)", status.Version, status.Name)
+		}
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up after To(0): %v", err)
+	}
+	if len(applied) != len(migrator.Migrations()) {
+		t.Errorf("Up after To(0) applied %d migrations, want %d", len(applied), len(migrator.Migrations()))
+	}
+	assertMatchesModels(t, migrator)
+}
+
+// TestMigrationsReconcileLegacySchema migrates a database created by the old init.sql, whose tables 0001
+// leaves alone, and checks it ends up matching the models with its venues' timezones derived
+func TestMigrationsReconcileLegacySchema(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Open(t, testSchema)
+
+	legacy, err := os.ReadFile("testdata/legacy_init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(string(legacy)).Error; err != nil {
+		t.Fatalf("create legacy schema: %v", err)
+	}
+	err = db.Exec(`INSERT INTO locations (name, city, state, country, latitude, longitude)
+		VALUES ('Manhattan Theater District', 'New York', 'New York', 'United States', 40.7589, -73.9851)`).Error
+	if err != nil {
+		t.Fatalf("insert legacy location: %v", err)
+	}
+
+	migrator := newMigrator(t, db)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	assertMatchesModels(t, migrator)
+
+	var timezone string
+	if err := db.Raw("SELECT timezone FROM locations WHERE city = 'New York'").Scan(&timezone).Error; err != nil {
+		t.Fatal(err)
+	}
+	if timezone != "America/New_York" {
+		t.Errorf("legacy New York location has timezone %q, want America/New_York", timezone)
+	}
+}
+
+// newMigrator creates a migrator for the embedded migrations
+func newMigrator(t *testing.T, db *gorm.DB) *migrations.Migrator {
+	t.Helper()
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	return migrator
+}
+
+// assertMatchesModels fails the test for every difference between the models and the schema
+func assertMatchesModels(t *testing.T, migrator *migrations.Migrator) {
+	t.Helper()
+	mismatches, err := migrator.Verify(context.Background(), models.All()...)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	for _, mismatch := range mismatches {
+		t.Error(mismatch)
+	}
+}
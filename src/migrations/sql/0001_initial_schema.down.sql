@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS shows;
+DROP TABLE IF EXISTS theatres;
+DROP TABLE IF EXISTS show_types;
+DROP TABLE IF EXISTS theatre_types;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,102 @@
+-- PostGIS powers the nearby-location and nearby-theatre queries
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    state VARCHAR(100),
+    country VARCHAR(100) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    postal_code VARCHAR(20),
+    address TEXT,
+    description TEXT,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS theatre_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS show_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS theatres (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    capacity INTEGER CHECK (capacity >= 0),
+    address TEXT,
+    phone VARCHAR(20),
+    email VARCHAR(255),
+    website VARCHAR(500),
+    image_url VARCHAR(500),
+    is_featured BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    location_id UUID NOT NULL REFERENCES locations(id),
+    theatre_type_id UUID NOT NULL REFERENCES theatre_types(id)
+);
+
+CREATE TABLE IF NOT EXISTS shows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    director VARCHAR(255),
+    "cast" TEXT,
+    duration INTEGER CHECK (duration >= 0),
+    start_date TIMESTAMPTZ,
+    end_date TIMESTAMPTZ,
+    timezone VARCHAR(64),
+    start_local VARCHAR(19),
+    end_local VARCHAR(19),
+    price DECIMAL(10,2) CHECK (price >= 0),
+    image_url VARCHAR(500),
+    trailer_url VARCHAR(500),
+    is_featured BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    theatre_id UUID NOT NULL REFERENCES theatres(id),
+    show_type_id UUID NOT NULL REFERENCES show_types(id),
+    CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_locations_coordinates ON locations(latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_locations_active ON locations(is_active);
+CREATE INDEX IF NOT EXISTS idx_theatre_types_deleted_at ON theatre_types(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_show_types_deleted_at ON show_types(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_theatres_deleted_at ON theatres(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_theatres_location ON theatres(location_id);
+CREATE INDEX IF NOT EXISTS idx_theatres_type ON theatres(theatre_type_id);
+CREATE INDEX IF NOT EXISTS idx_theatres_active ON theatres(is_active);
+CREATE INDEX IF NOT EXISTS idx_theatres_featured ON theatres(is_featured);
+CREATE INDEX IF NOT EXISTS idx_shows_deleted_at ON shows(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_shows_theatre ON shows(theatre_id);
+CREATE INDEX IF NOT EXISTS idx_shows_type ON shows(show_type_id);
+CREATE INDEX IF NOT EXISTS idx_shows_active ON shows(is_active);
+CREATE INDEX IF NOT EXISTS idx_shows_featured ON shows(is_featured);
+CREATE INDEX IF NOT EXISTS idx_shows_dates ON shows(start_date, end_date);
@@ -0,0 +1,4 @@
+-- Intentionally empty: the legacy schemas this reconciles were inconsistent with the
+-- application, and restoring their NOT NULL and CHECK constraints would reject rows
+-- the API has since accepted.
+SELECT 1;
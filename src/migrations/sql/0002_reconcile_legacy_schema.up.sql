@@ -0,0 +1,97 @@
+-- Databases created before versioned migrations came from either the old init.sql or
+-- GORM's AutoMigrate, which disagreed with each other and with the models. The tables
+-- already existed, so 0001 left them alone; bring both variants in line with it here.
+-- On a database created by 0001 every statement leaves the schema unchanged.
+
+-- Old init.sql: venue timezones, local show times and calendar sequences came later
+ALTER TABLE locations
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE shows
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS start_local VARCHAR(19),
+    ADD COLUMN IF NOT EXISTS end_local VARCHAR(19),
+    ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+
+-- Old init.sql: stricter columns than the API accepts
+ALTER TABLE locations
+    ALTER COLUMN city TYPE VARCHAR(100),
+    ALTER COLUMN state TYPE VARCHAR(100),
+    ALTER COLUMN state DROP NOT NULL,
+    ALTER COLUMN country TYPE VARCHAR(100),
+    ALTER COLUMN latitude TYPE DOUBLE PRECISION,
+    ALTER COLUMN latitude DROP NOT NULL,
+    ALTER COLUMN longitude TYPE DOUBLE PRECISION,
+    ALTER COLUMN longitude DROP NOT NULL;
+
+ALTER TABLE theatres DROP CONSTRAINT IF EXISTS theatres_capacity_check;
+ALTER TABLE theatres ADD CONSTRAINT theatres_capacity_check CHECK (capacity >= 0);
+ALTER TABLE theatres
+    ALTER COLUMN capacity DROP NOT NULL,
+    ALTER COLUMN phone TYPE VARCHAR(20),
+    ALTER COLUMN website TYPE VARCHAR(500),
+    ALTER COLUMN image_url TYPE VARCHAR(500);
+
+ALTER TABLE shows DROP CONSTRAINT IF EXISTS shows_duration_check;
+ALTER TABLE shows ADD CONSTRAINT shows_duration_check CHECK (duration >= 0);
+ALTER TABLE shows
+    ALTER COLUMN duration DROP NOT NULL,
+    ALTER COLUMN image_url TYPE VARCHAR(500),
+    ALTER COLUMN trailer_url TYPE VARCHAR(500);
+
+-- AutoMigrate: nullable flags and timestamps, date-only show dates, bigint sequence
+UPDATE locations SET is_active = true WHERE is_active IS NULL;
+UPDATE theatre_types SET is_active = true WHERE is_active IS NULL;
+UPDATE show_types SET is_active = true WHERE is_active IS NULL;
+UPDATE theatres SET is_active = true WHERE is_active IS NULL;
+UPDATE theatres SET is_featured = false WHERE is_featured IS NULL;
+UPDATE shows SET is_active = true WHERE is_active IS NULL;
+UPDATE shows SET is_featured = false WHERE is_featured IS NULL;
+
+ALTER TABLE locations
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE theatre_types
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE show_types
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE theatres
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN is_featured SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+ALTER TABLE shows
+    ALTER COLUMN start_date TYPE TIMESTAMPTZ,
+    ALTER COLUMN end_date TYPE TIMESTAMPTZ,
+    ALTER COLUMN sequence TYPE INTEGER,
+    ALTER COLUMN is_active SET NOT NULL,
+    ALTER COLUMN is_featured SET NOT NULL,
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET DEFAULT NOW();
+
+UPDATE locations SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE locations SET updated_at = NOW() WHERE updated_at IS NULL;
+UPDATE theatre_types SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE theatre_types SET updated_at = NOW() WHERE updated_at IS NULL;
+UPDATE show_types SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE show_types SET updated_at = NOW() WHERE updated_at IS NULL;
+UPDATE theatres SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE theatres SET updated_at = NOW() WHERE updated_at IS NULL;
+UPDATE shows SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE shows SET updated_at = NOW() WHERE updated_at IS NULL;
+
+ALTER TABLE locations ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE theatre_types ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE show_types ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE theatres ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+ALTER TABLE shows ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_theatre_types_deleted_at ON theatre_types(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_show_types_deleted_at ON show_types(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_theatres_deleted_at ON theatres(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_shows_deleted_at ON shows(deleted_at);
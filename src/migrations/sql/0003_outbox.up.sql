@@ -0,0 +1,22 @@
+-- Domain events are written here in the same transaction as the change they describe,
+-- then delivered to the configured sinks by the outbox relay
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sequence BIGSERIAL NOT NULL UNIQUE,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    request_id VARCHAR(128),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+-- The relay polls for due messages and checks each aggregate for older undelivered ones
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_due ON outbox_messages (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_aggregate ON outbox_messages (aggregate_type, aggregate_id, sequence) WHERE status <> 'delivered';
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_status ON outbox_messages (status, sequence);
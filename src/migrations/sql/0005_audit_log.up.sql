@@ -0,0 +1,16 @@
+-- Who changed what: one row per create, update or delete, with a field-level diff
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128),
+    ip_address VARCHAR(45),
+    changes JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_entries (actor, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_created_at ON audit_entries (created_at);
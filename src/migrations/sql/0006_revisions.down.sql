@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS theatre_revisions;
+DROP TABLE IF EXISTS show_revisions;
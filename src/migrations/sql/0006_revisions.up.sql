@@ -0,0 +1,27 @@
+-- Numbered snapshots of a show's editable fields after every change, for history and point-in-time restore
+CREATE TABLE IF NOT EXISTS show_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    show_id UUID NOT NULL REFERENCES shows(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('baseline', 'create', 'update', 'restore')),
+    restored_from INTEGER,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128),
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (show_id, revision)
+);
+
+-- The same for theatres
+CREATE TABLE IF NOT EXISTS theatre_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    theatre_id UUID NOT NULL REFERENCES theatres(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('baseline', 'create', 'update', 'restore')),
+    restored_from INTEGER,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(128),
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (theatre_id, revision)
+);
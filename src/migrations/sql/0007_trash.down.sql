@@ -0,0 +1,12 @@
+-- The old check rejects the restore and purge entries, so they go
+DELETE FROM audit_entries WHERE action IN ('restore', 'purge');
+ALTER TABLE audit_entries DROP CONSTRAINT IF EXISTS audit_entries_action_check;
+ALTER TABLE audit_entries ADD CONSTRAINT audit_entries_action_check
+    CHECK (action IN ('create', 'update', 'delete'));
+
+-- Fails while a deleted type shares its name with a live one; purge or rename it first
+DROP INDEX IF EXISTS idx_show_types_name_live;
+ALTER TABLE show_types ADD CONSTRAINT show_types_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_theatre_types_name_live;
+ALTER TABLE theatre_types ADD CONSTRAINT theatre_types_name_key UNIQUE (name);
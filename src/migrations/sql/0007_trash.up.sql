@@ -0,0 +1,17 @@
+-- A soft-deleted type no longer holds on to its name; only live rows must be unique.
+-- Databases from 0001 or the old init.sql name the constraint *_name_key, AutoMigrate
+-- used uni_* (constraint) or idx_* (index).
+ALTER TABLE theatre_types DROP CONSTRAINT IF EXISTS theatre_types_name_key;
+ALTER TABLE theatre_types DROP CONSTRAINT IF EXISTS uni_theatre_types_name;
+DROP INDEX IF EXISTS idx_theatre_types_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_theatre_types_name_live ON theatre_types (name) WHERE deleted_at IS NULL;
+
+ALTER TABLE show_types DROP CONSTRAINT IF EXISTS show_types_name_key;
+ALTER TABLE show_types DROP CONSTRAINT IF EXISTS uni_show_types_name;
+DROP INDEX IF EXISTS idx_show_types_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_show_types_name_live ON show_types (name) WHERE deleted_at IS NULL;
+
+-- Restores from and purges of the trash are audited too
+ALTER TABLE audit_entries DROP CONSTRAINT IF EXISTS audit_entries_action_check;
+ALTER TABLE audit_entries ADD CONSTRAINT audit_entries_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
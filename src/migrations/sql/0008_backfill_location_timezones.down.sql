@@ -0,0 +1,3 @@
+-- Intentionally empty: the derived timezones are what the API would have assigned, and
+-- the UTC they replaced was never chosen by anyone.
+SELECT 1;
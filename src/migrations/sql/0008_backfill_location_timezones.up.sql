@@ -0,0 +1,4 @@
+-- Locations that predate venue timezones took the column default, UTC, whatever their
+-- coordinates. The backfill registered for this version derives their timezones in Go,
+-- where the timezone polygons live, and re-localizes their shows in this transaction.
+SELECT 1;
@@ -0,0 +1,91 @@
+-- The tables and indexes of the init.sql that created databases before versioned migrations
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(255) NOT NULL,
+    state VARCHAR(255) NOT NULL,
+    country VARCHAR(255) NOT NULL,
+    latitude DECIMAL(10,8) NOT NULL,
+    longitude DECIMAL(11,8) NOT NULL,
+    postal_code VARCHAR(20),
+    address TEXT,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS theatre_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS show_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true
+);
+
+CREATE TABLE IF NOT EXISTS theatres (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    address TEXT,
+    phone VARCHAR(50),
+    email VARCHAR(255),
+    website VARCHAR(255),
+    image_url TEXT,
+    is_featured BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    location_id UUID NOT NULL REFERENCES locations(id),
+    theatre_type_id UUID NOT NULL REFERENCES theatre_types(id)
+);
+
+CREATE TABLE IF NOT EXISTS shows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    director VARCHAR(255),
+    "cast" TEXT,
+    duration INTEGER NOT NULL CHECK (duration > 0),
+    start_date TIMESTAMPTZ,
+    end_date TIMESTAMPTZ,
+    price DECIMAL(10,2) CHECK (price >= 0),
+    image_url TEXT,
+    trailer_url TEXT,
+    is_featured BOOLEAN NOT NULL DEFAULT false,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    theatre_id UUID NOT NULL REFERENCES theatres(id),
+    show_type_id UUID NOT NULL REFERENCES show_types(id),
+    CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_locations_coordinates ON locations(latitude, longitude);
+CREATE INDEX IF NOT EXISTS idx_locations_active ON locations(is_active);
+CREATE INDEX IF NOT EXISTS idx_theatres_location ON theatres(location_id);
+CREATE INDEX IF NOT EXISTS idx_theatres_type ON theatres(theatre_type_id);
+CREATE INDEX IF NOT EXISTS idx_theatres_active ON theatres(is_active);
+CREATE INDEX IF NOT EXISTS idx_theatres_featured ON theatres(is_featured);
+CREATE INDEX IF NOT EXISTS idx_shows_theatre ON shows(theatre_id);
+CREATE INDEX IF NOT EXISTS idx_shows_type ON shows(show_type_id);
+CREATE INDEX IF NOT EXISTS idx_shows_active ON shows(is_active);
+CREATE INDEX IF NOT EXISTS idx_shows_featured ON shows(is_featured);
+CREATE INDEX IF NOT EXISTS idx_shows_dates ON shows(start_date, end_date);
@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// columnInfo is a column as reported by information_schema
+type columnInfo struct {
+	ColumnName       string
+	DataType         string
+	CharacterMaximum *int
+	NumericPrecision *int
+	NumericScale     *int
+	IsNullable       string
+}
+
+// typeSizePattern strips the whitespace GORM and Postgres disagree on, e.g. "decimal(10, 2)"
+var typeSizePattern = regexp.MustCompile(`\s+`)
+
+// Verify compares the GORM models against the migrated schema and returns every mismatch;
+// an empty result means the models and migrations agree
+func (m *Migrator) Verify(ctx context.Context, models ...interface{}) ([]string, error) {
+	var mismatches []string
+
+	for _, model := range models {
+		stmt := m.db.WithContext(ctx).Model(model).Statement
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		var columns []columnInfo
+		err := m.db.WithContext(ctx).Raw(`
+			SELECT column_name, data_type, character_maximum_length AS character_maximum,
+			       numeric_precision, numeric_scale, is_nullable
+			FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ?`, table).Scan(&columns).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) == 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: table does not exist", table))
+			continue
+		}
+
+		byName := make(map[string]columnInfo, len(columns))
+		for _, column := range columns {
+			byName[column.ColumnName] = column
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			column, ok := byName[field.DBName]
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: column does not exist", table, field.DBName))
+				continue
+			}
+			delete(byName, field.DBName)
+
+			expected := normalizeType(m.db.Dialector.DataTypeOf(field))
+			actual := columnType(column)
+			if expected != actual {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: model type %s, schema type %s", table, field.DBName, expected, actual))
+			}
+
+			modelNullable := !field.NotNull && !field.PrimaryKey
+			schemaNullable := column.IsNullable == "YES"
+			if modelNullable != schemaNullable {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: model nullable %t, schema nullable %t", table, field.DBName, modelNullable, schemaNullable))
+			}
+		}
+
+		for name := range byName {
+			mismatches = append(mismatches, fmt.Sprintf("%s.%s: column has no model field", table, name))
+		}
+	}
+
+	return mismatches, nil
+}
+
+// columnType renders an information_schema column in the same spelling GORM uses
+func columnType(column columnInfo) string {
+	switch column.DataType {
+	case "character varying":
+		if column.CharacterMaximum != nil {
+			return fmt.Sprintf("varchar(%d)", *column.CharacterMaximum)
+		}
+		return "varchar"
+	case "numeric":
+		if column.NumericPrecision != nil && column.NumericScale != nil {
+			return fmt.Sprintf("decimal(%d,%d)", *column.NumericPrecision, *column.NumericScale)
+		}
+		return "decimal"
+	}
+	return normalizeType(column.DataType)
+}
+
+// normalizeType maps Postgres type aliases onto one spelling
+func normalizeType(dataType string) string {
+	dataType = typeSizePattern.ReplaceAllString(strings.ToLower(dataType), "")
+
+	switch {
+	case strings.HasPrefix(dataType, "timestampwithtimezone"), strings.HasPrefix(dataType, "timestamptz"):
+		return "timestamptz"
+	case strings.HasPrefix(dataType, "timestampwithouttimezone"), dataType == "timestamp":
+		return "timestamp"
+	case dataType == "doubleprecision", dataType == "float8":
+		return "double precision"
+	case dataType == "int", dataType == "int4":
+		return "integer"
+	case dataType == "int8":
+		return "bigint"
+	case dataType == "bool":
+		return "boolean"
+	case strings.HasPrefix(dataType, "numeric"):
+		return "decimal" + strings.TrimPrefix(dataType, "numeric")
+	case strings.HasPrefix(dataType, "charactervarying"):
+		return "varchar" + strings.TrimPrefix(dataType, "charactervarying")
+	}
+	return dataType
+}
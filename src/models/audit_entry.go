@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditEntry records one create, update or delete: who made it, from where, and which fields changed
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EntityType string          `json:"entity_type" gorm:"type:varchar(50);not null"`
+	EntityID   uuid.UUID       `json:"entity_id" gorm:"type:uuid;not null"`
+	Action     string          `json:"action" gorm:"type:varchar(20);not null"`
+	Actor      string          `json:"actor" gorm:"type:varchar(255);not null"`
+	RequestID  string          `json:"request_id" gorm:"type:varchar(128)"`
+	IPAddress  string          `json:"ip_address" gorm:"type:varchar(45)"`
+	Changes    json.RawMessage `json:"changes" gorm:"type:jsonb;not null"` // field name to {"old": ..., "new": ...}
+	CreatedAt  time.Time       `json:"created_at" gorm:"not null"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (a *AuditEntry) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AuditFilter narrows an audit log query; zero fields match everything
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	Action     string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportJob tracks the progress and outcome of a bulk import
+type ImportJob struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Status      string          `json:"status" gorm:"type:varchar(20);not null"`
+	Processed   int             `json:"processed" gorm:"type:integer;not null;default:0"`
+	Total       int             `json:"total" gorm:"type:integer;not null"`
+	Error       string          `json:"error" gorm:"type:text"`
+	Report      json.RawMessage `json:"report" gorm:"type:jsonb"` // the dto.ImportReport, once the job finishes
+	CreatedAt   time.Time       `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"not null"`
+	CompletedAt *time.Time      `json:"completed_at" gorm:"type:timestamptz"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (j *ImportJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
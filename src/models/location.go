@@ -19,9 +19,10 @@ type Location struct {
 	PostalCode  string         `json:"postal_code" gorm:"type:varchar(20)" validate:"max=20"`
 	Address     string         `json:"address" gorm:"type:text" validate:"max=500"`
 	Description string         `json:"description" gorm:"type:text" validate:"max=1000"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	Timezone    string         `json:"timezone" gorm:"type:varchar(64);not null;default:'UTC'" validate:"omitempty,timezone,max=64"` // IANA timezone name
+	IsActive    bool           `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Relationships
@@ -0,0 +1,20 @@
+package models
+
+// All returns every persisted model, in dependency order
+func All() []interface{} {
+	return []interface{}{
+		&Location{},
+		&TheatreType{},
+		&ShowType{},
+		&Theatre{},
+		&Show{},
+		&OutboxMessage{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+		&WebhookDeliveryAttempt{},
+		&AuditEntry{},
+		&ShowRevision{},
+		&TheatreRevision{},
+		&ImportJob{},
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxMessage is a domain event recorded alongside the change it describes, awaiting delivery to the sinks
+type OutboxMessage struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Sequence      int64           `json:"sequence" gorm:"type:bigint;not null;->"` // assigned by the database; orders messages of one aggregate
+	AggregateType string          `json:"aggregate_type" gorm:"type:varchar(50);not null"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" gorm:"type:uuid;not null"`
+	EventName     string          `json:"event_name" gorm:"type:varchar(100);not null"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	RequestID     string          `json:"request_id" gorm:"type:varchar(128)"`
+	Status        string          `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts      int             `json:"attempts" gorm:"type:integer;not null;default:0"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" gorm:"type:timestamptz;not null;default:now()"`
+	LastError     string          `json:"last_error" gorm:"type:text"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"not null"`
+	DeliveredAt   *time.Time      `json:"delivered_at" gorm:"type:timestamptz"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (m *OutboxMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShowRevision is a numbered snapshot of a show's editable fields, taken after every change
+type ShowRevision struct {
+	ID           uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ShowID       uuid.UUID       `json:"show_id" gorm:"type:uuid;not null"`
+	Revision     int             `json:"revision" gorm:"type:integer;not null"`
+	Action       string          `json:"action" gorm:"type:varchar(20);not null"`
+	RestoredFrom *int            `json:"restored_from" gorm:"type:integer"` // the revision a restore copied
+	Actor        string          `json:"actor" gorm:"type:varchar(255);not null"`
+	RequestID    string          `json:"request_id" gorm:"type:varchar(128)"`
+	Snapshot     json.RawMessage `json:"snapshot" gorm:"type:jsonb;not null"` // the show as a create/update request body
+	CreatedAt    time.Time       `json:"created_at" gorm:"not null"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (r *ShowRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TheatreRevision is a numbered snapshot of a theatre's editable fields, taken after every change
+type TheatreRevision struct {
+	ID           uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TheatreID    uuid.UUID       `json:"theatre_id" gorm:"type:uuid;not null"`
+	Revision     int             `json:"revision" gorm:"type:integer;not null"`
+	Action       string          `json:"action" gorm:"type:varchar(20);not null"`
+	RestoredFrom *int            `json:"restored_from" gorm:"type:integer"` // the revision a restore copied
+	Actor        string          `json:"actor" gorm:"type:varchar(255);not null"`
+	RequestID    string          `json:"request_id" gorm:"type:varchar(128)"`
+	Snapshot     json.RawMessage `json:"snapshot" gorm:"type:jsonb;not null"` // the theatre as a create/update request body
+	CreatedAt    time.Time       `json:"created_at" gorm:"not null"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (r *TheatreRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -15,15 +15,19 @@ type Show struct {
 	Director    string         `json:"director" gorm:"type:varchar(255)" validate:"max=255"`
 	Cast        string         `json:"cast" gorm:"type:text" validate:"max=1000"`
 	Duration    int            `json:"duration" gorm:"type:integer" validate:"omitempty,min=1,max=600"` // Duration in minutes
-	StartDate   *time.Time     `json:"start_date" gorm:"type:date"`
-	EndDate     *time.Time     `json:"end_date" gorm:"type:date"`
+	StartDate   *time.Time     `json:"start_date" gorm:"type:timestamptz"`
+	EndDate     *time.Time     `json:"end_date" gorm:"type:timestamptz"`
+	Timezone    string         `json:"timezone" gorm:"type:varchar(64)"`    // Venue timezone the local times are expressed in
+	StartLocal  string         `json:"start_local" gorm:"type:varchar(19)"` // Venue wall-clock start, e.g. 2025-10-18T19:30:00
+	EndLocal    string         `json:"end_local" gorm:"type:varchar(19)"`   // Venue wall-clock end
 	Price       float64        `json:"price" gorm:"type:decimal(10,2)" validate:"omitempty,min=0"`
 	ImageURL    string         `json:"image_url" gorm:"type:varchar(500)" validate:"omitempty,url,max=500"`
 	TrailerURL  string         `json:"trailer_url" gorm:"type:varchar(500)" validate:"omitempty,url,max=500"`
-	IsFeatured  bool           `json:"is_featured" gorm:"default:false"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	IsFeatured  bool           `json:"is_featured" gorm:"not null;default:false"`
+	IsActive    bool           `json:"is_active" gorm:"not null;default:true"`
+	Sequence    int            `json:"sequence" gorm:"type:integer;not null;default:0"` // iCalendar revision, bumped on every update
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Foreign Keys
@@ -18,10 +18,10 @@ type Theatre struct {
 	Email       string         `json:"email" gorm:"type:varchar(255)" validate:"omitempty,email,max=255"`
 	Website     string         `json:"website" gorm:"type:varchar(500)" validate:"omitempty,url,max=500"`
 	ImageURL    string         `json:"image_url" gorm:"type:varchar(500)" validate:"omitempty,url,max=500"`
-	IsFeatured  bool           `json:"is_featured" gorm:"default:false"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	IsFeatured  bool           `json:"is_featured" gorm:"not null;default:false"`
+	IsActive    bool           `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"not null"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 
 	// Foreign Keys
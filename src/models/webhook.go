@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription is a partner endpoint that receives signed deliveries of the events it subscribes to
+type WebhookSubscription struct {
+	ID                  uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	URL                 string         `json:"url" gorm:"type:varchar(500);not null"`
+	Secret              string         `json:"-" gorm:"type:varchar(255);not null"`
+	Description         string         `json:"description" gorm:"type:text"`
+	EventTypes          []string       `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	TheatreID           *uuid.UUID     `json:"theatre_id" gorm:"type:uuid"`   // only events about this theatre or its shows
+	ShowTypeID          *uuid.UUID     `json:"show_type_id" gorm:"type:uuid"` // only events about this show type or its shows
+	IsActive            bool           `json:"is_active" gorm:"not null;default:true"`
+	ConsecutiveFailures int            `json:"consecutive_failures" gorm:"type:integer;not null;default:0"`
+	DisabledAt          *time.Time     `json:"disabled_at" gorm:"type:timestamptz"`
+	DisabledReason      string         `json:"disabled_reason" gorm:"type:text"`
+	CreatedAt           time.Time      `json:"created_at" gorm:"not null"`
+	UpdatedAt           time.Time      `json:"updated_at" gorm:"not null"`
+	DeletedAt           gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// WebhookDelivery is one event queued for one subscription, retried until it succeeds or runs out of attempts
+type WebhookDelivery struct {
+	ID                 uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SubscriptionID     uuid.UUID       `json:"subscription_id" gorm:"type:uuid;not null"`
+	OutboxMessageID    *uuid.UUID      `json:"outbox_message_id" gorm:"type:uuid"` // nil for test events
+	EventName          string          `json:"event_name" gorm:"type:varchar(100);not null"`
+	Payload            json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status             string          `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts           int             `json:"attempts" gorm:"type:integer;not null;default:0"`
+	NextAttemptAt      time.Time       `json:"next_attempt_at" gorm:"type:timestamptz;not null;default:now()"`
+	LastResponseStatus *int            `json:"last_response_status" gorm:"type:integer"`
+	LastError          string          `json:"last_error" gorm:"type:text"`
+	CreatedAt          time.Time       `json:"created_at" gorm:"not null"`
+	CompletedAt        *time.Time      `json:"completed_at" gorm:"type:timestamptz"`
+
+	// Relationships
+	Subscription WebhookSubscription      `json:"subscription,omitempty" gorm:"foreignKey:SubscriptionID"`
+	Log          []WebhookDeliveryAttempt `json:"log,omitempty" gorm:"foreignKey:DeliveryID"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// WebhookDeliveryAttempt records one HTTP request made for a delivery
+type WebhookDeliveryAttempt struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	DeliveryID     uuid.UUID `json:"delivery_id" gorm:"type:uuid;not null"`
+	Attempt        int       `json:"attempt" gorm:"type:integer;not null"`
+	ResponseStatus *int      `json:"response_status" gorm:"type:integer"` // nil when no response arrived
+	ResponseBody   string    `json:"response_body" gorm:"type:text"`
+	Error          string    `json:"error" gorm:"type:text"`
+	DurationMs     int       `json:"duration_ms" gorm:"type:integer;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"not null"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (a *WebhookDeliveryAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Succeeded reports whether the endpoint answered with a 2xx status
+func (a *WebhookDeliveryAttempt) Succeeded() bool {
+	return a.ResponseStatus != nil && *a.ResponseStatus >= 200 && *a.ResponseStatus <= 299
+}
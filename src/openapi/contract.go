@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/controllers"
+	"theatre-management-system/src/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContractOptions decides how strictly requests and responses are held to the document
+type ContractOptions struct {
+	Strict            bool // reject unknown query parameters and JSON fields
+	ValidateResponses bool // log responses that don't match the document
+}
+
+// Contract checks requests, and optionally responses, against the document it enforces. Its middleware is
+// installed before the routes are registered, while the document describing them is generated after, so
+// requests pass unchecked until Enforce hands it the document
+type Contract struct {
+	options ContractOptions
+	doc     *Document
+}
+
+// NewContract creates a contract that enforces nothing until given a document
+func NewContract(options ContractOptions) *Contract {
+	return &Contract{options: options}
+}
+
+// Enforce starts checking against doc; call it before the server starts
+func (ct *Contract) Enforce(doc *Document) {
+	ct.doc = doc
+}
+
+// Middleware rejects requests whose path parameters, query parameters or JSON body don't match the route's
+// operation with 400, listing every violation; routes the document doesn't describe pass unchecked
+func (ct *Contract) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ct.doc == nil {
+			c.Next()
+			return
+		}
+		op := ct.doc.Operation(c.Request.Method, Path(c.FullPath()))
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		violations, err := ct.checkRequest(c, op)
+		if err != nil {
+			controllers.BadRequestResponse(c, constants.ErrorInvalidInput, err)
+			c.Abort()
+			return
+		}
+		if len(violations) > 0 {
+			rejection := &dto.ContractViolations{Violations: violations}
+			controllers.ErrorResponseWithData(c, http.StatusBadRequest, constants.ErrorContractViolation, rejection, rejection)
+			c.Abort()
+			return
+		}
+
+		if !ct.options.ValidateResponses {
+			c.Next()
+			return
+		}
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		ct.checkResponse(c, op, recorder)
+	}
+}
+
+// checkRequest lists the ways the request breaks op; an error means the body couldn't be read
+func (ct *Contract) checkRequest(c *gin.Context, op *Operation) ([]dto.Violation, error) {
+	var violations []dto.Violation
+	known := make(map[string]bool)
+	for _, parameter := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch parameter.In {
+		case "path":
+			value, present = c.Param(parameter.Name), true
+		case "query":
+			known[parameter.Name] = true
+			value, present = c.GetQuery(parameter.Name)
+		default:
+			continue
+		}
+		if !present || value == "" {
+			if parameter.Required {
+				violations = append(violations, dto.Violation{In: parameter.In, Name: parameter.Name, Message: "is required"})
+			}
+			continue
+		}
+
+		check := &validator{schemas: ct.doc.Components.Schemas, in: parameter.In}
+		parsed, ok := parseParameter(parameter.Schema, value)
+		if !ok {
+			violations = append(violations, check.violation(parameter.Name, "must be %s", describeType(parameter.Schema.Type)))
+			continue
+		}
+		violations = append(violations, check.validate(parameter.Schema, parsed, parameter.Name)...)
+	}
+
+	if ct.options.Strict {
+		for name := range c.Request.URL.Query() {
+			if !known[name] {
+				violations = append(violations, dto.Violation{In: "query", Name: name, Message: "is not a known parameter"})
+			}
+		}
+	}
+
+	bodyViolations, err := ct.checkBody(c, op)
+	return append(violations, bodyViolations...), err
+}
+
+// checkBody validates a JSON request body, leaving it in place for the handler to bind
+func (ct *Contract) checkBody(c *gin.Context, op *Operation) ([]dto.Violation, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+	media, ok := op.RequestBody.Content[gin.MIMEJSON]
+	if !ok {
+		return nil, nil // uploads are checked by their handler
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []dto.Violation{{In: "body", Message: "is required"}}, nil
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		return []dto.Violation{{In: "body", Message: "must be valid JSON: " + err.Error()}}, nil
+	}
+
+	check := &validator{schemas: ct.doc.Components.Schemas, strict: ct.options.Strict, in: "body"}
+	return check.validate(media.Schema, value, ""), nil
+}
+
+// checkResponse logs the ways a JSON response breaks op; the client has already been sent it
+func (ct *Contract) checkResponse(c *gin.Context, op *Operation, recorder *responseRecorder) {
+	status := c.Writer.Status()
+	if status == constants.StatusClientClosedRequest {
+		return // nobody was sent anything
+	}
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		slog.WarnContext(c.Request.Context(), "Response status is not in the OpenAPI document",
+			"method", c.Request.Method, "route", c.FullPath(), "status", status)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(c.Writer.Header().Get("Content-Type"))
+	media, ok := response.Content[contentType]
+	if contentType != gin.MIMEJSON || !ok || recorder.skipped || recorder.body.Len() == 0 {
+		return
+	}
+
+	value, err := decodeJSON(recorder.body.Bytes())
+	var violations []dto.Violation
+	if err != nil {
+		violations = []dto.Violation{{In: "response", Message: "must be valid JSON: " + err.Error()}}
+	} else {
+		check := &validator{schemas: ct.doc.Components.Schemas, in: "response"}
+		violations = check.validate(media.Schema, value, "")
+	}
+	if len(violations) > 0 {
+		slog.WarnContext(c.Request.Context(), "Response does not match the OpenAPI document",
+			"method", c.Request.Method, "route", c.FullPath(), "status", status,
+			"violations", summarize(violations))
+	}
+}
+
+// summarize lists violations as "name: message" for logs
+func summarize(violations []dto.Violation) string {
+	problems := make([]string, len(violations))
+	for i, violation := range violations {
+		problems[i] = violation.Name + ": " + violation.Message
+	}
+	return strings.Join(problems, "; ")
+}
+
+// decodeJSON decodes a whole document, keeping numbers as written
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+// responseRecorder keeps a copy of a JSON response as it is written, up to constants.ContractMaxResponseBytes
+type responseRecorder struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	skipped bool // not JSON, or too big to check
+}
+
+// Write passes data on to the client, keeping a copy
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if !r.skipped {
+		if !strings.HasPrefix(r.Header().Get("Content-Type"), gin.MIMEJSON) || r.body.Len()+len(data) > constants.ContractMaxResponseBytes {
+			r.skipped = true
+			r.body.Reset()
+		} else {
+			r.body.Write(data)
+		}
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+// WriteString passes s on to the client, keeping a copy
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	return r.Write([]byte(s))
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"theatre-management-system/src/controllers"
+	"theatre-management-system/src/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newContractRouter serves a few documented routes behind the contract middleware. Handlers answer 200,
+// and the webhook handler binds its body into bound, the way the real one does
+func newContractRouter(options ContractOptions, bound *dto.WebhookBase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	contract := NewContract(options)
+	router.Use(contract.Middleware())
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/shows", ok)
+	router.GET("/api/v1/shows/:id/revisions/:revision", ok)
+	router.POST("/api/v1/locations/batch", ok)
+	router.POST("/api/v1/webhooks", func(c *gin.Context) {
+		if err := c.ShouldBindJSON(bound); err != nil {
+			controllers.BadRequestResponse(c, "bind", err)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	contract.Enforce(Generate(router.Routes(), Options{}))
+	return router
+}
+
+func TestContractMiddleware(t *testing.T) {
+	const (
+		showID  = "3f1c2a9e-7b4d-4c1e-9a53-2f0e8d6b7c41"
+		webhook = `{"url": "https://example.com/hook", "event_types": ["show.*"]}`
+	)
+
+	tests := []struct {
+		name       string
+		strict     bool
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		want       []dto.Violation
+	}{
+		{name: "valid query", method: http.MethodGet, target: "/api/v1/shows?limit=20&offset=40&format=csv", wantStatus: http.StatusOK},
+		{name: "limit 0 means the default", method: http.MethodGet, target: "/api/v1/shows?limit=0", wantStatus: http.StatusOK},
+		{name: "non-integer query parameter", method: http.MethodGet, target: "/api/v1/shows?limit=ten",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "query", Name: "limit", Message: "must be an integer"}}},
+		{name: "query parameter below its minimum", method: http.MethodGet, target: "/api/v1/shows?offset=-1",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "query", Name: "offset", Message: "must be at least 0"}}},
+		{name: "query parameter outside its enum", method: http.MethodGet, target: "/api/v1/shows?format=pdf",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "query", Name: "format", Message: "must be one of json, csv, xlsx"}}},
+		{name: "non-boolean query parameter", method: http.MethodPost, target: "/api/v1/locations/batch?atomic=maybe", body: `[]`,
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "query", Name: "atomic", Message: "must be a boolean"}}},
+		{name: "unknown query parameter passes by default", method: http.MethodGet, target: "/api/v1/shows?sort=title", wantStatus: http.StatusOK},
+		{name: "unknown query parameter in strict mode", strict: true, method: http.MethodGet, target: "/api/v1/shows?sort=title",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "query", Name: "sort", Message: "is not a known parameter"}}},
+		{name: "valid path parameters", method: http.MethodGet, target: "/api/v1/shows/" + showID + "/revisions/2", wantStatus: http.StatusOK},
+		{name: "path parameter not a uuid", method: http.MethodGet, target: "/api/v1/shows/42/revisions/2",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "path", Name: "id", Message: "must be a valid uuid"}}},
+		{name: "path parameter not an integer", method: http.MethodGet, target: "/api/v1/shows/" + showID + "/revisions/latest",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "path", Name: "revision", Message: "must be an integer"}}},
+		{name: "valid body", method: http.MethodPost, target: "/api/v1/webhooks", body: webhook, wantStatus: http.StatusOK},
+		{name: "unknown body field passes by default", method: http.MethodPost, target: "/api/v1/webhooks",
+			body: `{"url": "https://example.com/hook", "event_types": ["show.*"], "colour": "blue"}`, wantStatus: http.StatusOK},
+		{name: "unknown body field in strict mode", strict: true, method: http.MethodPost, target: "/api/v1/webhooks",
+			body:       `{"url": "https://example.com/hook", "event_types": ["show.*"], "colour": "blue"}`,
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "body", Name: "colour", Message: "is not a known field"}}},
+		{name: "missing body", method: http.MethodPost, target: "/api/v1/webhooks",
+			wantStatus: http.StatusBadRequest, want: []dto.Violation{{In: "body", Message: "is required"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bound dto.WebhookBase
+			router := newContractRouter(ContractOptions{Strict: tt.strict}, &bound)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", gin.MIMEJSON)
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body %s", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var response struct {
+				Data dto.ContractViolations `json:"data"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			got := response.Data.Violations
+			if len(got) != len(tt.want) {
+				t.Fatalf("violations = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("violation %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestContractMiddlewareLeavesBodyForHandler(t *testing.T) {
+	var bound dto.WebhookBase
+	router := newContractRouter(ContractOptions{Strict: true}, &bound)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks",
+		strings.NewReader(`{"url": "https://example.com/hook", "event_types": ["show.*", "theatre.created"]}`))
+	request.Header.Set("Content-Type", gin.MIMEJSON)
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", recorder.Code, recorder.Body)
+	}
+	if bound.URL != "https://example.com/hook" || len(bound.EventTypes) != 2 || bound.EventTypes[1] != "theatre.created" {
+		t.Errorf("handler bound %+v, want the request body", bound)
+	}
+}
@@ -0,0 +1,228 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/controllers"
+	"theatre-management-system/src/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyScheme names the API key security scheme
+const apiKeyScheme = "apiKey"
+
+// Options describes how the deployment serving the document is configured
+type Options struct {
+	APIKeyHeader string // the header writes must carry when API key auth is on; empty when it is off
+}
+
+// Generate describes the registered routes the route table knows; Undocumented lists any others
+func Generate(registered gin.RoutesInfo, options Options) *Document {
+	registry := newSchemaRegistry()
+	envelope := registry.of(controllers.APIResponse{})
+
+	doc := &Document{
+		OpenAPI: constants.OpenAPIVersion,
+		Info: Info{
+			Title:       constants.OpenAPITitle,
+			Version:     constants.APIVersion,
+			Description: "Every JSON response is wrapped in the APIResponse envelope; data carries the result.",
+		},
+		Paths: make(map[string]*PathItem),
+	}
+	if options.APIKeyHeader != "" {
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+			apiKeyScheme: {Type: "apiKey", In: "header", Name: options.APIKeyHeader},
+		}
+	}
+
+	described := describedRoutes()
+	tagged := make(map[string]bool)
+	for _, info := range registered {
+		rt, ok := described[info.Method+" "+info.Path]
+		if !ok {
+			continue
+		}
+
+		path := Path(info.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		op := item.operation(info.Method)
+		if op == nil {
+			continue
+		}
+		*op = operation(rt, registry, envelope, options)
+
+		tag := (*op).Tags[0]
+		if !tagged[tag] {
+			tagged[tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: tag})
+		}
+	}
+
+	doc.Components.Schemas = registry.components
+	return doc
+}
+
+// Undocumented lists the registered routes the document doesn't describe, as "METHOD /path"
+func Undocumented(doc *Document, registered gin.RoutesInfo) []string {
+	var missing []string
+	for _, info := range registered {
+		if doc.Operation(info.Method, Path(info.Path)) == nil {
+			missing = append(missing, info.Method+" "+info.Path)
+		}
+	}
+	return missing
+}
+
+// Path converts a gin route path, with :name and *name parameters, to an OpenAPI one with {name}
+func Path(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// describedRoutes indexes the route table by method and path
+func describedRoutes() map[string]route {
+	described := make(map[string]route, len(routes))
+	for _, rt := range routes {
+		described[rt.method+" "+rt.path] = rt
+	}
+	return described
+}
+
+// operation describes one route
+func operation(rt route, registry *schemaRegistry, envelope *Schema, options Options) *Operation {
+	op := &Operation{
+		OperationID: rt.id,
+		Summary:     rt.summary,
+		Tags:        []string{tag(rt.path)},
+		Responses:   make(map[string]*Response),
+	}
+
+	// Parameters
+	for _, segment := range strings.Split(rt.path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: pathParams[name]})
+		}
+	}
+	op.Parameters = append(op.Parameters, rt.query...)
+	if rt.paged {
+		op.Parameters = append(op.Parameters, limitParam, offsetParam)
+	}
+	if rt.represent == representList {
+		op.Parameters = append(op.Parameters, formatParam)
+	}
+
+	// Request body
+	switch {
+	case rt.body != nil:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{
+			gin.MIMEJSON: {Schema: registry.of(rt.body)},
+		}}
+	case rt.upload:
+		file := &Schema{Type: "string", Format: "binary"}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{
+			gin.MIMEMultipartPOSTForm: {Schema: &Schema{Type: "object", Properties: map[string]*Schema{"file": file}, Required: []string{"file"}}},
+			"text/csv":                {Schema: &Schema{Type: "string"}},
+			"application/x-ndjson":    {Schema: &Schema{Type: "string"}},
+		}}
+	}
+
+	// Successful responses
+	statuses := rt.statuses
+	if len(statuses) == 0 {
+		statuses = []int{http.StatusOK}
+	}
+	for _, status := range statuses {
+		op.Responses[strconv.Itoa(status)] = success(rt, registry, envelope)
+	}
+
+	// Errors: the envelope with error set, carrying data for a request breaking the contract and for a delete
+	// refused over dependents. Any request can be malformed, fail or run out of time
+	failures := append([]int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusGatewayTimeout}, rt.errors...)
+	if strings.Contains(rt.path, "/:") {
+		failures = append(failures, http.StatusNotFound)
+	}
+	if op.RequestBody != nil {
+		failures = append(failures, http.StatusRequestEntityTooLarge)
+	}
+	if options.APIKeyHeader != "" && rt.method != http.MethodGet {
+		op.Security = []map[string][]string{{apiKeyScheme: {}}}
+		failures = append(failures, http.StatusUnauthorized)
+	}
+	for _, status := range failures {
+		schema := envelope
+		switch {
+		case status == http.StatusBadRequest:
+			schema = &Schema{AllOf: []*Schema{envelope, {
+				Type:       "object",
+				Properties: map[string]*Schema{"data": registry.of(dto.ContractViolations{})},
+			}}}
+		case status == http.StatusConflict && rt.conflict != nil:
+			schema = withData(envelope, registry.of(rt.conflict))
+		}
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*MediaType{gin.MIMEJSON: {Schema: schema}},
+		}
+	}
+
+	return op
+}
+
+// success describes a route's successful response in each representation it offers
+func success(rt route, registry *schemaRegistry, envelope *Schema) *Response {
+	if rt.represent == representCalendar {
+		return &Response{Description: "iCalendar feed", Content: map[string]*MediaType{
+			"text/calendar": {Schema: &Schema{Type: "string"}},
+		}}
+	}
+
+	schema := envelope
+	if rt.data != nil {
+		schema = withData(envelope, registry.of(rt.data))
+	}
+	content := map[string]*MediaType{gin.MIMEJSON: {Schema: schema}}
+	switch rt.represent {
+	case representList:
+		content["text/csv"] = &MediaType{Schema: &Schema{Type: "string"}}
+		content[constants.XLSXContentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	case representJSONLD:
+		content[constants.MIMEJSONLD] = &MediaType{Schema: registry.of(rt.alternate)}
+	}
+	return &Response{Description: rt.summary, Content: content}
+}
+
+// withData narrows the envelope's data to schema; a nil list encodes as null
+func withData(envelope, schema *Schema) *Schema {
+	if schema.Type == "array" {
+		schema = nullable(schema)
+	}
+	return &Schema{AllOf: []*Schema{envelope, {
+		Type:       "object",
+		Properties: map[string]*Schema{"data": schema},
+		Required:   []string{"data"},
+	}}}
+}
+
+// tag groups a route with the others for its resource, such as locations or webhooks
+func tag(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok {
+		return "health"
+	}
+	resource, _, _ := strings.Cut(rest, "/")
+	return resource
+}
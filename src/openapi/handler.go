@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// SpecHandler serves the document as JSON
+func SpecHandler(doc *Document) gin.HandlerFunc {
+	body, err := json.Marshal(doc)
+	return func(c *gin.Context) {
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, gin.MIMEJSON+"; charset=utf-8", body)
+	}
+}
+
+// DocsHandler serves the bundled Swagger UI under prefix, a route ending in /*filepath, showing the document at specPath
+func DocsHandler(prefix, specPath string) gin.HandlerFunc {
+	files := http.StripPrefix(prefix, http.FileServer(http.FS(swaggerFiles.FS)))
+	initializer := []byte(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "` + specPath + `",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`)
+
+	return func(c *gin.Context) {
+		// The stock initializer shows the petstore example
+		if c.Param("filepath") == "/swagger-initializer.js" {
+			c.Data(http.StatusOK, "text/javascript; charset=utf-8", initializer)
+			return
+		}
+		files.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,228 @@
+package openapi
+
+import (
+	"net/http"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/controllers"
+	"theatre-management-system/src/dto"
+)
+
+// Representations a route answers with besides the JSON envelope
+const (
+	representJSON     = iota
+	representList     // ?format=csv|xlsx exports the list
+	representJSONLD   // Accept: application/ld+json returns schema.org structured data
+	representCalendar // always iCalendar, never the envelope
+)
+
+// anyOf is data that takes one of several shapes, such as the trash's, which depends on the resource
+type anyOf []interface{}
+
+// route describes what one registered route takes and answers with
+type route struct {
+	method    string
+	path      string // as registered with gin
+	id        string // the operationId
+	summary   string
+	query     []*Parameter
+	paged     bool        // takes limit and offset
+	body      interface{} // the JSON request body, nil when there is none
+	upload    bool        // takes a file, as multipart or the raw request body
+	data      interface{} // the envelope's data on success, nil when there is none
+	statuses  []int       // success statuses; 200 when empty
+	errors    []int       // error statuses beyond those implied by parameters and bodies
+	conflict  interface{} // the envelope's data with a 409
+	represent int
+	alternate interface{} // the JSON-LD document
+}
+
+// Path parameters, by name
+var pathParams = map[string]*Schema{
+	"id":         {Type: "string", Format: "uuid"},
+	"locationId": {Type: "string", Format: "uuid"},
+	"typeId":     {Type: "string", Format: "uuid"},
+	"theatreId":  {Type: "string", Format: "uuid"},
+	"deliveryId": {Type: "string", Format: "uuid"},
+	"revision":   {Type: "integer", Minimum: float(1)},
+	"name":       {Type: "string"},
+	"resource":   {Type: "string", Enum: enum(constants.BatchResourceLocations, constants.BatchResourceTheatreTypes, constants.BatchResourceShowTypes, constants.BatchResourceTheatres, constants.BatchResourceShows)},
+	"entity":     {Type: "string", Enum: enum(constants.ImportEntityLocations, constants.ImportEntityTheatres, constants.ImportEntityShows)},
+}
+
+// Query parameters shared between routes
+var (
+	limitParam      = queryParam("limit", "Page size, capped at pagination.max_limit; 0 or omitted means pagination.default_limit. Exports hold this many rows, or all of them when 0 or omitted", &Schema{Type: "integer"})
+	offsetParam     = queryParam("offset", "Number of items to skip", &Schema{Type: "integer", Minimum: float(0), Default: constants.DefaultOffset})
+	formatParam     = queryParam("format", "Exports the list as CSV or XLSX instead of JSON", &Schema{Type: "string", Enum: enum(constants.ExportFormatJSON, constants.ExportFormatCSV, constants.ExportFormatXLSX)})
+	searchParam     = requiredQueryParam("q", "Search text", &Schema{Type: "string", MinLength: integer(1)})
+	latitudeParam   = requiredQueryParam("latitude", "", &Schema{Type: "number", Minimum: float(-90), Maximum: float(90)})
+	longitudeParam  = requiredQueryParam("longitude", "", &Schema{Type: "number", Minimum: float(-180), Maximum: float(180)})
+	radiusParam     = queryParam("radius", "Search radius in kilometers", &Schema{Type: "number", Default: constants.DefaultRadius})
+	reassignToParam = queryParam("reassign_to", "Where records that belong to this one move to, under the reassign delete policy", &Schema{Type: "string", Format: "uuid"})
+	showTypeParam   = queryParam("show_type_id", "Only shows of this type", &Schema{Type: "string", Format: "uuid"})
+	atomicParam     = queryParam("atomic", "Roll every operation back when any fails", &Schema{Type: "boolean", Default: true})
+	fromRevision    = requiredQueryParam("from", "The older revision", &Schema{Type: "integer", Minimum: float(1)})
+	toRevision      = requiredQueryParam("to", "The newer revision", &Schema{Type: "integer", Minimum: float(1)})
+
+	importParams = []*Parameter{
+		queryParam("dry_run", "Validate every row, write nothing", &Schema{Type: "boolean", Default: false}),
+		queryParam("async", "Run as a background job whatever the file's size", &Schema{Type: "boolean", Default: false}),
+		queryParam("format", "Detected from the file name or content type when omitted", &Schema{Type: "string", Enum: enum(constants.ImportFormatCSV, constants.ImportFormatNDJSON)}),
+		queryParam("mode", "", &Schema{Type: "string", Enum: enum(constants.ImportModeAtomic, constants.ImportModePartial)}),
+	}
+	auditParams = []*Parameter{
+		queryParam("entity", "", &Schema{Type: "string", Enum: enum(constants.EventAggregateLocation, constants.EventAggregateTheatreType, constants.EventAggregateShowType, constants.EventAggregateTheatre, constants.EventAggregateShow)}),
+		queryParam("id", "Entity ID", &Schema{Type: "string", Format: "uuid"}),
+		queryParam("action", "", &Schema{Type: "string", Enum: enum(constants.AuditActionCreate, constants.AuditActionUpdate, constants.AuditActionDelete, constants.AuditActionRestore, constants.AuditActionPurge)}),
+		queryParam("actor", "", &Schema{Type: "string"}),
+		queryParam("from", "Inclusive", &Schema{Type: "string", Format: "date-time"}),
+		queryParam("to", "Exclusive", &Schema{Type: "string", Format: "date-time"}),
+	}
+	outboxStatusParam   = queryParam("status", "", &Schema{Type: "string", Enum: enum(constants.OutboxStatusPending, constants.OutboxStatusDelivered, constants.OutboxStatusFailed)})
+	deliveryStatusParam = queryParam("status", "", &Schema{Type: "string", Enum: enum(constants.WebhookDeliveryPending, constants.WebhookDeliverySucceeded, constants.WebhookDeliveryFailed)})
+)
+
+// Statuses shared between routes
+var (
+	writeErrors  = []int{http.StatusUnprocessableEntity}
+	deleteErrors = []int{http.StatusConflict, http.StatusUnprocessableEntity}
+	batchStatus  = []int{http.StatusOK, http.StatusMultiStatus}
+	importStatus = []int{http.StatusOK, http.StatusAccepted}
+)
+
+// routes describes every route setupRoutes registers
+var routes = []route{
+	// Health
+	{method: http.MethodGet, path: "/health", id: "healthCheck", summary: "Check the health of the API and its dependencies", data: controllers.HealthResponse{}, errors: []int{http.StatusServiceUnavailable}},
+	{method: http.MethodGet, path: "/ready", id: "readinessCheck", summary: "Check whether the API is ready for traffic", data: map[string]interface{}{}, errors: []int{http.StatusServiceUnavailable}},
+	{method: http.MethodGet, path: "/live", id: "livenessCheck", summary: "Check whether the API is alive", data: map[string]interface{}{}},
+
+	// Locations
+	{method: http.MethodPost, path: "/api/v1/locations", id: "createLocation", summary: "Create a location", body: dto.LocationBase{}, data: dto.LocationDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodPost, path: "/api/v1/locations/batch", id: "batchLocations", summary: "Create, update and delete locations in one request", query: []*Parameter{atomicParam}, body: []dto.BatchOperation{}, data: dto.BatchResult{}, statuses: batchStatus},
+	{method: http.MethodGet, path: "/api/v1/locations", id: "getAllLocations", summary: "List locations", paged: true, data: []dto.LocationSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/locations/:id", id: "getLocationByID", summary: "Get a location", data: dto.LocationDetails{}},
+	{method: http.MethodPatch, path: "/api/v1/locations/:id", id: "updateLocation", summary: "Update a location", body: dto.LocationBase{}, data: dto.LocationDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/locations/:id", id: "deleteLocation", summary: "Delete a location", query: []*Parameter{reassignToParam}, errors: deleteErrors, conflict: dto.DeleteConflict{}},
+	{method: http.MethodGet, path: "/api/v1/locations/active", id: "getActiveLocations", summary: "List active locations", data: []dto.LocationSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/locations/nearby", id: "getLocationsByCoordinates", summary: "List locations near a point", query: []*Parameter{latitudeParam, longitudeParam, radiusParam}, data: []dto.LocationSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/locations/search", id: "searchLocations", summary: "Search locations", query: []*Parameter{searchParam}, data: []dto.LocationSummary{}, represent: representList},
+
+	// Theatre types
+	{method: http.MethodPost, path: "/api/v1/theatre-types", id: "createTheatreType", summary: "Create a theatre type", body: dto.TheatreTypeBase{}, data: dto.TheatreTypeDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodPost, path: "/api/v1/theatre-types/batch", id: "batchTheatreTypes", summary: "Create, update and delete theatre types in one request", query: []*Parameter{atomicParam}, body: []dto.BatchOperation{}, data: dto.BatchResult{}, statuses: batchStatus},
+	{method: http.MethodGet, path: "/api/v1/theatre-types", id: "getAllTheatreTypes", summary: "List theatre types", paged: true, data: []dto.TheatreTypeSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatre-types/:id", id: "getTheatreTypeByID", summary: "Get a theatre type", data: dto.TheatreTypeDetails{}},
+	{method: http.MethodPatch, path: "/api/v1/theatre-types/:id", id: "updateTheatreType", summary: "Update a theatre type", body: dto.TheatreTypeBase{}, data: dto.TheatreTypeDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/theatre-types/:id", id: "deleteTheatreType", summary: "Delete a theatre type", query: []*Parameter{reassignToParam}, errors: deleteErrors, conflict: dto.DeleteConflict{}},
+	{method: http.MethodGet, path: "/api/v1/theatre-types/active", id: "getActiveTheatreTypes", summary: "List active theatre types", data: []dto.TheatreTypeSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatre-types/name/:name", id: "getTheatreTypeByName", summary: "Get a theatre type by name", data: dto.TheatreTypeDetails{}},
+
+	// Show types
+	{method: http.MethodPost, path: "/api/v1/show-types", id: "createShowType", summary: "Create a show type", body: dto.ShowTypeBase{}, data: dto.ShowTypeDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodPost, path: "/api/v1/show-types/batch", id: "batchShowTypes", summary: "Create, update and delete show types in one request", query: []*Parameter{atomicParam}, body: []dto.BatchOperation{}, data: dto.BatchResult{}, statuses: batchStatus},
+	{method: http.MethodGet, path: "/api/v1/show-types", id: "getAllShowTypes", summary: "List show types", paged: true, data: []dto.ShowTypeSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/show-types/:id", id: "getShowTypeByID", summary: "Get a show type", data: dto.ShowTypeDetails{}},
+	{method: http.MethodPatch, path: "/api/v1/show-types/:id", id: "updateShowType", summary: "Update a show type", body: dto.ShowTypeBase{}, data: dto.ShowTypeDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/show-types/:id", id: "deleteShowType", summary: "Delete a show type", query: []*Parameter{reassignToParam}, errors: deleteErrors, conflict: dto.DeleteConflict{}},
+	{method: http.MethodGet, path: "/api/v1/show-types/active", id: "getActiveShowTypes", summary: "List active show types", data: []dto.ShowTypeSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/show-types/name/:name", id: "getShowTypeByName", summary: "Get a show type by name", data: dto.ShowTypeDetails{}},
+
+	// Theatres
+	{method: http.MethodPost, path: "/api/v1/theatres", id: "createTheatre", summary: "Create a theatre", body: dto.TheatreBase{}, data: dto.TheatreDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodPost, path: "/api/v1/theatres/batch", id: "batchTheatres", summary: "Create, update and delete theatres in one request", query: []*Parameter{atomicParam}, body: []dto.BatchOperation{}, data: dto.BatchResult{}, statuses: batchStatus},
+	{method: http.MethodGet, path: "/api/v1/theatres", id: "getAllTheatres", summary: "List theatres", paged: true, data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/:id", id: "getTheatreByID", summary: "Get a theatre", data: dto.TheatreDetails{}, represent: representJSONLD, alternate: dto.PerformingArtsTheaterLD{}},
+	{method: http.MethodPatch, path: "/api/v1/theatres/:id", id: "updateTheatre", summary: "Update a theatre", body: dto.TheatreBase{}, data: dto.TheatreDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/theatres/:id", id: "deleteTheatre", summary: "Delete a theatre", query: []*Parameter{reassignToParam}, errors: deleteErrors, conflict: dto.DeleteConflict{}},
+	{method: http.MethodGet, path: "/api/v1/theatres/active", id: "getActiveTheatres", summary: "List active theatres", data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/featured", id: "getFeaturedTheatres", summary: "List featured theatres", data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/location/:locationId", id: "getTheatresByLocationID", summary: "List the theatres at a location", data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/type/:typeId", id: "getTheatresByTheatreTypeID", summary: "List the theatres of a type", data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/nearby", id: "getNearbyTheatres", summary: "List theatres near a point", query: []*Parameter{latitudeParam, longitudeParam, radiusParam}, data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/search", id: "searchTheatres", summary: "Search theatres", query: []*Parameter{searchParam}, data: []dto.TheatreSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/:id/calendar.ics", id: "getTheatreCalendar", summary: "Get a theatre's shows as an iCalendar feed", query: []*Parameter{showTypeParam}, represent: representCalendar},
+	{method: http.MethodGet, path: "/api/v1/theatres/:id/revisions", id: "getTheatreRevisions", summary: "List a theatre's revisions", paged: true, data: []dto.RevisionSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/theatres/:id/revisions/diff", id: "diffTheatreRevisions", summary: "Compare two revisions of a theatre", query: []*Parameter{fromRevision, toRevision}, data: dto.RevisionDiff{}},
+	{method: http.MethodGet, path: "/api/v1/theatres/:id/revisions/:revision", id: "getTheatreRevision", summary: "Get a revision of a theatre", data: dto.RevisionDetails{}},
+	{method: http.MethodPost, path: "/api/v1/theatres/:id/revisions/:revision/restore", id: "restoreTheatreRevision", summary: "Restore a theatre to a revision", data: dto.TheatreDetails{}, errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+
+	// Shows
+	{method: http.MethodPost, path: "/api/v1/shows", id: "createShow", summary: "Create a show", body: dto.ShowBase{}, data: dto.ShowDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodPost, path: "/api/v1/shows/batch", id: "batchShows", summary: "Create, update and delete shows in one request", query: []*Parameter{atomicParam}, body: []dto.BatchOperation{}, data: dto.BatchResult{}, statuses: batchStatus},
+	{method: http.MethodGet, path: "/api/v1/shows", id: "getAllShows", summary: "List shows", paged: true, data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/:id", id: "getShowByID", summary: "Get a show", data: dto.ShowDetails{}, represent: representJSONLD, alternate: dto.TheaterEventLD{}},
+	{method: http.MethodPatch, path: "/api/v1/shows/:id", id: "updateShow", summary: "Update a show", body: dto.ShowBase{}, data: dto.ShowDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/shows/:id", id: "deleteShow", summary: "Delete a show", query: []*Parameter{reassignToParam}, errors: deleteErrors, conflict: dto.DeleteConflict{}},
+	{method: http.MethodGet, path: "/api/v1/shows/active", id: "getActiveShows", summary: "List active shows", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/featured", id: "getFeaturedShows", summary: "List featured shows", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/current", id: "getCurrentShows", summary: "List shows running now", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/upcoming", id: "getUpcomingShows", summary: "List shows yet to start", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/theatre/:theatreId", id: "getShowsByTheatreID", summary: "List the shows at a theatre", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/type/:typeId", id: "getShowsByShowTypeID", summary: "List the shows of a type", data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/search", id: "searchShows", summary: "Search shows", query: []*Parameter{searchParam}, data: []dto.ShowSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/:id/calendar.ics", id: "getShowCalendar", summary: "Get a show's performances as an iCalendar feed", represent: representCalendar},
+	{method: http.MethodGet, path: "/api/v1/shows/:id/revisions", id: "getShowRevisions", summary: "List a show's revisions", paged: true, data: []dto.RevisionSummary{}, represent: representList},
+	{method: http.MethodGet, path: "/api/v1/shows/:id/revisions/diff", id: "diffShowRevisions", summary: "Compare two revisions of a show", query: []*Parameter{fromRevision, toRevision}, data: dto.RevisionDiff{}},
+	{method: http.MethodGet, path: "/api/v1/shows/:id/revisions/:revision", id: "getShowRevision", summary: "Get a revision of a show", data: dto.RevisionDetails{}},
+	{method: http.MethodPost, path: "/api/v1/shows/:id/revisions/:revision/restore", id: "restoreShowRevision", summary: "Restore a show to a revision", data: dto.ShowDetails{}, errors: []int{http.StatusConflict, http.StatusUnprocessableEntity}},
+
+	// Imports
+	{method: http.MethodPost, path: "/api/v1/imports/:entity", id: "importFile", summary: "Import a CSV or NDJSON file", query: importParams, upload: true, data: dto.ImportJob{}, statuses: importStatus, errors: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}},
+	{method: http.MethodGet, path: "/api/v1/imports/jobs/:id", id: "getImportJob", summary: "Get an import job", data: dto.ImportJob{}},
+
+	// Webhooks
+	{method: http.MethodPost, path: "/api/v1/webhooks", id: "createWebhook", summary: "Subscribe to events", body: dto.WebhookBase{}, data: dto.WebhookDetails{}, statuses: []int{http.StatusCreated}, errors: writeErrors},
+	{method: http.MethodGet, path: "/api/v1/webhooks", id: "getAllWebhooks", summary: "List webhook subscriptions", paged: true, data: []dto.WebhookDetails{}},
+	{method: http.MethodGet, path: "/api/v1/webhooks/:id", id: "getWebhookByID", summary: "Get a webhook subscription", data: dto.WebhookDetails{}},
+	{method: http.MethodPatch, path: "/api/v1/webhooks/:id", id: "updateWebhook", summary: "Update a webhook subscription", body: dto.WebhookBase{}, data: dto.WebhookDetails{}, errors: writeErrors},
+	{method: http.MethodDelete, path: "/api/v1/webhooks/:id", id: "deleteWebhook", summary: "Delete a webhook subscription"},
+	{method: http.MethodPost, path: "/api/v1/webhooks/:id/test", id: "sendTestEvent", summary: "Send a test event to a webhook", data: dto.WebhookDeliveryDetails{}},
+	{method: http.MethodGet, path: "/api/v1/webhooks/:id/deliveries", id: "getDeliveries", summary: "List a webhook's deliveries", query: []*Parameter{deliveryStatusParam}, paged: true, data: []dto.WebhookDeliverySummary{}},
+	{method: http.MethodGet, path: "/api/v1/webhooks/:id/deliveries/:deliveryId", id: "getDelivery", summary: "Get a delivery with its attempts", data: dto.WebhookDeliveryDetails{}},
+
+	// Audit
+	{method: http.MethodGet, path: "/api/v1/audit", id: "listAuditEntries", summary: "List recorded changes", query: auditParams, paged: true, data: []dto.AuditEntry{}},
+
+	// Trash
+	{method: http.MethodGet, path: "/api/v1/trash/:resource", id: "listDeleted", summary: "List deleted records", paged: true, data: anyOf{[]dto.LocationSummary{}, []dto.TheatreTypeSummary{}, []dto.ShowTypeSummary{}, []dto.TheatreSummary{}, []dto.ShowSummary{}}, represent: representList},
+	{method: http.MethodPost, path: "/api/v1/trash/:resource/:id/restore", id: "restoreDeleted", summary: "Restore a deleted record", data: anyOf{dto.LocationDetails{}, dto.TheatreTypeDetails{}, dto.ShowTypeDetails{}, dto.TheatreDetails{}, dto.ShowDetails{}}, errors: []int{http.StatusConflict}},
+	{method: http.MethodDelete, path: "/api/v1/trash/:resource/:id", id: "purgeDeleted", summary: "Permanently delete a deleted record", errors: []int{http.StatusConflict}},
+
+	// Outbox
+	{method: http.MethodGet, path: "/api/v1/admin/outbox", id: "listOutboxMessages", summary: "List outbox messages", query: []*Parameter{outboxStatusParam}, paged: true, data: []dto.OutboxMessage{}},
+	{method: http.MethodPost, path: "/api/v1/admin/outbox/replay", id: "replayFailedOutboxMessages", summary: "Replay every failed outbox message", data: dto.OutboxReplayResult{}},
+	{method: http.MethodGet, path: "/api/v1/admin/outbox/:id", id: "getOutboxMessage", summary: "Get an outbox message", data: dto.OutboxMessage{}},
+	{method: http.MethodPost, path: "/api/v1/admin/outbox/:id/replay", id: "replayOutboxMessage", summary: "Replay an outbox message", data: dto.OutboxMessage{}, errors: []int{http.StatusConflict}},
+}
+
+// queryParam describes an optional query parameter
+func queryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// requiredQueryParam describes a query parameter the route can't do without
+func requiredQueryParam(name, description string, schema *Schema) *Parameter {
+	parameter := queryParam(name, description, schema)
+	parameter.Required = true
+	return parameter
+}
+
+// enum lists the values a parameter may take
+func enum(values ...string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return enum
+}
+
+// float points to a bound
+func float(value float64) *float64 {
+	return &value
+}
+
+// integer points to a length
+func integer(value int) *int {
+	return &value
+}
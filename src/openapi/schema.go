@@ -0,0 +1,253 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry builds schemas for Go types, collecting named structs as components referred to by $ref
+type schemaRegistry struct {
+	components map[string]*Schema
+}
+
+// newSchemaRegistry creates an empty registry
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: make(map[string]*Schema)}
+}
+
+// of returns the schema for the type of v; nil describes any value
+func (r *schemaRegistry) of(v interface{}) *Schema {
+	switch v := v.(type) {
+	case nil:
+		return &Schema{}
+	case anyOf:
+		schema := &Schema{}
+		for _, shape := range v {
+			schema.AnyOf = append(schema.AnyOf, r.of(shape))
+		}
+		return schema
+	}
+	return r.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema for t, registering it as a component when it is a named struct
+func (r *schemaRegistry) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.schema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		if _, ok := r.components[t.Name()]; !ok {
+			r.components[t.Name()] = nil // reserve the name so recursive types terminate
+			r.components[t.Name()] = r.object(t)
+		}
+		return &Schema{Ref: componentRef(t.Name())}
+	}
+	return &Schema{}
+}
+
+// object describes a struct's JSON fields. A field is required when validated as required, or when it is
+// always present in the JSON: neither a pointer, omitempty nor carrying validate rules that allow leaving it out
+func (r *schemaRegistry) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(s, t)
+	return s
+}
+
+// addFields adds the JSON fields of t to s, flattening embedded structs as encoding/json does
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			r.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		validate, hasRules := field.Tag.Lookup("validate")
+		property := r.schema(field.Type)
+		rules := parseRules(validate)
+		if property.Ref == "" {
+			rules.apply(property)
+		}
+		omitEmpty := strings.Contains(options, "omitempty")
+		isPointer := field.Type.Kind() == reflect.Ptr
+		if isPointer && !omitEmpty {
+			property = nullable(property)
+		}
+		s.Properties[name] = property
+
+		if rules.required || (!hasRules && !omitEmpty && !isPointer) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// rules is what a validate tag says about a value and, after dive, its items
+type rules struct {
+	required  bool
+	omitEmpty bool // constraints only hold for non-empty values
+	min, max  *float64
+	format    string
+	enum      []interface{}
+	items     *rules
+}
+
+// parseRules reads the go-playground validator rules the DTOs use
+func parseRules(tag string) rules {
+	var r rules
+	if tag == "" {
+		return r
+	}
+	current := &r
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			current.items = &rules{}
+			current = current.items
+		case "required":
+			current.required = true
+		case "omitempty":
+			current.omitEmpty = true
+		case "min", "gte":
+			current.min = parseBound(param)
+		case "max", "lte":
+			current.max = parseBound(param)
+		case "len":
+			current.min, current.max = parseBound(param), parseBound(param)
+		case "email":
+			current.format = "email"
+		case "url", "uri":
+			current.format = "uri"
+		case "uuid", "uuid4":
+			current.format = "uuid"
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				current.enum = append(current.enum, value)
+			}
+		}
+	}
+	return r
+}
+
+// parseBound parses a numeric rule parameter
+func parseBound(param string) *float64 {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil
+	}
+	return &bound
+}
+
+// apply translates the rules into constraints on s: min and max bound a string's length, an array's
+// items or a number's value, depending on what s describes
+func (r rules) apply(s *Schema) {
+	switch s.Type {
+	case "string":
+		minLength := r.min
+		if r.required && minLength == nil {
+			one := 1.0
+			minLength = &one // required rejects empty strings
+		}
+		// An omitempty string may still be empty, whatever its minimum length
+		if !r.omitEmpty {
+			s.MinLength = toInt(minLength)
+		}
+		s.MaxLength = toInt(r.max)
+		s.Format = firstNonEmpty(r.format, s.Format)
+	case "array":
+		s.MinItems, s.MaxItems = toInt(r.min), toInt(r.max)
+		if r.items != nil && s.Items != nil && s.Items.Ref == "" {
+			r.items.apply(s.Items)
+		}
+	case "integer", "number":
+		s.Minimum, s.Maximum = r.min, r.max
+	}
+	if len(r.enum) > 0 {
+		s.Enum = r.enum
+	}
+}
+
+// nullable lets s also be null
+func nullable(s *Schema) *Schema {
+	switch t := s.Type.(type) {
+	case string:
+		s.Type = []string{t, "null"}
+		return s
+	case nil:
+		if s.Ref == "" {
+			return s // already anything, null included
+		}
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+// componentRef refers to a schema in components
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// toInt converts a bound to an integer one
+func toInt(bound *float64) *int {
+	if bound == nil {
+		return nil
+	}
+	value := int(*bound)
+	return &value
+}
+
+// firstNonEmpty returns the first of values that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
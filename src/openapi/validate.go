@@ -0,0 +1,277 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/dto"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// validator checks decoded JSON values against the document's schemas
+type validator struct {
+	schemas map[string]*Schema
+	strict  bool   // reject object fields the schema doesn't list
+	in      string // where the values come from: path, query, body or response
+}
+
+// validate returns every way value breaks schema; name locates value within what is being checked
+func (v *validator) validate(schema *Schema, value interface{}, name string) []dto.Violation {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		return v.validate(v.schemas[strings.TrimPrefix(schema.Ref, componentRef(""))], value, name)
+	}
+
+	var violations []dto.Violation
+	for _, part := range schema.AllOf {
+		violations = append(violations, v.validate(part, value, name)...)
+	}
+	if len(schema.AnyOf) > 0 {
+		violations = append(violations, v.validateAnyOf(schema.AnyOf, value, name)...)
+	}
+	if schema.Type != nil && !hasType(schema.Type, value) {
+		return append(violations, v.violation(name, "must be %s", describeType(schema.Type)))
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		violations = append(violations, v.violation(name, "must be one of %s", describeEnum(schema.Enum)))
+	}
+
+	switch value := value.(type) {
+	case string:
+		violations = append(violations, v.validateString(schema, value, name)...)
+	case json.Number:
+		number, _ := value.Float64()
+		if schema.Minimum != nil && number < *schema.Minimum {
+			violations = append(violations, v.violation(name, "must be at least %v", *schema.Minimum))
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			violations = append(violations, v.violation(name, "must be at most %v", *schema.Maximum))
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			violations = append(violations, v.violation(name, "must have at least %s", items(*schema.MinItems)))
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			violations = append(violations, v.violation(name, "must have at most %s", items(*schema.MaxItems)))
+		}
+		for i, item := range value {
+			violations = append(violations, v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", name, i))...)
+		}
+	case map[string]interface{}:
+		violations = append(violations, v.validateObject(schema, value, name)...)
+	}
+	return violations
+}
+
+// validateAnyOf accepts value when any of the schemas does, otherwise reporting the closest one's violations
+func (v *validator) validateAnyOf(schemas []*Schema, value interface{}, name string) []dto.Violation {
+	var closest []dto.Violation
+	for i, schema := range schemas {
+		violations := v.validate(schema, value, name)
+		if len(violations) == 0 {
+			return nil
+		}
+		if i == 0 || len(violations) < len(closest) {
+			closest = violations
+		}
+	}
+	return closest
+}
+
+// validateString checks a string's length and format; like the DTOs' omitempty rules, formats allow empty strings
+func (v *validator) validateString(schema *Schema, value, name string) []dto.Violation {
+	var violations []dto.Violation
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		if length == 0 {
+			violations = append(violations, v.violation(name, "must not be empty"))
+		} else {
+			violations = append(violations, v.violation(name, "must be at least %d characters", *schema.MinLength))
+		}
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		violations = append(violations, v.violation(name, "must be at most %d characters", *schema.MaxLength))
+	}
+	if value != "" && !hasFormat(schema.Format, value) {
+		violations = append(violations, v.violation(name, "must be a valid %s", schema.Format))
+	}
+	return violations
+}
+
+// validateObject checks an object's required, listed and, in strict mode, unknown fields
+func (v *validator) validateObject(schema *Schema, value map[string]interface{}, name string) []dto.Violation {
+	var violations []dto.Violation
+	for _, field := range schema.Required {
+		if _, ok := value[field]; !ok {
+			violations = append(violations, v.violation(join(name, field), "is required"))
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(value)) {
+		if property, ok := schema.Properties[field]; ok {
+			violations = append(violations, v.validate(property, value[field], join(name, field))...)
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case *Schema:
+			violations = append(violations, v.validate(additional, value[field], join(name, field))...)
+		case nil:
+			if v.strict && schema.Properties != nil {
+				violations = append(violations, v.violation(join(name, field), "is not a known field"))
+			}
+		}
+	}
+	return violations
+}
+
+// violation describes one problem with the value at name
+func (v *validator) violation(name, format string, args ...interface{}) dto.Violation {
+	return dto.Violation{In: v.in, Name: name, Message: fmt.Sprintf(format, args...)}
+}
+
+// hasType reports whether value, as decoded with json.Decoder.UseNumber, is of one of the types
+func hasType(types interface{}, value interface{}) bool {
+	names, ok := types.([]string)
+	if !ok {
+		names = []string{types.(string)}
+	}
+	for _, name := range names {
+		switch value := value.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case json.Number:
+			if name == "number" {
+				return true
+			}
+			if _, err := value.Int64(); err == nil && name == "integer" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasFormat reports whether value is written in format; unknown formats accept anything
+func hasFormat(format, value string) bool {
+	var err error
+	switch format {
+	case "uuid":
+		_, err = uuid.Parse(value)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "email":
+		_, err = mail.ParseAddress(value)
+	case "uri":
+		var parsed *url.URL
+		parsed, err = url.ParseRequestURI(value)
+		if err == nil && (parsed.Scheme == "" || parsed.Host == "") {
+			return false
+		}
+	}
+	return err == nil
+}
+
+// inEnum reports whether value is one of enum, comparing numbers and strings by their text
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeType names the types a value may have, for messages
+func describeType(types interface{}) string {
+	names, ok := types.([]string)
+	if !ok {
+		names = []string{types.(string)}
+	}
+	described := make([]string, len(names))
+	for i, name := range names {
+		switch name {
+		case "array", "integer", "object":
+			described[i] = "an " + name
+		case "null":
+			described[i] = name
+		default:
+			described[i] = "a " + name
+		}
+	}
+	return strings.Join(described, " or ")
+}
+
+// describeEnum lists allowed values, for messages
+func describeEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, ", ")
+}
+
+// items counts array items, for messages
+func items(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return strconv.Itoa(n) + " items"
+}
+
+// join locates field within the object at name
+func join(name, field string) string {
+	if name == "" {
+		return field
+	}
+	return name + "." + field
+}
+
+// parseParameter converts a path or query parameter to the JSON value its schema describes, so it validates like a body field
+func parseParameter(schema *Schema, value string) (interface{}, bool) {
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(value), true
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(value), true
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, false
+		}
+		return parsed, true
+	}
+	return value, true
+}
@@ -0,0 +1,211 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/mappers"
+	"theatre-management-system/src/models"
+	"time"
+)
+
+// relay polls the outbox and hands due messages to every sink
+type relay struct {
+	cfg      config.OutboxConfig
+	messages interfaces.OutboxRepository
+	sinks    []interfaces.OutboxSink
+	mapper   *mappers.OutboxMapper
+
+	// lastPurge is when delivered messages past their retention were last removed
+	lastPurge time.Time
+
+	// stopping ends polling; ctx is only cancelled when shutdown runs out of time mid-batch
+	stopping chan struct{}
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// Start runs the relay in the background until the returned worker is shut down, which also closes the sinks.
+// Every instance may run a relay: claimed messages are leased, so each is delivered by one relay at a time.
+func Start(cfg config.OutboxConfig, messages interfaces.OutboxRepository, sinks []interfaces.OutboxSink) interfaces.Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &relay{
+		cfg:      cfg,
+		messages: messages,
+		sinks:    sinks,
+		mapper:   mappers.NewOutboxMapper(),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go r.run()
+	return r
+}
+
+// run polls until stopped, draining full batches back to back
+func (r *relay) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// A full batch means more are probably due, so only wait once the outbox is drained
+		for r.relayBatch() == r.cfg.BatchSize {
+			if r.isStopping() {
+				return
+			}
+		}
+		r.purgeDelivered()
+
+		select {
+		case <-r.stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isStopping reports whether Shutdown has been called
+func (r *relay) isStopping() bool {
+	select {
+	case <-r.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// relayBatch claims due messages and delivers them concurrently, returning how many it claimed;
+// a batch holds at most one message per aggregate, so concurrency never reorders an aggregate's events
+func (r *relay) relayBatch() int {
+	messages, err := r.messages.ClaimDue(r.ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		if r.ctx.Err() == nil {
+			slog.Error("Failed to claim outbox messages", "error", err)
+		}
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, message := range messages {
+		wg.Add(1)
+		go func(message *models.OutboxMessage) {
+			defer wg.Done()
+			r.deliver(message)
+		}(message)
+	}
+	wg.Wait()
+
+	return len(messages)
+}
+
+// deliver hands one message to every sink; if any fails the message is retried against all of them,
+// so sinks may see a message more than once
+func (r *relay) deliver(message *models.OutboxMessage) {
+	envelope := r.mapper.ToEnvelope(message)
+
+	var errs []error
+	for _, sink := range r.sinks {
+		ctx, cancel := context.WithTimeout(r.ctx, r.cfg.DeliveryTimeout)
+		if err := sink.Deliver(ctx, envelope); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+		cancel()
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		r.retry(message, err)
+		return
+	}
+	if err := r.messages.MarkDelivered(r.ctx, message.ID); err != nil {
+		// The lease expires and the message goes out again, which at-least-once delivery allows
+		slog.Error("Failed to mark outbox message delivered", "id", message.ID, "error", err)
+	}
+}
+
+// retry schedules the next attempt with jittered exponential backoff, or marks the message failed once it is out of attempts
+func (r *relay) retry(message *models.OutboxMessage, deliveryErr error) {
+	lastError := deliveryErr.Error()
+	if len(lastError) > constants.OutboxMaxErrorChars {
+		lastError = lastError[:constants.OutboxMaxErrorChars]
+	}
+
+	if message.Attempts >= r.cfg.MaxAttempts {
+		slog.Error("Outbox message failed; replay it once the sink is fixed",
+			"id", message.ID,
+			"event", message.EventName,
+			"aggregate_id", message.AggregateID,
+			"attempts", message.Attempts,
+			"error", lastError,
+		)
+		if err := r.messages.MarkFailed(r.ctx, message.ID, lastError); err != nil {
+			slog.Error("Failed to mark outbox message failed", "id", message.ID, "error", err)
+		}
+		return
+	}
+
+	backoff := r.cfg.RetryBackoff
+	for i := 1; i < message.Attempts && backoff < r.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, r.cfg.MaxBackoff)
+	wait := backoff + rand.N(backoff/4+1)
+
+	slog.Warn("Outbox delivery failed, retrying",
+		"id", message.ID,
+		"event", message.EventName,
+		"attempt", message.Attempts,
+		"max_attempts", r.cfg.MaxAttempts,
+		"retry_in", wait.Round(time.Millisecond).String(),
+		"error", lastError,
+	)
+	if err := r.messages.MarkRetry(r.ctx, message.ID, lastError, time.Now().Add(wait)); err != nil {
+		slog.Error("Failed to schedule outbox retry", "id", message.ID, "error", err)
+	}
+}
+
+// purgeDelivered drops delivered messages older than the retention period, at most once per purge interval
+func (r *relay) purgeDelivered() {
+	if r.cfg.Retention <= 0 || time.Since(r.lastPurge) < constants.OutboxPurgeInterval {
+		return
+	}
+	r.lastPurge = time.Now()
+
+	purged, err := r.messages.DeleteDeliveredBefore(r.ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		if r.ctx.Err() == nil {
+			slog.Error("Failed to purge delivered outbox messages", "error", err)
+		}
+		return
+	}
+	if purged > 0 {
+		slog.Info("Purged delivered outbox messages", "count", purged)
+	}
+}
+
+// Shutdown stops polling and waits for the batch in flight, cancelling its deliveries if ctx expires first,
+// then closes the sinks
+func (r *relay) Shutdown(ctx context.Context) error {
+	close(r.stopping)
+
+	var err error
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.cancel()
+		<-r.done
+		err = ctx.Err()
+	}
+	r.cancel()
+
+	return errors.Join(err, closeSinks(r.sinks))
+}
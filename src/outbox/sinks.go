@@ -0,0 +1,194 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+// NewSinks opens every configured sink; the stdout sink writes to out and subscriptions serves as the subscriptions sink
+func NewSinks(cfg config.OutboxConfig, out io.Writer, subscriptions interfaces.OutboxSink) ([]interfaces.OutboxSink, error) {
+	var sinks []interfaces.OutboxSink
+	for _, name := range cfg.Sinks {
+		var sink interfaces.OutboxSink
+		switch name {
+		case constants.OutboxSinkStdout:
+			sink = &stdoutSink{out: out}
+		case constants.OutboxSinkWebhook:
+			sink = &webhookSink{url: cfg.WebhookURL, client: &http.Client{}}
+		case constants.OutboxSinkNATS:
+			// Connecting in the background keeps startup independent of the broker; publishes fail and retry until it is up
+			conn, err := nats.Connect(cfg.NATSURL, nats.Name(constants.OutboxClientName), nats.RetryOnFailedConnect(true), nats.MaxReconnects(-1))
+			if err != nil {
+				closeSinks(sinks)
+				return nil, fmt.Errorf("nats sink: %w", err)
+			}
+			sink = &natsSink{conn: conn, subject: cfg.NATSSubject}
+		case constants.OutboxSinkWebhookSubscriptions:
+			sink = subscriptions
+		case constants.OutboxSinkKafka:
+			sink = &kafkaSink{writer: &kafka.Writer{
+				Addr:  kafka.TCP(cfg.KafkaBrokers...),
+				Topic: cfg.KafkaTopic,
+				// Messages are keyed by aggregate, so each aggregate's events land on one partition in order
+				Balancer:     &kafka.Hash{},
+				RequiredAcks: kafka.RequireAll,
+				BatchTimeout: 10 * time.Millisecond, // deliveries are synchronous, so don't wait to fill a batch
+				MaxAttempts:  1,                     // the relay owns retries
+			}}
+		default:
+			closeSinks(sinks)
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// closeSinks closes every sink, reporting all failures
+func closeSinks(sinks []interfaces.OutboxSink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s sink: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stdoutSink writes each message as a line of JSON
+type stdoutSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// Name returns "stdout"
+func (s *stdoutSink) Name() string { return constants.OutboxSinkStdout }
+
+// Deliver writes the envelope on its own line
+func (s *stdoutSink) Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(s.out).Encode(envelope)
+}
+
+// Close does nothing
+func (s *stdoutSink) Close() error { return nil }
+
+// webhookSink POSTs each message to a URL, treating any 2xx answer as accepted
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Name returns "webhook"
+func (s *webhookSink) Name() string { return constants.OutboxSinkWebhook }
+
+// Deliver POSTs the envelope as JSON
+func (s *webhookSink) Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.HeaderOutboxMessageID, envelope.ID.String())
+	req.Header.Set(constants.HeaderOutboxEvent, envelope.Event)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // lets the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Close releases idle connections
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// natsSink publishes each message to <subject>.<event name>
+type natsSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// Name returns "nats"
+func (s *natsSink) Name() string { return constants.OutboxSinkNATS }
+
+// Deliver publishes the envelope and waits for the server to acknowledge the connection's writes;
+// the Nats-Msg-Id header lets a JetStream stream drop redeliveries
+func (s *natsSink) Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(s.subject + "." + envelope.Event)
+	msg.Header.Set(nats.MsgIdHdr, envelope.ID.String())
+	msg.Data = data
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return s.conn.FlushWithContext(ctx)
+}
+
+// Close drops the connection
+func (s *natsSink) Close() error {
+	s.conn.Close()
+	return nil
+}
+
+// kafkaSink produces each message to a topic, keyed by aggregate ID
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// Name returns "kafka"
+func (s *kafkaSink) Name() string { return constants.OutboxSinkKafka }
+
+// Deliver produces the envelope and waits for every in-sync replica to acknowledge it
+func (s *kafkaSink) Deliver(ctx context.Context, envelope *dto.OutboxEnvelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(envelope.AggregateID.String()),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: constants.HeaderOutboxMessageID, Value: []byte(envelope.ID.String())},
+			{Key: constants.HeaderOutboxEvent, Value: []byte(envelope.Event)},
+		},
+	})
+}
+
+// Close flushes and closes the writer
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
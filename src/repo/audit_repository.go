@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditRepository implements the AuditRepository interface
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) interfaces.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append records entries in the current transaction
+func (r *auditRepository) Append(ctx context.Context, entries ...*models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(entries).Error
+}
+
+// List retrieves audit entries matching filter, newest first
+func (r *auditRepository) List(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]*models.AuditEntry, error) {
+	var entries []*models.AuditEntry
+	query := r.db.WithContext(ctx).Order("created_at DESC, id").Limit(limit).Offset(offset)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	err := query.Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// DeleteBefore removes entries recorded before cutoff, returning how many there were
+func (r *auditRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&models.AuditEntry{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// importJobRepository implements the ImportJobRepository interface
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates a new import job repository
+func NewImportJobRepository(db *gorm.DB) interfaces.ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+// Create creates a new import job
+func (r *importJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetByID retrieves an import job by ID
+func (r *importJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update stores an import job's progress or outcome
+func (r *importJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// DeleteUpdatedBefore removes jobs that haven't changed since cutoff, returning how many there were
+func (r *importJobRepository) DeleteUpdatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("updated_at < ?", cutoff).Delete(&models.ImportJob{})
+	return result.RowsAffected, result.Error
+}
@@ -1,12 +1,15 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"theatre-management-system/src/interfaces"
 	"theatre-management-system/src/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // locationRepository implements the LocationRepository interface
@@ -20,14 +23,14 @@ func NewLocationRepository(db *gorm.DB) interfaces.LocationRepository {
 }
 
 // Create creates a new location
-func (r *locationRepository) Create(location *models.Location) error {
-	return r.db.Create(location).Error
+func (r *locationRepository) Create(ctx context.Context, location *models.Location) error {
+	return r.db.WithContext(ctx).Create(location).Error
 }
 
 // GetByID retrieves a location by ID
-func (r *locationRepository) GetByID(id uuid.UUID) (*models.Location, error) {
+func (r *locationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Location, error) {
 	var location models.Location
-	err := r.db.Preload("Theatres").First(&location, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Theatres").First(&location, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,27 +38,84 @@ func (r *locationRepository) GetByID(id uuid.UUID) (*models.Location, error) {
 }
 
 // GetAll retrieves all locations with pagination
-func (r *locationRepository) GetAll(limit, offset int) ([]*models.Location, error) {
+func (r *locationRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Location, error) {
 	var locations []*models.Location
-	err := r.db.Limit(limit).Offset(offset).Find(&locations).Error
+	err := r.db.WithContext(ctx).Order("created_at, id").Limit(limit).Offset(offset).Find(&locations).Error
 	if err != nil {
 		return nil, err
 	}
 	return locations, nil
 }
 
-// Update updates an existing location
-func (r *locationRepository) Update(location *models.Location) error {
-	return r.db.Save(location).Error
+// Update updates an existing location, re-localizing its shows to the location's timezone and bumping
+// their calendar sequence
+func (r *locationRepository) Update(ctx context.Context, location *models.Location) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(location).Error; err != nil {
+			return err
+		}
+		if err := localizeShows(tx, "t.location_id = ?", location.ID); err != nil {
+			return err
+		}
+		return resequenceShows(tx, "t.location_id = ?", location.ID)
+	})
 }
 
 // Delete soft deletes a location
-func (r *locationRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Location{}, "id = ?", id).Error
+func (r *locationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Location{}, "id = ?", id).Error
+}
+
+// GetDeleted retrieves soft-deleted locations with pagination, most recently deleted first
+func (r *locationRepository) GetDeleted(ctx context.Context, limit, offset int) ([]*models.Location, error) {
+	var locations []*models.Location
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted location by ID
+func (r *locationRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Location, error) {
+	var location models.Location
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&location, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// Restore takes a location out of the trash
+func (r *locationRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Location{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes a soft-deleted location that no theatres, deleted or not, refer to, reporting whether it did
+func (r *locationRepository) Purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	ids, err := purgeDeleted(ctx, r.db, "locations", locationUnreferenced, "id = ?", id)
+	return len(ids) > 0, err
+}
+
+// PurgeDeletedBefore permanently deletes locations soft-deleted before cutoff that no theatres refer to, returning their IDs
+func (r *locationRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	return purgeDeleted(ctx, r.db, "locations", locationUnreferenced, "deleted_at < ?", cutoff)
+}
+
+// LockForShare locks a location against concurrent updates and deletes until the transaction ends
+func (r *locationRepository) LockForShare(ctx context.Context, id uuid.UUID) error {
+	var location models.Location
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&location, "id = ?", id).Error
+}
+
+// LockForUpdate locks a location against anything that would refer to, update or delete it until the transaction ends
+func (r *locationRepository) LockForUpdate(ctx context.Context, id uuid.UUID) error {
+	var location models.Location
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&location, "id = ?", id).Error
 }
 
 // GetByCoordinates finds locations within a radius (in kilometers) of given coordinates
-func (r *locationRepository) GetByCoordinates(latitude, longitude, radius float64) ([]*models.Location, error) {
+func (r *locationRepository) GetByCoordinates(ctx context.Context, latitude, longitude, radius float64) ([]*models.Location, error) {
 	var locations []*models.Location
 
 	// Using PostGIS ST_DWithin function for geographic queries
@@ -74,7 +134,7 @@ func (r *locationRepository) GetByCoordinates(latitude, longitude, radius float6
 		AND deleted_at IS NULL
 	`
 
-	err := r.db.Raw(query, longitude, latitude, radiusMeters).Scan(&locations).Error
+	err := r.db.WithContext(ctx).Raw(query, longitude, latitude, radiusMeters).Scan(&locations).Error
 	if err != nil {
 		return nil, err
 	}
@@ -82,10 +142,20 @@ func (r *locationRepository) GetByCoordinates(latitude, longitude, radius float6
 	return locations, nil
 }
 
+// GetByNameAndCity retrieves a location by its natural key, ignoring case
+func (r *locationRepository) GetByNameAndCity(ctx context.Context, name, city string) (*models.Location, error) {
+	var location models.Location
+	err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?) AND LOWER(city) = LOWER(?)", name, city).First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
 // GetActiveLocations retrieves all active locations
-func (r *locationRepository) GetActiveLocations() ([]*models.Location, error) {
+func (r *locationRepository) GetActiveLocations(ctx context.Context) ([]*models.Location, error) {
 	var locations []*models.Location
-	err := r.db.Where("is_active = ?", true).Find(&locations).Error
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&locations).Error
 	if err != nil {
 		return nil, err
 	}
@@ -93,11 +163,11 @@ func (r *locationRepository) GetActiveLocations() ([]*models.Location, error) {
 }
 
 // Search searches locations by name, city, or country
-func (r *locationRepository) Search(query string) ([]*models.Location, error) {
+func (r *locationRepository) Search(ctx context.Context, query string) ([]*models.Location, error) {
 	var locations []*models.Location
 	searchPattern := fmt.Sprintf("%%%s%%", query)
 
-	err := r.db.Where(
+	err := r.db.WithContext(ctx).Where(
 		"name ILIKE ? OR city ILIKE ? OR country ILIKE ?",
 		searchPattern, searchPattern, searchPattern,
 	).Find(&locations).Error
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// outboxRepository implements the OutboxRepository interface
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) interfaces.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Append records messages in the current transaction
+func (r *outboxRepository) Append(ctx context.Context, messages ...*models.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(messages).Error
+}
+
+// GetByID retrieves an outbox message by ID
+func (r *outboxRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	err := r.db.WithContext(ctx).First(&message, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// List retrieves outbox messages, oldest first, optionally filtered by status
+func (r *outboxRepository) List(ctx context.Context, status string, limit, offset int) ([]*models.OutboxMessage, error) {
+	var messages []*models.OutboxMessage
+	query := r.db.WithContext(ctx).Order("sequence").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ClaimDue leases due messages; SKIP LOCKED lets several relays poll at once without blocking each other
+func (r *outboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxMessage, error) {
+	var messages []*models.OutboxMessage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A message waits while an older one for the same aggregate is undelivered, so each aggregate's events arrive in order
+		err := tx.Raw(`
+			SELECT * FROM outbox_messages m
+			WHERE m.status = ? AND m.next_attempt_at <= NOW()
+			  AND NOT EXISTS (
+			      SELECT 1 FROM outbox_messages earlier
+			      WHERE earlier.aggregate_type = m.aggregate_type
+			        AND earlier.aggregate_id = m.aggregate_id
+			        AND earlier.status <> ?
+			        AND earlier.sequence < m.sequence)
+			ORDER BY m.sequence
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`, constants.OutboxStatusPending, constants.OutboxStatusDelivered, limit).Scan(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(messages))
+		for i, message := range messages {
+			ids[i] = message.ID
+			message.Attempts++
+		}
+		return tx.Model(&models.OutboxMessage{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": time.Now().Add(lease),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// MarkDelivered records a successful delivery
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       constants.OutboxStatusDelivered,
+		"delivered_at": time.Now(),
+		"last_error":   "",
+	}).Error
+}
+
+// MarkRetry records a failed delivery to be retried at nextAttemptAt
+func (r *outboxRepository) MarkRetry(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+}
+
+// MarkFailed records that a message ran out of attempts
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     constants.OutboxStatusFailed,
+		"last_error": lastError,
+	}).Error
+}
+
+// Replay makes an undelivered message due now with its attempts reset
+func (r *outboxRepository) Replay(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).
+		Where("id = ? AND status <> ?", id, constants.OutboxStatusDelivered).
+		Updates(replayedColumns())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReplayFailed makes every failed message due now, returning how many there were
+func (r *outboxRepository) ReplayFailed(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).
+		Where("status = ?", constants.OutboxStatusFailed).
+		Updates(replayedColumns())
+	return result.RowsAffected, result.Error
+}
+
+// DeleteDeliveredBefore removes messages delivered before cutoff, returning how many there were
+func (r *outboxRepository) DeleteDeliveredBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND delivered_at < ?", constants.OutboxStatusDelivered, cutoff).
+		Delete(&models.OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
+
+// replayedColumns resets a message to a fresh pending state
+func replayedColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"status":          constants.OutboxStatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// primaryContextKey marks a context whose reads must see the primary's latest writes
+type primaryContextKey struct{}
+
+// WithPrimary routes every read made with the returned context to the primary database
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// usesPrimary reports whether reads made with ctx must go to the primary
+func usesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryContextKey{}).(bool)
+	return primary
+}
+
+// UseReadReplica sends reads made outside a transaction to the replica; writes, transactions,
+// pinned connections and contexts marked WithPrimary stay on the primary
+func UseReadReplica(db *gorm.DB, replica gorm.ConnPool) error {
+	primary := db.ConnPool
+
+	route := func(tx *gorm.DB) {
+		// Anything other than the shared primary pool is a transaction or a pinned connection
+		if tx.Statement.ConnPool != primary {
+			return
+		}
+		if tx.Statement.Context != nil && usesPrimary(tx.Statement.Context) {
+			return
+		}
+		tx.Statement.ConnPool = replica
+	}
+
+	if err := db.Callback().Query().Before("gorm:query").Register("replica:route_query", route); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("replica:route_row", route)
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// showRevisionRepository implements the ShowRevisionRepository interface
+type showRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewShowRevisionRepository creates a new show revision repository
+func NewShowRevisionRepository(db *gorm.DB) interfaces.ShowRevisionRepository {
+	return &showRevisionRepository{db: db}
+}
+
+// Append stores a revision in the current transaction
+func (r *showRevisionRepository) Append(ctx context.Context, revision *models.ShowRevision) error {
+	return r.db.WithContext(ctx).Create(revision).Error
+}
+
+// GetLatest retrieves a show's highest-numbered revision
+func (r *showRevisionRepository) GetLatest(ctx context.Context, showID uuid.UUID) (*models.ShowRevision, error) {
+	var revision models.ShowRevision
+	err := r.db.WithContext(ctx).Where("show_id = ?", showID).Order("revision DESC").First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetByRevision retrieves one revision of a show by its number
+func (r *showRevisionRepository) GetByRevision(ctx context.Context, showID uuid.UUID, revision int) (*models.ShowRevision, error) {
+	var showRevision models.ShowRevision
+	err := r.db.WithContext(ctx).Where("show_id = ? AND revision = ?", showID, revision).First(&showRevision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &showRevision, nil
+}
+
+// GetByShowID retrieves a show's revisions, newest first
+func (r *showRevisionRepository) GetByShowID(ctx context.Context, showID uuid.UUID, limit, offset int) ([]*models.ShowRevision, error) {
+	var revisions []*models.ShowRevision
+	err := r.db.WithContext(ctx).Where("show_id = ?", showID).Order("revision DESC").Limit(limit).Offset(offset).Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// theatreRevisionRepository implements the TheatreRevisionRepository interface
+type theatreRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewTheatreRevisionRepository creates a new theatre revision repository
+func NewTheatreRevisionRepository(db *gorm.DB) interfaces.TheatreRevisionRepository {
+	return &theatreRevisionRepository{db: db}
+}
+
+// Append stores a revision in the current transaction
+func (r *theatreRevisionRepository) Append(ctx context.Context, revision *models.TheatreRevision) error {
+	return r.db.WithContext(ctx).Create(revision).Error
+}
+
+// GetLatest retrieves a theatre's highest-numbered revision
+func (r *theatreRevisionRepository) GetLatest(ctx context.Context, theatreID uuid.UUID) (*models.TheatreRevision, error) {
+	var revision models.TheatreRevision
+	err := r.db.WithContext(ctx).Where("theatre_id = ?", theatreID).Order("revision DESC").First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// GetByRevision retrieves one revision of a theatre by its number
+func (r *theatreRevisionRepository) GetByRevision(ctx context.Context, theatreID uuid.UUID, revision int) (*models.TheatreRevision, error) {
+	var theatreRevision models.TheatreRevision
+	err := r.db.WithContext(ctx).Where("theatre_id = ? AND revision = ?", theatreID, revision).First(&theatreRevision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &theatreRevision, nil
+}
+
+// GetByTheatreID retrieves a theatre's revisions, newest first
+func (r *theatreRevisionRepository) GetByTheatreID(ctx context.Context, theatreID uuid.UUID, limit, offset int) ([]*models.TheatreRevision, error) {
+	var revisions []*models.TheatreRevision
+	err := r.db.WithContext(ctx).Where("theatre_id = ?", theatreID).Order("revision DESC").Limit(limit).Offset(offset).Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
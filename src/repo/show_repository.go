@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"theatre-management-system/src/interfaces"
 	"theatre-management-system/src/models"
@@ -10,6 +11,40 @@ import (
 	"gorm.io/gorm"
 )
 
+// localizeShowsQuery recomputes the venue-local start/end of shows from their location's timezone
+const localizeShowsQuery = `
+	UPDATE shows SET
+		timezone = l.timezone,
+		start_local = COALESCE(to_char(shows.start_date AT TIME ZONE l.timezone, 'YYYY-MM-DD"T"HH24:MI:SS'), ''),
+		end_local = COALESCE(to_char(shows.end_date AT TIME ZONE l.timezone, 'YYYY-MM-DD"T"HH24:MI:SS'), '')
+	FROM theatres t
+	INNER JOIN locations l ON t.location_id = l.id
+	WHERE shows.theatre_id = t.id
+	AND shows.deleted_at IS NULL
+`
+
+// resequenceShowsQuery bumps the iCalendar sequence of shows whose theatre or location changed, since their
+// feed entries show the venue too and subscribed calendars only replace entries with a higher sequence
+const resequenceShowsQuery = `
+	UPDATE shows SET sequence = sequence + 1
+	FROM theatres t
+	WHERE shows.theatre_id = t.id
+	AND shows.deleted_at IS NULL
+`
+
+// venueToday is the current date in the venue's timezone
+const venueToday = "(CAST(? AS timestamptz) AT TIME ZONE locations.timezone)::date"
+
+// localizeShows refreshes venue-local show times for shows matching the given condition
+func localizeShows(tx *gorm.DB, condition string, args ...interface{}) error {
+	return tx.Exec(localizeShowsQuery+" AND "+condition, args...).Error
+}
+
+// resequenceShows bumps the iCalendar sequence of shows matching the given condition
+func resequenceShows(tx *gorm.DB, condition string, args ...interface{}) error {
+	return tx.Exec(resequenceShowsQuery+" AND "+condition, args...).Error
+}
+
 // showRepository implements the ShowRepository interface
 type showRepository struct {
 	db *gorm.DB
@@ -20,15 +55,20 @@ func NewShowRepository(db *gorm.DB) interfaces.ShowRepository {
 	return &showRepository{db: db}
 }
 
-// Create creates a new show
-func (r *showRepository) Create(show *models.Show) error {
-	return r.db.Create(show).Error
+// Create creates a new show and stores its venue-local times
+func (r *showRepository) Create(ctx context.Context, show *models.Show) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(show).Error; err != nil {
+			return err
+		}
+		return localizeShows(tx, "shows.id = ?", show.ID)
+	})
 }
 
 // GetByID retrieves a show by ID with all relationships
-func (r *showRepository) GetByID(id uuid.UUID) (*models.Show, error) {
+func (r *showRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Show, error) {
 	var show models.Show
-	err := r.db.Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").First(&show, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").First(&show, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,39 +76,96 @@ func (r *showRepository) GetByID(id uuid.UUID) (*models.Show, error) {
 }
 
 // GetAll retrieves all shows with pagination
-func (r *showRepository) GetAll(limit, offset int) ([]*models.Show, error) {
+func (r *showRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Show, error) {
 	var shows []*models.Show
-	err := r.db.Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").Limit(limit).Offset(offset).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").Order("created_at, id").Limit(limit).Offset(offset).Find(&shows).Error
 	if err != nil {
 		return nil, err
 	}
 	return shows, nil
 }
 
-// Update updates an existing show
-func (r *showRepository) Update(show *models.Show) error {
-	return r.db.Save(show).Error
+// Update updates an existing show and refreshes its venue-local times
+func (r *showRepository) Update(ctx context.Context, show *models.Show) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(show).Error; err != nil {
+			return err
+		}
+		return localizeShows(tx, "shows.id = ?", show.ID)
+	})
 }
 
 // Delete soft deletes a show
-func (r *showRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Show{}, "id = ?", id).Error
+func (r *showRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Show{}, "id = ?", id).Error
+}
+
+// GetDeleted retrieves soft-deleted shows with pagination, most recently deleted first
+func (r *showRepository) GetDeleted(ctx context.Context, limit, offset int) ([]*models.Show, error) {
+	var shows []*models.Show
+	err := r.db.WithContext(ctx).Unscoped().Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&shows).Error
+	if err != nil {
+		return nil, err
+	}
+	return shows, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted show by ID
+func (r *showRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Show, error) {
+	var show models.Show
+	err := r.db.WithContext(ctx).Unscoped().Preload("Theatre").Preload("Theatre.Location").Preload("ShowType").Where("deleted_at IS NOT NULL").First(&show, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
+// Restore takes a show out of the trash, refreshing its venue-local times in case its location has changed since
+func (r *showRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Show{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return localizeShows(tx, "shows.id = ?", id)
+	})
+}
+
+// Purge permanently deletes a soft-deleted show, reporting whether there was one
+func (r *showRepository) Purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	ids, err := purgeDeleted(ctx, r.db, "shows", "", "id = ?", id)
+	return len(ids) > 0, err
+}
+
+// PurgeDeletedBefore permanently deletes shows soft-deleted before cutoff, returning their IDs
+func (r *showRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	return purgeDeleted(ctx, r.db, "shows", "", "deleted_at < ?", cutoff)
 }
 
 // GetByTheatreID retrieves shows by theatre ID
-func (r *showRepository) GetByTheatreID(theatreID uuid.UUID) ([]*models.Show, error) {
+func (r *showRepository) GetByTheatreID(ctx context.Context, theatreID uuid.UUID) ([]*models.Show, error) {
 	var shows []*models.Show
-	err := r.db.Preload("Theatre").Preload("ShowType").Where("theatre_id = ?", theatreID).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").Where("theatre_id = ?", theatreID).Find(&shows).Error
 	if err != nil {
 		return nil, err
 	}
 	return shows, nil
 }
 
+// GetByTitleAndTheatreID retrieves a show by title at a theatre, ignoring case
+func (r *showRepository) GetByTitleAndTheatreID(ctx context.Context, title string, theatreID uuid.UUID) (*models.Show, error) {
+	var show models.Show
+	err := r.db.WithContext(ctx).Where("LOWER(title) = LOWER(?) AND theatre_id = ?", title, theatreID).First(&show).Error
+	if err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
 // GetByShowTypeID retrieves shows by show type ID
-func (r *showRepository) GetByShowTypeID(showTypeID uuid.UUID) ([]*models.Show, error) {
+func (r *showRepository) GetByShowTypeID(ctx context.Context, showTypeID uuid.UUID) ([]*models.Show, error) {
 	var shows []*models.Show
-	err := r.db.Preload("Theatre").Preload("ShowType").Where("show_type_id = ?", showTypeID).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").Where("show_type_id = ?", showTypeID).Find(&shows).Error
 	if err != nil {
 		return nil, err
 	}
@@ -76,9 +173,9 @@ func (r *showRepository) GetByShowTypeID(showTypeID uuid.UUID) ([]*models.Show,
 }
 
 // GetFeaturedShows retrieves all featured shows
-func (r *showRepository) GetFeaturedShows() ([]*models.Show, error) {
+func (r *showRepository) GetFeaturedShows(ctx context.Context) ([]*models.Show, error) {
 	var shows []*models.Show
-	err := r.db.Preload("Theatre").Preload("ShowType").Where("is_featured = ?", true).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").Where("is_featured = ?", true).Find(&shows).Error
 	if err != nil {
 		return nil, err
 	}
@@ -86,24 +183,27 @@ func (r *showRepository) GetFeaturedShows() ([]*models.Show, error) {
 }
 
 // GetActiveShows retrieves all active shows
-func (r *showRepository) GetActiveShows() ([]*models.Show, error) {
+func (r *showRepository) GetActiveShows(ctx context.Context) ([]*models.Show, error) {
 	var shows []*models.Show
-	err := r.db.Preload("Theatre").Preload("ShowType").Where("is_active = ?", true).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").Where("is_active = ?", true).Find(&shows).Error
 	if err != nil {
 		return nil, err
 	}
 	return shows, nil
 }
 
-// GetCurrentShows retrieves shows that are currently running
-func (r *showRepository) GetCurrentShows() ([]*models.Show, error) {
+// GetCurrentShows retrieves shows that are currently running in their venue's local day
+func (r *showRepository) GetCurrentShows(ctx context.Context) ([]*models.Show, error) {
 	var shows []*models.Show
 	now := time.Now()
 
-	err := r.db.Preload("Theatre").Preload("ShowType").Where(
-		"is_active = ? AND start_date <= ? AND (end_date IS NULL OR end_date >= ?)",
-		true, now, now,
-	).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").
+		Joins("INNER JOIN theatres ON theatres.id = shows.theatre_id").
+		Joins("INNER JOIN locations ON locations.id = theatres.location_id").
+		Where("shows.is_active = ?", true).
+		Where("(shows.start_date AT TIME ZONE locations.timezone)::date <= "+venueToday, now).
+		Where("(shows.end_date IS NULL OR (shows.end_date AT TIME ZONE locations.timezone)::date >= "+venueToday+")", now).
+		Find(&shows).Error
 
 	if err != nil {
 		return nil, err
@@ -111,15 +211,17 @@ func (r *showRepository) GetCurrentShows() ([]*models.Show, error) {
 	return shows, nil
 }
 
-// GetUpcomingShows retrieves shows that will start in the future
-func (r *showRepository) GetUpcomingShows() ([]*models.Show, error) {
+// GetUpcomingShows retrieves shows that start after the current day in their venue's timezone
+func (r *showRepository) GetUpcomingShows(ctx context.Context) ([]*models.Show, error) {
 	var shows []*models.Show
 	now := time.Now()
 
-	err := r.db.Preload("Theatre").Preload("ShowType").Where(
-		"is_active = ? AND start_date > ?",
-		true, now,
-	).Find(&shows).Error
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").
+		Joins("INNER JOIN theatres ON theatres.id = shows.theatre_id").
+		Joins("INNER JOIN locations ON locations.id = theatres.location_id").
+		Where("shows.is_active = ?", true).
+		Where("(shows.start_date AT TIME ZONE locations.timezone)::date > "+venueToday, now).
+		Find(&shows).Error
 
 	if err != nil {
 		return nil, err
@@ -128,11 +230,11 @@ func (r *showRepository) GetUpcomingShows() ([]*models.Show, error) {
 }
 
 // Search searches shows by title, description, director, or cast
-func (r *showRepository) Search(query string) ([]*models.Show, error) {
+func (r *showRepository) Search(ctx context.Context, query string) ([]*models.Show, error) {
 	var shows []*models.Show
 	searchPattern := fmt.Sprintf("%%%s%%", query)
 
-	err := r.db.Preload("Theatre").Preload("ShowType").Where(
+	err := r.db.WithContext(ctx).Preload("Theatre").Preload("ShowType").Where(
 		"title ILIKE ? OR description ILIKE ? OR director ILIKE ? OR cast ILIKE ?",
 		searchPattern, searchPattern, searchPattern, searchPattern,
 	).Find(&shows).Error
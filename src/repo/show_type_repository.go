@@ -1,11 +1,14 @@
 package repo
 
 import (
+	"context"
 	"theatre-management-system/src/interfaces"
 	"theatre-management-system/src/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // showTypeRepository implements the ShowTypeRepository interface
@@ -19,14 +22,14 @@ func NewShowTypeRepository(db *gorm.DB) interfaces.ShowTypeRepository {
 }
 
 // Create creates a new show type
-func (r *showTypeRepository) Create(showType *models.ShowType) error {
-	return r.db.Create(showType).Error
+func (r *showTypeRepository) Create(ctx context.Context, showType *models.ShowType) error {
+	return r.db.WithContext(ctx).Create(showType).Error
 }
 
 // GetByID retrieves a show type by ID
-func (r *showTypeRepository) GetByID(id uuid.UUID) (*models.ShowType, error) {
+func (r *showTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ShowType, error) {
 	var showType models.ShowType
-	err := r.db.Preload("Shows").First(&showType, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Shows").First(&showType, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +37,9 @@ func (r *showTypeRepository) GetByID(id uuid.UUID) (*models.ShowType, error) {
 }
 
 // GetAll retrieves all show types with pagination
-func (r *showTypeRepository) GetAll(limit, offset int) ([]*models.ShowType, error) {
+func (r *showTypeRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.ShowType, error) {
 	var showTypes []*models.ShowType
-	err := r.db.Limit(limit).Offset(offset).Find(&showTypes).Error
+	err := r.db.WithContext(ctx).Order("created_at, id").Limit(limit).Offset(offset).Find(&showTypes).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,19 +47,67 @@ func (r *showTypeRepository) GetAll(limit, offset int) ([]*models.ShowType, erro
 }
 
 // Update updates an existing show type
-func (r *showTypeRepository) Update(showType *models.ShowType) error {
-	return r.db.Save(showType).Error
+func (r *showTypeRepository) Update(ctx context.Context, showType *models.ShowType) error {
+	return r.db.WithContext(ctx).Save(showType).Error
 }
 
 // Delete soft deletes a show type
-func (r *showTypeRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.ShowType{}, "id = ?", id).Error
+func (r *showTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.ShowType{}, "id = ?", id).Error
+}
+
+// GetDeleted retrieves soft-deleted show types with pagination, most recently deleted first
+func (r *showTypeRepository) GetDeleted(ctx context.Context, limit, offset int) ([]*models.ShowType, error) {
+	var showTypes []*models.ShowType
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&showTypes).Error
+	if err != nil {
+		return nil, err
+	}
+	return showTypes, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted show type by ID
+func (r *showTypeRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.ShowType, error) {
+	var showType models.ShowType
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&showType, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &showType, nil
+}
+
+// Restore takes a show type out of the trash
+func (r *showTypeRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.ShowType{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes a soft-deleted show type that no shows, deleted or not, refer to, reporting whether it did
+func (r *showTypeRepository) Purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	ids, err := purgeDeleted(ctx, r.db, "show_types", showTypeUnreferenced, "id = ?", id)
+	return len(ids) > 0, err
+}
+
+// PurgeDeletedBefore permanently deletes show types soft-deleted before cutoff that no shows refer to, returning their IDs
+func (r *showTypeRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	return purgeDeleted(ctx, r.db, "show_types", showTypeUnreferenced, "deleted_at < ?", cutoff)
+}
+
+// LockForShare locks a show type against concurrent updates and deletes until the transaction ends
+func (r *showTypeRepository) LockForShare(ctx context.Context, id uuid.UUID) error {
+	var showType models.ShowType
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&showType, "id = ?", id).Error
+}
+
+// LockForUpdate locks a show type against anything that would refer to, update or delete it until the transaction ends
+func (r *showTypeRepository) LockForUpdate(ctx context.Context, id uuid.UUID) error {
+	var showType models.ShowType
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&showType, "id = ?", id).Error
 }
 
 // GetByName retrieves a show type by name
-func (r *showTypeRepository) GetByName(name string) (*models.ShowType, error) {
+func (r *showTypeRepository) GetByName(ctx context.Context, name string) (*models.ShowType, error) {
 	var showType models.ShowType
-	err := r.db.Where("name = ?", name).First(&showType).Error
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&showType).Error
 	if err != nil {
 		return nil, err
 	}
@@ -64,9 +115,9 @@ func (r *showTypeRepository) GetByName(name string) (*models.ShowType, error) {
 }
 
 // GetActiveTypes retrieves all active show types
-func (r *showTypeRepository) GetActiveTypes() ([]*models.ShowType, error) {
+func (r *showTypeRepository) GetActiveTypes(ctx context.Context) ([]*models.ShowType, error) {
 	var showTypes []*models.ShowType
-	err := r.db.Where("is_active = ?", true).Find(&showTypes).Error
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&showTypes).Error
 	if err != nil {
 		return nil, err
 	}
@@ -1,12 +1,15 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"theatre-management-system/src/interfaces"
 	"theatre-management-system/src/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // theatreRepository implements the TheatreRepository interface
@@ -20,14 +23,14 @@ func NewTheatreRepository(db *gorm.DB) interfaces.TheatreRepository {
 }
 
 // Create creates a new theatre
-func (r *theatreRepository) Create(theatre *models.Theatre) error {
-	return r.db.Create(theatre).Error
+func (r *theatreRepository) Create(ctx context.Context, theatre *models.Theatre) error {
+	return r.db.WithContext(ctx).Create(theatre).Error
 }
 
 // GetByID retrieves a theatre by ID with all relationships
-func (r *theatreRepository) GetByID(id uuid.UUID) (*models.Theatre, error) {
+func (r *theatreRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Theatre, error) {
 	var theatre models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Preload("Shows").First(&theatre, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Preload("Shows.ShowType").First(&theatre, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,39 +38,109 @@ func (r *theatreRepository) GetByID(id uuid.UUID) (*models.Theatre, error) {
 }
 
 // GetAll retrieves all theatres with pagination
-func (r *theatreRepository) GetAll(limit, offset int) ([]*models.Theatre, error) {
+func (r *theatreRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Limit(limit).Offset(offset).Find(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Order("created_at, id").Limit(limit).Offset(offset).Find(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
 	return theatres, nil
 }
 
-// Update updates an existing theatre
-func (r *theatreRepository) Update(theatre *models.Theatre) error {
-	return r.db.Save(theatre).Error
+// Update updates an existing theatre, re-localizing its shows in case its location changed and bumping
+// their calendar sequence
+func (r *theatreRepository) Update(ctx context.Context, theatre *models.Theatre) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(theatre).Error; err != nil {
+			return err
+		}
+		if err := localizeShows(tx, "t.id = ?", theatre.ID); err != nil {
+			return err
+		}
+		return resequenceShows(tx, "t.id = ?", theatre.ID)
+	})
 }
 
 // Delete soft deletes a theatre
-func (r *theatreRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Theatre{}, "id = ?", id).Error
+func (r *theatreRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Theatre{}, "id = ?", id).Error
+}
+
+// GetDeleted retrieves soft-deleted theatres with pagination, most recently deleted first
+func (r *theatreRepository) GetDeleted(ctx context.Context, limit, offset int) ([]*models.Theatre, error) {
+	var theatres []*models.Theatre
+	err := r.db.WithContext(ctx).Unscoped().Preload("Location").Preload("TheatreType").Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&theatres).Error
+	if err != nil {
+		return nil, err
+	}
+	return theatres, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted theatre by ID
+func (r *theatreRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.Theatre, error) {
+	var theatre models.Theatre
+	err := r.db.WithContext(ctx).Unscoped().Preload("Location").Preload("TheatreType").Where("deleted_at IS NOT NULL").First(&theatre, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &theatre, nil
+}
+
+// Restore takes a theatre out of the trash
+func (r *theatreRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Theatre{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes a soft-deleted theatre that no shows, deleted or not, refer to, reporting whether it did
+func (r *theatreRepository) Purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	ids, err := purgeDeleted(ctx, r.db, "theatres", theatreUnreferenced, "id = ?", id)
+	return len(ids) > 0, err
+}
+
+// PurgeDeletedBefore permanently deletes theatres soft-deleted before cutoff that no shows refer to, returning their IDs
+func (r *theatreRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	return purgeDeleted(ctx, r.db, "theatres", theatreUnreferenced, "deleted_at < ?", cutoff)
+}
+
+// LockForShare locks a theatre against concurrent updates and deletes until the transaction ends
+func (r *theatreRepository) LockForShare(ctx context.Context, id uuid.UUID) error {
+	var theatre models.Theatre
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&theatre, "id = ?", id).Error
+}
+
+// LockForUpdate locks a theatre against anything that would refer to, update or delete it until the transaction ends
+func (r *theatreRepository) LockForUpdate(ctx context.Context, id uuid.UUID) error {
+	var theatre models.Theatre
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&theatre, "id = ?", id).Error
 }
 
 // GetByLocationID retrieves theatres by location ID
-func (r *theatreRepository) GetByLocationID(locationID uuid.UUID) ([]*models.Theatre, error) {
+func (r *theatreRepository) GetByLocationID(ctx context.Context, locationID uuid.UUID) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Where("location_id = ?", locationID).Find(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Where("location_id = ?", locationID).Find(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
 	return theatres, nil
 }
 
+// GetByNameAndCity retrieves a theatre by name within a city, ignoring case
+func (r *theatreRepository) GetByNameAndCity(ctx context.Context, name, city string) (*models.Theatre, error) {
+	var theatre models.Theatre
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").
+		Joins("INNER JOIN locations ON locations.id = theatres.location_id AND locations.deleted_at IS NULL").
+		Where("LOWER(theatres.name) = LOWER(?) AND LOWER(locations.city) = LOWER(?)", name, city).
+		First(&theatre).Error
+	if err != nil {
+		return nil, err
+	}
+	return &theatre, nil
+}
+
 // GetByTheatreTypeID retrieves theatres by theatre type ID
-func (r *theatreRepository) GetByTheatreTypeID(theatreTypeID uuid.UUID) ([]*models.Theatre, error) {
+func (r *theatreRepository) GetByTheatreTypeID(ctx context.Context, theatreTypeID uuid.UUID) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Where("theatre_type_id = ?", theatreTypeID).Find(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Where("theatre_type_id = ?", theatreTypeID).Find(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
@@ -75,9 +148,9 @@ func (r *theatreRepository) GetByTheatreTypeID(theatreTypeID uuid.UUID) ([]*mode
 }
 
 // GetFeaturedTheatres retrieves all featured theatres
-func (r *theatreRepository) GetFeaturedTheatres() ([]*models.Theatre, error) {
+func (r *theatreRepository) GetFeaturedTheatres(ctx context.Context) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Where("is_featured = ?", true).Find(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Where("is_featured = ?", true).Find(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +158,9 @@ func (r *theatreRepository) GetFeaturedTheatres() ([]*models.Theatre, error) {
 }
 
 // GetActiveTheatres retrieves all active theatres
-func (r *theatreRepository) GetActiveTheatres() ([]*models.Theatre, error) {
+func (r *theatreRepository) GetActiveTheatres(ctx context.Context) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
-	err := r.db.Preload("Location").Preload("TheatreType").Where("is_active = ?", true).Find(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Where("is_active = ?", true).Find(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
@@ -95,11 +168,11 @@ func (r *theatreRepository) GetActiveTheatres() ([]*models.Theatre, error) {
 }
 
 // Search searches theatres by name or description
-func (r *theatreRepository) Search(query string) ([]*models.Theatre, error) {
+func (r *theatreRepository) Search(ctx context.Context, query string) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
 	searchPattern := fmt.Sprintf("%%%s%%", query)
 
-	err := r.db.Preload("Location").Preload("TheatreType").Where(
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Where(
 		"name ILIKE ? OR description ILIKE ?",
 		searchPattern, searchPattern,
 	).Find(&theatres).Error
@@ -112,7 +185,7 @@ func (r *theatreRepository) Search(query string) ([]*models.Theatre, error) {
 }
 
 // GetNearbyTheatres finds theatres within a radius (in kilometers) of given coordinates
-func (r *theatreRepository) GetNearbyTheatres(latitude, longitude, radius float64) ([]*models.Theatre, error) {
+func (r *theatreRepository) GetNearbyTheatres(ctx context.Context, latitude, longitude, radius float64) ([]*models.Theatre, error) {
 	var theatres []*models.Theatre
 
 	// Using PostGIS ST_DWithin function for geographic queries
@@ -133,7 +206,7 @@ func (r *theatreRepository) GetNearbyTheatres(latitude, longitude, radius float6
 		AND l.deleted_at IS NULL
 	`
 
-	err := r.db.Preload("Location").Preload("TheatreType").Raw(query, longitude, latitude, radiusMeters).Scan(&theatres).Error
+	err := r.db.WithContext(ctx).Preload("Location").Preload("TheatreType").Raw(query, longitude, latitude, radiusMeters).Scan(&theatres).Error
 	if err != nil {
 		return nil, err
 	}
@@ -1,11 +1,14 @@
 package repo
 
 import (
+	"context"
 	"theatre-management-system/src/interfaces"
 	"theatre-management-system/src/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // theatreTypeRepository implements the TheatreTypeRepository interface
@@ -19,14 +22,14 @@ func NewTheatreTypeRepository(db *gorm.DB) interfaces.TheatreTypeRepository {
 }
 
 // Create creates a new theatre type
-func (r *theatreTypeRepository) Create(theatreType *models.TheatreType) error {
-	return r.db.Create(theatreType).Error
+func (r *theatreTypeRepository) Create(ctx context.Context, theatreType *models.TheatreType) error {
+	return r.db.WithContext(ctx).Create(theatreType).Error
 }
 
 // GetByID retrieves a theatre type by ID
-func (r *theatreTypeRepository) GetByID(id uuid.UUID) (*models.TheatreType, error) {
+func (r *theatreTypeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TheatreType, error) {
 	var theatreType models.TheatreType
-	err := r.db.Preload("Theatres").First(&theatreType, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Theatres").First(&theatreType, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +37,9 @@ func (r *theatreTypeRepository) GetByID(id uuid.UUID) (*models.TheatreType, erro
 }
 
 // GetAll retrieves all theatre types with pagination
-func (r *theatreTypeRepository) GetAll(limit, offset int) ([]*models.TheatreType, error) {
+func (r *theatreTypeRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.TheatreType, error) {
 	var theatreTypes []*models.TheatreType
-	err := r.db.Limit(limit).Offset(offset).Find(&theatreTypes).Error
+	err := r.db.WithContext(ctx).Order("created_at, id").Limit(limit).Offset(offset).Find(&theatreTypes).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,19 +47,67 @@ func (r *theatreTypeRepository) GetAll(limit, offset int) ([]*models.TheatreType
 }
 
 // Update updates an existing theatre type
-func (r *theatreTypeRepository) Update(theatreType *models.TheatreType) error {
-	return r.db.Save(theatreType).Error
+func (r *theatreTypeRepository) Update(ctx context.Context, theatreType *models.TheatreType) error {
+	return r.db.WithContext(ctx).Save(theatreType).Error
 }
 
 // Delete soft deletes a theatre type
-func (r *theatreTypeRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.TheatreType{}, "id = ?", id).Error
+func (r *theatreTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.TheatreType{}, "id = ?", id).Error
+}
+
+// GetDeleted retrieves soft-deleted theatre types with pagination, most recently deleted first
+func (r *theatreTypeRepository) GetDeleted(ctx context.Context, limit, offset int) ([]*models.TheatreType, error) {
+	var theatreTypes []*models.TheatreType
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Limit(limit).Offset(offset).Find(&theatreTypes).Error
+	if err != nil {
+		return nil, err
+	}
+	return theatreTypes, nil
+}
+
+// GetDeletedByID retrieves a soft-deleted theatre type by ID
+func (r *theatreTypeRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.TheatreType, error) {
+	var theatreType models.TheatreType
+	err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&theatreType, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &theatreType, nil
+}
+
+// Restore takes a theatre type out of the trash
+func (r *theatreTypeRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.TheatreType{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes a soft-deleted theatre type that no theatres, deleted or not, refer to, reporting whether it did
+func (r *theatreTypeRepository) Purge(ctx context.Context, id uuid.UUID) (bool, error) {
+	ids, err := purgeDeleted(ctx, r.db, "theatre_types", theatreTypeUnreferenced, "id = ?", id)
+	return len(ids) > 0, err
+}
+
+// PurgeDeletedBefore permanently deletes theatre types soft-deleted before cutoff that no theatres refer to, returning their IDs
+func (r *theatreTypeRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	return purgeDeleted(ctx, r.db, "theatre_types", theatreTypeUnreferenced, "deleted_at < ?", cutoff)
+}
+
+// LockForShare locks a theatre type against concurrent updates and deletes until the transaction ends
+func (r *theatreTypeRepository) LockForShare(ctx context.Context, id uuid.UUID) error {
+	var theatreType models.TheatreType
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&theatreType, "id = ?", id).Error
+}
+
+// LockForUpdate locks a theatre type against anything that would refer to, update or delete it until the transaction ends
+func (r *theatreTypeRepository) LockForUpdate(ctx context.Context, id uuid.UUID) error {
+	var theatreType models.TheatreType
+	return r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&theatreType, "id = ?", id).Error
 }
 
 // GetByName retrieves a theatre type by name
-func (r *theatreTypeRepository) GetByName(name string) (*models.TheatreType, error) {
+func (r *theatreTypeRepository) GetByName(ctx context.Context, name string) (*models.TheatreType, error) {
 	var theatreType models.TheatreType
-	err := r.db.Where("name = ?", name).First(&theatreType).Error
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&theatreType).Error
 	if err != nil {
 		return nil, err
 	}
@@ -64,9 +115,9 @@ func (r *theatreTypeRepository) GetByName(name string) (*models.TheatreType, err
 }
 
 // GetActiveTypes retrieves all active theatre types
-func (r *theatreTypeRepository) GetActiveTypes() ([]*models.TheatreType, error) {
+func (r *theatreTypeRepository) GetActiveTypes(ctx context.Context) ([]*models.TheatreType, error) {
 	var theatreTypes []*models.TheatreType
-	err := r.db.Where("is_active = ?", true).Find(&theatreTypes).Error
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&theatreTypes).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Rows a foreign key still points at, even from the trash, cannot be purged
+const (
+	locationUnreferenced    = "NOT EXISTS (SELECT 1 FROM theatres WHERE theatres.location_id = locations.id)"
+	theatreTypeUnreferenced = "NOT EXISTS (SELECT 1 FROM theatres WHERE theatres.theatre_type_id = theatre_types.id)"
+	showTypeUnreferenced    = "NOT EXISTS (SELECT 1 FROM shows WHERE shows.show_type_id = show_types.id)"
+	theatreUnreferenced     = "NOT EXISTS (SELECT 1 FROM shows WHERE shows.theatre_id = theatres.id)"
+)
+
+// purgeDeleted permanently deletes the soft-deleted rows of table matching condition, skipping any that fail
+// the unreferenced guard when one is given, and returns the IDs it deleted
+func purgeDeleted(ctx context.Context, db *gorm.DB, table, unreferenced, condition string, args ...interface{}) ([]uuid.UUID, error) {
+	query := "DELETE FROM " + table + " WHERE deleted_at IS NOT NULL AND " + condition
+	if unreferenced != "" {
+		query += " AND " + unreferenced
+	}
+
+	var ids []uuid.UUID
+	if err := db.WithContext(ctx).Raw(query+" RETURNING id", args...).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
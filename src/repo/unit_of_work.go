@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// unitOfWork implements the UnitOfWork interface
+type unitOfWork struct {
+	db               *gorm.DB
+	locations        interfaces.LocationRepository
+	theatreTypes     interfaces.TheatreTypeRepository
+	showTypes        interfaces.ShowTypeRepository
+	theatres         interfaces.TheatreRepository
+	shows            interfaces.ShowRepository
+	outbox           interfaces.OutboxRepository
+	webhooks         interfaces.WebhookRepository
+	audit            interfaces.AuditRepository
+	showRevisions    interfaces.ShowRevisionRepository
+	theatreRevisions interfaces.TheatreRevisionRepository
+	importJobs       interfaces.ImportJobRepository
+
+	// afterCommit collects callbacks for the transaction this unit of work runs in; nil outside one
+	afterCommit *[]func()
+}
+
+// NewUnitOfWork creates a unit of work whose repositories use the given database handle
+func NewUnitOfWork(db *gorm.DB) interfaces.UnitOfWork {
+	return newUnitOfWork(db, nil)
+}
+
+// newUnitOfWork creates a unit of work that queues after-commit callbacks on afterCommit
+func newUnitOfWork(db *gorm.DB, afterCommit *[]func()) *unitOfWork {
+	return &unitOfWork{
+		db:               db,
+		afterCommit:      afterCommit,
+		locations:        NewLocationRepository(db),
+		theatreTypes:     NewTheatreTypeRepository(db),
+		showTypes:        NewShowTypeRepository(db),
+		theatres:         NewTheatreRepository(db),
+		shows:            NewShowRepository(db),
+		outbox:           NewOutboxRepository(db),
+		webhooks:         NewWebhookRepository(db),
+		audit:            NewAuditRepository(db),
+		showRevisions:    NewShowRevisionRepository(db),
+		theatreRevisions: NewTheatreRevisionRepository(db),
+		importJobs:       NewImportJobRepository(db),
+	}
+}
+
+// Locations returns the location repository
+func (u *unitOfWork) Locations() interfaces.LocationRepository {
+	return u.locations
+}
+
+// TheatreTypes returns the theatre type repository
+func (u *unitOfWork) TheatreTypes() interfaces.TheatreTypeRepository {
+	return u.theatreTypes
+}
+
+// ShowTypes returns the show type repository
+func (u *unitOfWork) ShowTypes() interfaces.ShowTypeRepository {
+	return u.showTypes
+}
+
+// Theatres returns the theatre repository
+func (u *unitOfWork) Theatres() interfaces.TheatreRepository {
+	return u.theatres
+}
+
+// Shows returns the show repository
+func (u *unitOfWork) Shows() interfaces.ShowRepository {
+	return u.shows
+}
+
+// Outbox returns the outbox repository
+func (u *unitOfWork) Outbox() interfaces.OutboxRepository {
+	return u.outbox
+}
+
+// Webhooks returns the webhook repository
+func (u *unitOfWork) Webhooks() interfaces.WebhookRepository {
+	return u.webhooks
+}
+
+// Audit returns the audit repository
+func (u *unitOfWork) Audit() interfaces.AuditRepository {
+	return u.audit
+}
+
+// ShowRevisions returns the show revision repository
+func (u *unitOfWork) ShowRevisions() interfaces.ShowRevisionRepository {
+	return u.showRevisions
+}
+
+// TheatreRevisions returns the theatre revision repository
+func (u *unitOfWork) TheatreRevisions() interfaces.TheatreRevisionRepository {
+	return u.theatreRevisions
+}
+
+// ImportJobs returns the import job repository
+func (u *unitOfWork) ImportJobs() interfaces.ImportJobRepository {
+	return u.importJobs
+}
+
+// Do runs fn in a transaction; GORM turns transactions started inside another into savepoints
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx interfaces.UnitOfWork) error) error {
+	var callbacks []func()
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(newUnitOfWork(tx, &callbacks))
+	})
+	if err != nil {
+		// Rolled back, so whatever fn queued never happened
+		return err
+	}
+
+	// A savepoint hands its callbacks to the enclosing transaction, which may still roll back
+	if u.afterCommit != nil {
+		*u.afterCommit = append(*u.afterCommit, callbacks...)
+		return nil
+	}
+	for _, callback := range callbacks {
+		callback()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the enclosing transaction commits, or straight away outside a transaction
+func (u *unitOfWork) AfterCommit(fn func()) {
+	if u.afterCommit == nil {
+		fn()
+		return
+	}
+	*u.afterCommit = append(*u.afterCommit, fn)
+}
@@ -0,0 +1,124 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"theatre-management-system/src/repo"
+	"theatre-management-system/src/testdb"
+
+	"gorm.io/gorm"
+)
+
+// testSchema keeps these tests' tables apart from other packages' running at the same time
+const testSchema = "repo_test"
+
+var errRollback = errors.New("roll back")
+
+func TestDoRollsBackWritesWhenFnFails(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	uow := repo.NewUnitOfWork(db)
+
+	err := uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if err := tx.Locations().Create(ctx, newLocation("Rolled Back")); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Do returned %v, want %v", err, errRollback)
+	}
+	assertLocations(t, db)
+}
+
+func TestNestedDoRollsBackOnlyToItsSavepoint(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.Migrated(t, testSchema)
+	uow := repo.NewUnitOfWork(db)
+
+	err := uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		if err := tx.Locations().Create(ctx, newLocation("Outer")); err != nil {
+			return err
+		}
+		err := tx.Do(ctx, func(inner interfaces.UnitOfWork) error {
+			if err := inner.Locations().Create(ctx, newLocation("Inner")); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Errorf("nested Do returned %v, want %v", err, errRollback)
+		}
+		return tx.Locations().Create(ctx, newLocation("After Savepoint"))
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	assertLocations(t, db, "After Savepoint", "Outer")
+}
+
+func TestAfterCommitSkipsRolledBackWork(t *testing.T) {
+	ctx := context.Background()
+	uow := repo.NewUnitOfWork(testdb.Migrated(t, testSchema))
+
+	var ran []string
+	err := uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		tx.AfterCommit(func() { ran = append(ran, "rolled back") })
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Do returned %v, want %v", err, errRollback)
+	}
+	if len(ran) != 0 {
+		t.Fatalf("callbacks ran after a rollback: %v", ran)
+	}
+
+	err = uow.Do(ctx, func(tx interfaces.UnitOfWork) error {
+		tx.AfterCommit(func() { ran = append(ran, "outer") })
+		tx.Do(ctx, func(inner interfaces.UnitOfWork) error {
+			inner.AfterCommit(func() { ran = append(ran, "rolled back savepoint") })
+			return errRollback
+		})
+		if err := tx.Do(ctx, func(inner interfaces.UnitOfWork) error {
+			inner.AfterCommit(func() { ran = append(ran, "released savepoint") })
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(ran) != 0 {
+			t.Errorf("callbacks ran before the transaction committed: %v", ran)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if len(ran) != 2 || ran[0] != "outer" || ran[1] != "released savepoint" {
+		t.Errorf("callbacks ran = %v, want [outer released savepoint]", ran)
+	}
+}
+
+// newLocation builds a location with only the required fields set
+func newLocation(name string) *models.Location {
+	return &models.Location{Name: name, City: "New York", Country: "United States"}
+}
+
+// assertLocations fails the test unless the locations table holds exactly the named rows
+func assertLocations(t *testing.T, db *gorm.DB, want ...string) {
+	t.Helper()
+	var names []string
+	if err := db.Model(&models.Location{}).Order("name").Pluck("name", &names).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != len(want) {
+		t.Fatalf("locations = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("locations = %v, want %v", names, want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package repo
+
+import (
+	"context"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookRepository implements the WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create creates a new webhook subscription
+func (r *webhookRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+// GetByID retrieves a webhook subscription by ID
+func (r *webhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.db.WithContext(ctx).First(&subscription, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetAll retrieves all webhook subscriptions with pagination, oldest first
+func (r *webhookRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := r.db.WithContext(ctx).Order("created_at, id").Limit(limit).Offset(offset).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetActive retrieves every active webhook subscription
+func (r *webhookRepository) GetActive(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Update updates an existing webhook subscription
+func (r *webhookRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+// Delete soft deletes a webhook subscription; its pending deliveries are no longer claimed
+func (r *webhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.WebhookSubscription{}, "id = ?", id).Error
+}
+
+// RecordSuccess clears a subscription's run of failures
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookSubscription{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		UpdateColumn("consecutive_failures", 0).Error
+}
+
+// RecordFailure counts a failed delivery and disables the subscription once it reaches disableAfter failures in a row
+func (r *webhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int, reason string) (bool, error) {
+	var disabled bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.WebhookSubscription{}).Where("id = ?", id).
+			UpdateColumn("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		// The increment holds the row lock, so only one failure can be the one that disables it
+		result := tx.Model(&models.WebhookSubscription{}).
+			Where("id = ? AND is_active AND consecutive_failures >= ?", id, disableAfter).
+			UpdateColumns(map[string]interface{}{
+				"is_active":       false,
+				"disabled_at":     time.Now(),
+				"disabled_reason": reason,
+				"updated_at":      time.Now(),
+			})
+		disabled = result.RowsAffected > 0
+		return result.Error
+	})
+	return disabled, err
+}
+
+// CreateDeliveries queues deliveries, skipping any already queued for the same subscription and outbox message
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries ...*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "outbox_message_id"}},
+		DoNothing: true,
+	}).Create(deliveries).Error
+}
+
+// GetDeliveries retrieves a subscription's deliveries, newest first, optionally filtered by status
+func (r *webhookRepository) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDelivery retrieves one of a subscription's deliveries with its attempts in order
+func (r *webhookRepository) GetDelivery(ctx context.Context, subscriptionID, id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Preload("Log", func(db *gorm.DB) *gorm.DB { return db.Order("attempt") }).
+		First(&delivery, "id = ? AND subscription_id = ?", id, subscriptionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ClaimDueDeliveries leases due deliveries; SKIP LOCKED lets several dispatchers poll at once without blocking each other
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Raw(`
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = ? AND d.next_attempt_at <= NOW()
+			  AND s.is_active AND s.deleted_at IS NULL
+			ORDER BY d.next_attempt_at
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED`, constants.WebhookDeliveryPending, limit).Scan(&ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": time.Now().Add(lease),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Preload("Subscription").Where("id IN ?", ids).Order("next_attempt_at").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// SaveAttempt logs an attempt and stores the delivery's resulting status in one transaction
+func (r *webhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(delivery).
+			Select("status", "next_attempt_at", "last_response_status", "last_error", "completed_at").
+			Updates(delivery).Error
+	})
+}
+
+// DeleteDeliveriesCompletedBefore removes deliveries, and their attempts, completed before cutoff
+func (r *webhookRepository) DeleteDeliveriesCompletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status <> ? AND completed_at < ?", constants.WebhookDeliveryPending, cutoff).
+		Delete(&models.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,146 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+	"theatre-management-system/src/interfaces"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// fixtureExtensions are the file formats a fixture can be written in, JSON first
+var fixtureExtensions = []string{".json", ".yaml", ".yml"}
+
+// Usage describes the seed subcommand
+const Usage = `usage: seed [flags] <set|file.json|file.yaml>
+
+sets:
+  minimal      one record of each kind
+  demo         realistic sample catalogue for local development
+  load-test    synthetic catalogue for performance testing
+
+flags (generator mode, override the set's "generate" block):
+  -locations n     generate n locations (0 uses the set's own)
+  -theatres n      generate n theatres
+  -shows n         generate n shows
+  -random-seed s   random seed; the same seed regenerates the same records
+  -name-prefix p   start generated location and theatre names with p`
+
+// LoadFixture reads a built-in fixture set by name, or a JSON or YAML fixture file by path
+func LoadFixture(name string) (*dto.SeedFixture, error) {
+	extension := strings.ToLower(filepath.Ext(name))
+	data, err := readFixture(name, extension)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML goes through JSON so both formats share the DTOs' json tags and unknown-field check
+	if extension == ".yaml" || extension == ".yml" {
+		var document interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", constants.ErrorSeedInvalid, name, err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", constants.ErrorSeedInvalid, name, err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var fixture dto.SeedFixture
+	if err := decoder.Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", constants.ErrorSeedInvalid, name, err)
+	}
+	return &fixture, nil
+}
+
+// readFixture reads a fixture file when name has a fixture extension, or else the built-in set of that name
+func readFixture(name, extension string) ([]byte, error) {
+	if slices.Contains(fixtureExtensions, extension) {
+		return os.ReadFile(name)
+	}
+	for _, extension := range fixtureExtensions {
+		if data, err := fixtures.ReadFile("fixtures/" + name + extension); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown fixture set %q (expected %s, %s or %s)", name, constants.SeedSetMinimal, constants.SeedSetDemo, constants.SeedSetLoadTest)
+}
+
+// RunCLI loads a fixture set, applies any generator flags and seeds it, writing a summary to out
+func RunCLI(ctx context.Context, service interfaces.SeedService, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	locations := flags.Int("locations", -1, "")
+	theatres := flags.Int("theatres", -1, "")
+	shows := flags.Int("shows", -1, "")
+	randomSeed := flags.Int64("random-seed", -1, "")
+	namePrefix := flags.String("name-prefix", "", "")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errors.New(Usage)
+	}
+
+	fixture, err := LoadFixture(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// Flags only override what they set, keeping the rest of the set's generate block
+	if *locations >= 0 || *theatres >= 0 || *shows >= 0 || *randomSeed >= 0 || *namePrefix != "" {
+		if fixture.Generate == nil {
+			fixture.Generate = &dto.SeedGenerateCount{}
+		}
+		if *locations >= 0 {
+			fixture.Generate.Locations = *locations
+		}
+		if *theatres >= 0 {
+			fixture.Generate.Theatres = *theatres
+		}
+		if *shows >= 0 {
+			fixture.Generate.Shows = *shows
+		}
+		if *randomSeed >= 0 {
+			fixture.Generate.RandomSeed = *randomSeed
+		}
+		if *namePrefix != "" {
+			fixture.Generate.NamePrefix = *namePrefix
+		}
+	}
+
+	report, err := service.Seed(ctx, fixture)
+	if err != nil {
+		return err
+	}
+
+	rows := []struct {
+		name   string
+		counts dto.SeedEntityCounts
+	}{
+		{"theatre types", report.TheatreTypes},
+		{"show types", report.ShowTypes},
+		{"locations", report.Locations},
+		{"theatres", report.Theatres},
+		{"shows", report.Shows},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(out, "%-14s %6d created %6d updated %6d unchanged\n", row.name, row.counts.Created, row.counts.Updated, row.counts.Unchanged)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/dto"
+)
+
+// minimalYAML is the minimal set written as YAML, dates left unquoted
+const minimalYAML = `description: "Smallest useful data set: one record of each kind"
+theatre_types:
+  - name: Broadway
+    description: Professional theaters in Manhattan's Theater District with 500+ seats
+show_types:
+  - name: Musical
+    description: Theatrical productions featuring songs, spoken dialogue, acting, and dance
+locations:
+  - name: Manhattan Theater District
+    city: New York
+    state: New York
+    country: United States
+    latitude: 40.7589
+    longitude: -73.9851
+    postal_code: "10036"
+    address: Times Square, NYC
+    description: Heart of Broadway theater district
+    timezone: America/New_York
+theatres:
+  - name: Majestic Theatre
+    description: Home of The Phantom of the Opera for over three decades
+    capacity: 1645
+    address: 245 W 44th St, New York, NY 10036
+    phone: (212) 239-6200
+    website: https://www.majestic-theatre.com
+    is_featured: true
+    theatre_type: Broadway
+    location_name: Manhattan Theater District
+    location_city: New York
+shows:
+  - title: The Phantom of the Opera
+    description: Andrew Lloyd Webber's haunting romance beneath the Paris Opera House
+    director: Hal Prince
+    duration: 150
+    start_date: 2026-01-15T19:30:00-05:00
+    end_date: 2026-12-31T22:00:00-05:00
+    price: 129.0
+    is_featured: true
+    show_type: Musical
+    theatre_name: Majestic Theatre
+    theatre_city: New York
+`
+
+// writeFixture writes a fixture file into the test's temporary directory
+func writeFixture(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFixtureSets(t *testing.T) {
+	for _, name := range []string{constants.SeedSetMinimal, constants.SeedSetDemo, constants.SeedSetLoadTest} {
+		if _, err := LoadFixture(name); err != nil {
+			t.Errorf("LoadFixture(%q): %v", name, err)
+		}
+	}
+	if _, err := LoadFixture("everything"); err == nil || !strings.Contains(err.Error(), `unknown fixture set "everything"`) {
+		t.Errorf("LoadFixture of an unknown set returned %v", err)
+	}
+}
+
+func TestLoadFixtureYAMLMatchesJSON(t *testing.T) {
+	want, err := LoadFixture(constants.SeedSetMinimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"minimal.yaml", "minimal.YML"} {
+		got, err := LoadFixture(writeFixture(t, name, minimalYAML))
+		if err != nil {
+			t.Fatalf("LoadFixture(%s): %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s loaded as %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestLoadFixtureRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{name: "typo.json", contents: `{"theatre_types": [{"name": "Broadway", "descripton": "typo"}]}`},
+		{name: "typo.yaml", contents: "theatre_types:\n  - name: Broadway\n    descripton: typo\n"},
+		{name: "broken.yaml", contents: "theatre_types: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFixture(writeFixture(t, tt.name, tt.contents))
+			if err == nil || !strings.HasPrefix(err.Error(), constants.ErrorSeedInvalid) {
+				t.Errorf("LoadFixture returned %v, want %s", err, constants.ErrorSeedInvalid)
+			}
+		})
+	}
+}
+
+// recordingSeeder remembers the fixture it was asked to seed
+type recordingSeeder struct{ fixture *dto.SeedFixture }
+
+func (s *recordingSeeder) Seed(_ context.Context, fixture *dto.SeedFixture) (*dto.SeedReport, error) {
+	s.fixture = fixture
+	return &dto.SeedReport{}, nil
+}
+
+func TestRunCLIFlagsOverrideGenerate(t *testing.T) {
+	seeder := &recordingSeeder{}
+	args := []string{"-shows", "10", "-name-prefix", "Smoke ", constants.SeedSetLoadTest}
+	if err := RunCLI(context.Background(), seeder, args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("RunCLI: %v", err)
+	}
+
+	want := dto.SeedGenerateCount{Locations: 200, Theatres: 5000, Shows: 10, RandomSeed: 42, NamePrefix: "Smoke "}
+	if *seeder.fixture.Generate != want {
+		t.Errorf("generate = %+v, want %+v", *seeder.fixture.Generate, want)
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/interfaces"
+	"time"
+)
+
+// Drainer is told when shutdown begins, so readiness can start failing
+type Drainer interface {
+	StartDraining()
+}
+
+// Server runs the HTTP API and shuts it down in order: drain, stop serving, stop workers, close the databases
+type Server struct {
+	http    *http.Server
+	config  config.ServerConfig
+	drainer Drainer
+	workers []interfaces.Worker
+	dbs     []*sql.DB
+}
+
+// New creates a server for the given handler; workers are stopped and every pool in dbs is closed on shutdown
+func New(cfg config.ServerConfig, handler http.Handler, drainer Drainer, dbs []*sql.DB, workers ...interfaces.Worker) *Server {
+	return &Server{
+		http: &http.Server{
+			Addr:              ":" + strconv.Itoa(cfg.Port),
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+		config:  cfg,
+		drainer: drainer,
+		workers: workers,
+		dbs:     dbs,
+	}
+}
+
+// Run serves until ctx is cancelled (typically by SIGINT or SIGTERM), then shuts down gracefully
+func (s *Server) Run(ctx context.Context) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server starting", "port", s.config.Port)
+		serveErr <- s.http.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		// The listener failed before any shutdown was requested
+		return err
+	case <-ctx.Done():
+	}
+
+	return s.shutdown(serveErr)
+}
+
+// shutdown drains traffic, waits for in-flight requests and background work, then releases the databases
+func (s *Server) shutdown(serveErr <-chan error) error {
+	slog.Info("Shutdown requested; draining", "drain_delay", s.config.DrainDelay.String())
+
+	// Fail readiness first and keep serving, so load balancers stop sending traffic before the listener closes
+	if s.drainer != nil {
+		s.drainer.StartDraining()
+	}
+	time.Sleep(s.config.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+
+	// Stop accepting connections and wait for in-flight requests
+	if err := s.http.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, err)
+	}
+
+	// Background work may still be using the database, so it stops before the pool closes
+	for _, worker := range s.workers {
+		if err := worker.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, db := range s.dbs {
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		slog.Info("Server stopped")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+// Package testdb gives tests that need PostgreSQL a schema of their own in the database named by
+// TEST_DATABASE_URL, skipping them when it isn't set
+package testdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"theatre-management-system/src/migrations"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// URLVariable names the environment variable holding the test database's connection URL
+const URLVariable = "TEST_DATABASE_URL"
+
+// Open connects to an empty schema, dropped again when the test ends. Packages pass their own schema name,
+// since go test runs packages in parallel against the same database
+func Open(t testing.TB, schema string) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(URLVariable)
+	if dsn == "" {
+		t.Skipf("%s is not set", URLVariable)
+	}
+
+	admin := open(t, dsn)
+	reset := fmt.Sprintf("DROP SCHEMA IF EXISTS %[1]s CASCADE; CREATE SCHEMA %[1]s", schema)
+	if err := admin.Exec(reset).Error; err != nil {
+		t.Fatalf("create schema %s: %v", schema, err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schema))
+	})
+
+	// Tables go in the test's schema; PostGIS stays where the extension was installed
+	return open(t, withSearchPath(dsn, schema+",public"))
+}
+
+// Migrated connects to an empty schema with every migration applied
+func Migrated(t testing.TB, schema string) *gorm.DB {
+	t.Helper()
+	db := Open(t, schema)
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+	return db
+}
+
+// open connects quietly, closing the pool when the test ends
+func open(t testing.TB, dsn string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// withSearchPath sets the search_path of every connection made with dsn, in URL or keyword/value form
+func withSearchPath(dsn, searchPath string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + searchPath
+	}
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	query := parsed.Query()
+	query.Set("search_path", searchPath)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
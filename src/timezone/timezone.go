@@ -0,0 +1,34 @@
+// Package timezone finds the IANA timezone at a point on the map, for the services and the migrations that
+// derive a venue's timezone from its coordinates
+package timezone
+
+import (
+	"sync"
+	"theatre-management-system/src/constants"
+
+	"github.com/ringsaturn/tzf"
+)
+
+var (
+	finder     tzf.F
+	finderErr  error
+	finderOnce sync.Once
+)
+
+// FromCoordinates returns the timezone at latitude and longitude, or the default timezone where there is
+// none, such as at sea
+func FromCoordinates(latitude, longitude float64) (string, error) {
+	// The finder holds the timezone polygons in memory, so it is only built on first use
+	finderOnce.Do(func() {
+		finder, finderErr = tzf.NewDefaultFinder()
+	})
+	if finderErr != nil {
+		return "", finderErr
+	}
+
+	name := finder.GetTimezoneName(longitude, latitude)
+	if name == "" {
+		return constants.DefaultTimezone, nil
+	}
+	return name, nil
+}
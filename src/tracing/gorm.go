@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"errors"
+	"theatre-management-system/src/constants"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey stores a statement's span between its before and after callbacks
+const gormSpanKey = "tracing:span"
+
+// InstrumentGorm records a client span for every GORM statement, as a child of the span in the statement's context
+func InstrumentGorm(db *gorm.DB) error {
+	tracer := otel.Tracer(constants.TracerName)
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			_, span := tracer.Start(tx.Statement.Context, "gorm."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+			)
+			tx.InstanceSet(gormSpanKey, span)
+		}
+	}
+
+	after := func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(gormSpanKey)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		defer span.End()
+
+		// Placeholders only: bound values may be personal data
+		span.SetAttributes(
+			semconv.DBQueryText(tx.Statement.SQL.String()),
+			attribute.Int64(constants.TracingAttributeRowsAffected, tx.Statement.RowsAffected),
+		)
+		if tx.Statement.Table != "" {
+			span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+		}
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(codes.Error, tx.Error.Error())
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", after),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", after),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", after),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", after),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	)
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// nopWorker is returned when tracing is disabled, so there is nothing to flush
+type nopWorker struct{}
+
+// Shutdown does nothing
+func (nopWorker) Shutdown(context.Context) error { return nil }
+
+// Setup installs the global tracer provider and the W3C trace context propagator; the returned
+// worker flushes pending spans on shutdown. When tracing is disabled incoming traceparent headers
+// are still passed on, so logs keep the caller's trace ID.
+func Setup(ctx context.Context, cfg config.TracingConfig, out io.Writer) (interfaces.Worker, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.Enabled {
+		return nopWorker{}, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(cfg.SamplePercent) / 100))),
+	}
+
+	switch cfg.Exporter {
+	case constants.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			return nil, err
+		}
+		// Exported as each span ends, so tests see spans without waiting for a batch
+		options = append(options, sdktrace.WithSyncer(exporter))
+	default:
+		clientOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			clientOptions = append(clientOptions, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return provider, nil
+}
@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"theatre-management-system/src/constants"
+)
+
+// nonPublicPrefixes are ranges that aren't public but that netip's loopback, private, link-local,
+// multicast and unspecified checks don't cover
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, which reaches the embedded IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+}
+
+// checkAddress fails unless ip is a public unicast address, so a webhook can't be pointed at this host,
+// the network it runs in or a cloud metadata service such as 169.254.169.254 or fd00:ec2::254
+func checkAddress(ip netip.Addr) error {
+	ip = ip.Unmap()
+	public := ip.IsGlobalUnicast() && !ip.IsPrivate()
+	for _, prefix := range nonPublicPrefixes {
+		public = public && !prefix.Contains(ip)
+	}
+	if !public {
+		return fmt.Errorf("%s: %s is not a public address", constants.ErrorWebhookInvalid, ip)
+	}
+	return nil
+}
+
+// checkHost resolves host, an IP address or name, and fails if it can't be resolved or any address is not public
+func checkHost(ctx context.Context, resolver *net.Resolver, host string) error {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return checkAddress(ip)
+	}
+
+	ips, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%s: %w", constants.ErrorWebhookInvalid, err)
+	}
+	for _, ip := range ips {
+		if err := checkAddress(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDial refuses connections to addresses that are not public. It runs after DNS resolution, for the
+// address actually dialed, so a name that resolved to a public address when the webhook was saved can't
+// be rebound to an internal one later
+func checkDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return checkAddress(addrPort.Addr())
+}
@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{"93.184.215.14", true},
+		{"2606:2800:21f:cb07:6820:80da:af6b:8b2c", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.20.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := checkAddress(netip.MustParseAddr(tt.address))
+			if public := err == nil; public != tt.public {
+				t.Errorf("checkAddress(%s) = %v, want public %v", tt.address, err, tt.public)
+			}
+		})
+	}
+}
+
+func TestCheckHostRejectsLiteralAndResolvedPrivateAddresses(t *testing.T) {
+	ctx := context.Background()
+	for _, host := range []string{"127.0.0.1", "169.254.169.254", "localhost"} {
+		if err := checkHost(ctx, net.DefaultResolver, host); err == nil {
+			t.Errorf("checkHost(%s) accepted a non-public host", host)
+		}
+	}
+}
+
+func TestCheckDialRejectsPrivateAddresses(t *testing.T) {
+	if err := checkDial("tcp4", "10.0.0.1:443", nil); err == nil {
+		t.Error("checkDial accepted a private address")
+	}
+	if err := checkDial("tcp6", "[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443", nil); err != nil {
+		t.Errorf("checkDial rejected a public address: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"theatre-management-system/src/config"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+)
+
+// dispatcher polls for due deliveries and sends them to their subscriptions
+type dispatcher struct {
+	cfg      config.WebhooksConfig
+	webhooks interfaces.WebhookRepository
+	sender   interfaces.WebhookSender
+
+	// lastPurge is when completed deliveries past their retention were last removed
+	lastPurge time.Time
+
+	// stopping ends polling; ctx is only cancelled when shutdown runs out of time mid-batch
+	stopping chan struct{}
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// Start runs the dispatcher in the background until the returned worker is shut down.
+// Every instance may run one: claimed deliveries are leased, so each is sent by one dispatcher at a time.
+func Start(cfg config.WebhooksConfig, webhooks interfaces.WebhookRepository, sender interfaces.WebhookSender) interfaces.Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &dispatcher{
+		cfg:      cfg,
+		webhooks: webhooks,
+		sender:   sender,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go d.run()
+	return d
+}
+
+// run polls until stopped, draining full batches back to back
+func (d *dispatcher) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for d.dispatchBatch() == d.cfg.BatchSize {
+			if d.isStopping() {
+				return
+			}
+		}
+		d.purgeCompleted()
+
+		select {
+		case <-d.stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isStopping reports whether Shutdown has been called
+func (d *dispatcher) isStopping() bool {
+	select {
+	case <-d.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// dispatchBatch claims due deliveries and sends them concurrently, returning how many it claimed
+func (d *dispatcher) dispatchBatch() int {
+	deliveries, err := d.webhooks.ClaimDueDeliveries(d.ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		if d.ctx.Err() == nil {
+			slog.Error("Failed to claim webhook deliveries", "error", err)
+		}
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.dispatch(delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// dispatch sends one delivery, logs the attempt and updates the delivery and its subscription's failure count
+func (d *dispatcher) dispatch(delivery *models.WebhookDelivery) {
+	subscription := &delivery.Subscription
+	attempt := d.sender.Send(d.ctx, subscription, delivery)
+	now := time.Now()
+
+	delivery.LastResponseStatus = attempt.ResponseStatus
+	delivery.LastError = attempt.Error
+	switch {
+	case attempt.Succeeded():
+		delivery.Status = constants.WebhookDeliverySucceeded
+		delivery.CompletedAt = &now
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = constants.WebhookDeliveryFailed
+		delivery.CompletedAt = &now
+		slog.Warn("Webhook delivery failed",
+			"id", delivery.ID,
+			"webhook_id", subscription.ID,
+			"event", delivery.EventName,
+			"attempts", delivery.Attempts,
+			"error", attempt.Error,
+		)
+	default:
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err := d.webhooks.SaveAttempt(d.ctx, delivery, attempt); err != nil {
+		// The lease expires and the delivery is sent again, which receivers deduplicate by delivery ID
+		slog.Error("Failed to record webhook delivery attempt", "id", delivery.ID, "error", err)
+		return
+	}
+
+	if attempt.Succeeded() {
+		if err := d.webhooks.RecordSuccess(d.ctx, subscription.ID); err != nil {
+			slog.Error("Failed to reset webhook failures", "webhook_id", subscription.ID, "error", err)
+		}
+		return
+	}
+
+	reason := fmt.Sprintf("%d failed attempts in a row; last error: %s", d.cfg.DisableAfter, attempt.Error)
+	disabled, err := d.webhooks.RecordFailure(d.ctx, subscription.ID, d.cfg.DisableAfter, reason)
+	if err != nil {
+		slog.Error("Failed to count webhook failure", "webhook_id", subscription.ID, "error", err)
+		return
+	}
+	if disabled {
+		slog.Warn("Webhook disabled; its deliveries resume once it is re-enabled",
+			"webhook_id", subscription.ID,
+			"url", subscription.URL,
+			"reason", reason,
+		)
+	}
+}
+
+// backoff is the jittered exponential wait before the attempt after the given one
+func (d *dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < d.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, d.cfg.MaxBackoff)
+	return backoff + rand.N(backoff/4+1)
+}
+
+// purgeCompleted drops completed deliveries older than the retention period, at most once per purge interval
+func (d *dispatcher) purgeCompleted() {
+	if d.cfg.Retention <= 0 || time.Since(d.lastPurge) < constants.WebhookPurgeInterval {
+		return
+	}
+	d.lastPurge = time.Now()
+
+	purged, err := d.webhooks.DeleteDeliveriesCompletedBefore(d.ctx, time.Now().Add(-d.cfg.Retention))
+	if err != nil {
+		if d.ctx.Err() == nil {
+			slog.Error("Failed to purge completed webhook deliveries", "error", err)
+		}
+		return
+	}
+	if purged > 0 {
+		slog.Info("Purged completed webhook deliveries", "count", purged)
+	}
+}
+
+// Shutdown stops polling and waits for the batch in flight, cancelling its requests if ctx expires first
+func (d *dispatcher) Shutdown(ctx context.Context) error {
+	close(d.stopping)
+
+	var err error
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+		d.cancel()
+		<-d.done
+		err = ctx.Err()
+	}
+	d.cancel()
+
+	return err
+}
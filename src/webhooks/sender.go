@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"theatre-management-system/src/constants"
+	"theatre-management-system/src/interfaces"
+	"theatre-management-system/src/models"
+	"time"
+	"unicode/utf8"
+)
+
+// sender POSTs deliveries signed with their subscription's secret
+type sender struct {
+	client   *http.Client
+	resolver *net.Resolver
+}
+
+// NewSender creates a webhook sender whose requests, including reading the response, give up after timeout.
+// It only connects to public addresses, checked as each connection is dialed
+func NewSender(timeout time.Duration) interfaces.WebhookSender {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDial}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be the address dialed and checked, rather than the webhook's host
+	transport.Proxy = nil
+
+	return &sender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect is reported as a failure rather than followed, so a signed body never goes somewhere unexpected
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		resolver: net.DefaultResolver,
+	}
+}
+
+// CheckURL fails unless rawURL's host resolves only to public addresses
+func (s *sender) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return checkHost(ctx, s.resolver, u.Hostname())
+}
+
+// Send POSTs the delivery's payload and describes the outcome; any 2xx answer counts as success
+func (s *sender) Send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) *models.WebhookDeliveryAttempt {
+	attempt := &models.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+	}
+
+	start := time.Now()
+	defer func() {
+		attempt.DurationMs = int(time.Since(start).Milliseconds())
+		attempt.Error = truncate(attempt.Error, constants.OutboxMaxErrorChars)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.WebhookUserAgent)
+	req.Header.Set(constants.HeaderWebhookID, delivery.ID.String())
+	req.Header.Set(constants.HeaderWebhookEvent, delivery.EventName)
+	req.Header.Set(constants.HeaderWebhookTimestamp, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(constants.HeaderWebhookSignature, Sign(subscription.Secret, start, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	attempt.ResponseStatus = &status
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, constants.WebhookMaxResponseBodyBytes))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // lets the connection be reused
+	attempt.ResponseBody = printable(body)
+
+	if !attempt.Succeeded() {
+		attempt.Error = fmt.Sprintf("endpoint responded %s", resp.Status)
+	}
+	return attempt
+}
+
+// Sign returns the signature header value for a body sent at timestamp: t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">.
+// Receivers recompute it with their copy of the secret and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + t + "," + constants.WebhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// printable makes a response body safe to store as text: a body cut off mid-character, binary data and NUL bytes,
+// which Postgres text columns reject, are replaced
+func printable(body []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(body), "\uFFFD"), "\x00", "\uFFFD")
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}